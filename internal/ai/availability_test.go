@@ -0,0 +1,97 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestMakeCombatDecisionUnavailable(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"timeout", func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(500 * time.Millisecond):
+			}
+		}},
+		{"server error", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newTestDecisionMaker(t, tc.handler)
+			d.options.MaxAttempts = 1
+			if err := d.SetTimeout(50 * time.Millisecond); err != nil {
+				t.Fatalf("SetTimeout: %v", err)
+			}
+
+			situation := testSituation("t1")
+			start := time.Now()
+			decision, err := d.MakeCombatDecision(context.Background(), situation.CurrentLocation,
+				situation.Threat, situation.HealthStatus, situation.AvailableWeapons)
+			if !errors.Is(err, ErrUnavailable) {
+				t.Fatalf("MakeCombatDecision = %+v, %v; want ErrUnavailable", decision, err)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("MakeCombatDecision took %s, want it bounded by the timeout", elapsed)
+			}
+
+			_, err = d.ShouldEngageProactively(context.Background(), *situation.Threat, common.Location{}, situation.HealthStatus)
+			if !errors.Is(err, ErrUnavailable) {
+				t.Errorf("ShouldEngageProactively error = %v, want ErrUnavailable", err)
+			}
+		})
+	}
+}
+
+func TestSetTimeoutRejectsNonPositive(t *testing.T) {
+	d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {})
+	if err := d.SetTimeout(0); err == nil {
+		t.Error("SetTimeout(0) succeeded, want an error")
+	}
+}
+
+func TestHealthy(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		status  int
+		healthy bool
+	}{
+		{"ok", http.StatusOK, true},
+		{"server error", http.StatusInternalServerError, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var path string
+			d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(tc.status)
+			})
+
+			err := d.Healthy(context.Background())
+			if path != "/api/tags" {
+				t.Errorf("probed %q, want /api/tags", path)
+			}
+			if tc.healthy && err != nil {
+				t.Errorf("Healthy = %v, want nil", err)
+			}
+			if !tc.healthy && !errors.Is(err, ErrUnavailable) {
+				t.Errorf("Healthy = %v, want ErrUnavailable", err)
+			}
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {})
+		d.baseURL = "http://127.0.0.1:1"
+		if err := d.Healthy(context.Background()); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Healthy = %v, want ErrUnavailable", err)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package ai
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"t800/internal/common"
+)
+
+const (
+	// defaultCacheTTL is how long a cached combat decision stays valid
+	defaultCacheTTL = 2 * time.Second
+	// defaultCacheSize is how many situations the cache remembers
+	defaultCacheSize = 128
+
+	// locationQuantum is the grid in meters locations are rounded to
+	locationQuantum = 1.0
+	// healthQuantum is the width in percent of a health bucket
+	healthQuantum = 10.0
+	// severityQuantum is the width of a severity bucket
+	severityQuantum = 2
+)
+
+// CacheStats counts combat decisions served from the cache and those that
+// needed an upstream call
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// cacheEntry is a cached decision and when it expires
+type cacheEntry struct {
+	key      string
+	decision CombatDecision
+	expires  time.Time
+}
+
+// decisionCache is a least recently used cache of combat decisions keyed by
+// a quantized situation fingerprint
+type decisionCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+	stats   CacheStats
+	now     func() time.Time
+}
+
+// newDecisionCache creates a cache; a zero TTL or size disables it
+func newDecisionCache(ttl time.Duration, size int) *decisionCache {
+	return &decisionCache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// enabled reports whether decisions are cached at all
+func (c *decisionCache) enabled() bool {
+	return c.ttl > 0 && c.size > 0
+}
+
+// get returns a copy of the unexpired decision cached under key
+func (c *decisionCache) get(key string) (*CombatDecision, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		if c.now().Before(entry.expires) {
+			c.order.MoveToFront(elem)
+			c.stats.Hits++
+			decision := entry.decision
+			return &decision, true
+		}
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+	c.stats.Misses++
+	return nil, false
+}
+
+// put caches a decision under key, evicting the least recently used entry
+// when full
+func (c *decisionCache) put(key string, decision CombatDecision) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = &cacheEntry{key: key, decision: decision, expires: expires}
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, decision: decision, expires: expires})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// snapshot returns the hit and miss counts
+func (c *decisionCache) snapshot() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// situationKey fingerprints a tactical picture, rounding locations, severity
+// and health so that small changes still share a cached decision
+func situationKey(loc common.Location, threat *common.Threat, healthStatus map[string]float64, weapons []string) string {
+	var b strings.Builder
+	quantize := func(l common.Location) string {
+		return fmt.Sprintf("%.0f,%.0f,%.0f",
+			math.Round(l.X/locationQuantum), math.Round(l.Y/locationQuantum), math.Round(l.Z/locationQuantum))
+	}
+	fmt.Fprintf(&b, "%s|%s|%s|%d|", quantize(loc), threat.ID, quantize(threat.Location), threat.Severity/severityQuantum)
+
+	parts := make([]string, 0, len(healthStatus))
+	for part := range healthStatus {
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	for _, part := range parts {
+		fmt.Fprintf(&b, "%s=%d,", part, int(healthStatus[part]/healthQuantum))
+	}
+
+	sorted := append([]string(nil), weapons...)
+	sort.Strings(sorted)
+	fmt.Fprintf(&b, "|%s", strings.Join(sorted, ","))
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+// countingDecisionMaker returns a decision maker whose upstream always
+// attacks, and the number of upstream calls it has made
+func countingDecisionMaker(t *testing.T) (*DecisionMaker, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		respond(t, w, attackDecision)
+	})
+	return d, &calls
+}
+
+// decide asks for a combat decision in situation, failing the test on error
+func decide(t *testing.T, d *DecisionMaker, situation Situation) {
+	t.Helper()
+	if _, err := d.MakeCombatDecision(context.Background(), situation.CurrentLocation,
+		situation.Threat, situation.HealthStatus, situation.AvailableWeapons); err != nil {
+		t.Fatalf("MakeCombatDecision: %v", err)
+	}
+}
+
+func TestIdenticalSituationsShareOneUpstreamCall(t *testing.T) {
+	d, calls := countingDecisionMaker(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d.cache.now = func() time.Time { return now }
+
+	situation := testSituation("t1")
+	situation.HealthStatus = map[string]float64{"body": 85}
+	decide(t, d, situation)
+	decide(t, d, situation)
+
+	// Small changes fall within the same quantized situation
+	jittered := testSituation("t1")
+	jittered.CurrentLocation = common.Location{X: 0.2}
+	jittered.HealthStatus = map[string]float64{"body": 88}
+	decide(t, d, jittered)
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("upstream called %d times for one situation, want 1", got)
+	}
+	if stats := d.CacheStats(); stats != (CacheStats{Hits: 2, Misses: 1}) {
+		t.Errorf("cache stats = %+v, want 2 hits and 1 miss", stats)
+	}
+
+	decide(t, d, testSituation("t2"))
+	if got := calls.Load(); got != 2 {
+		t.Errorf("upstream called %d times after a new threat, want 2", got)
+	}
+
+	now = now.Add(defaultCacheTTL)
+	decide(t, d, situation)
+	if got := calls.Load(); got != 3 {
+		t.Errorf("upstream called %d times after the TTL, want 3", got)
+	}
+}
+
+func TestDecisionCacheSizeAndDisabling(t *testing.T) {
+	d, calls := countingDecisionMaker(t)
+	if err := d.SetCache(time.Minute, 1); err != nil {
+		t.Fatalf("SetCache: %v", err)
+	}
+	decide(t, d, testSituation("t1"))
+	decide(t, d, testSituation("t2"))
+	decide(t, d, testSituation("t1"))
+	if got := calls.Load(); got != 3 {
+		t.Errorf("upstream called %d times with room for one situation, want 3", got)
+	}
+
+	if err := d.SetCache(0, 0); err != nil {
+		t.Fatalf("SetCache: %v", err)
+	}
+	calls.Store(0)
+	decide(t, d, testSituation("t1"))
+	decide(t, d, testSituation("t1"))
+	if got := calls.Load(); got != 2 {
+		t.Errorf("upstream called %d times with caching disabled, want 2", got)
+	}
+
+	if err := d.SetCache(-time.Second, 1); err == nil {
+		t.Error("SetCache accepted a negative TTL")
+	}
+}
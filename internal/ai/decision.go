@@ -4,31 +4,132 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"t800/internal/common"
+	"t800/internal/config"
 	"t800/internal/monitoring"
 )
 
 // DecisionMaker handles AI-based decision making
 type DecisionMaker struct {
-	baseURL string
-	logger  *monitoring.Logger
-	model   string
+	baseURL        string
+	logger         *monitoring.Logger
+	model          string
+	options        Options
+	maxConcurrency int
+	client         *http.Client
+	cache          *decisionCache
+}
+
+// defaultModel is the Ollama model used when none is configured
+const defaultModel = "llama3.2"
+
+// Options tunes the requests sent to Ollama
+type Options struct {
+	Model        string   // Model name; defaults to llama3.2
+	Temperature  *float64 // Sampling temperature; nil leaves the model default
+	MaxTokens    int      // Maximum tokens to generate; zero leaves the model default
+	SystemPrompt string   // Optional system prompt sent ahead of every request
+	MaxAttempts  int      // Attempts per request before giving up; defaults to 3
+}
+
+// OptionsFromEnv reads options from OLLAMA_MODEL, OLLAMA_TEMPERATURE,
+// OLLAMA_MAX_TOKENS, OLLAMA_SYSTEM_PROMPT and OLLAMA_MAX_ATTEMPTS
+func OptionsFromEnv() (Options, error) {
+	opts := Options{
+		Model:        os.Getenv("OLLAMA_MODEL"),
+		SystemPrompt: os.Getenv("OLLAMA_SYSTEM_PROMPT"),
+	}
+	if value := os.Getenv("OLLAMA_TEMPERATURE"); value != "" {
+		temperature, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return Options{}, fmt.Errorf("invalid OLLAMA_TEMPERATURE: %v", err)
+		}
+		opts.Temperature = &temperature
+	}
+	if value := os.Getenv("OLLAMA_MAX_TOKENS"); value != "" {
+		maxTokens, err := strconv.Atoi(value)
+		if err != nil {
+			return Options{}, fmt.Errorf("invalid OLLAMA_MAX_TOKENS: %v", err)
+		}
+		opts.MaxTokens = maxTokens
+	}
+	if value := os.Getenv("OLLAMA_MAX_ATTEMPTS"); value != "" {
+		maxAttempts, err := strconv.Atoi(value)
+		if err != nil {
+			return Options{}, fmt.Errorf("invalid OLLAMA_MAX_ATTEMPTS: %v", err)
+		}
+		opts.MaxAttempts = maxAttempts
+	}
+	return opts, nil
+}
+
+// defaultMaxConcurrency bounds parallel AI requests when deciding for several threats
+const defaultMaxConcurrency = 4
+
+// defaultTimeout bounds a single round-trip to the Ollama server
+const defaultTimeout = 5 * time.Second
+
+// defaultMaxAttempts is how many times a failing AI request is tried
+const defaultMaxAttempts = 3
+
+// retryBackoff is the wait before the first retry; it doubles on each retry
+const retryBackoff = 250 * time.Millisecond
+
+// ErrUnavailable is returned when the Ollama server cannot be reached, times
+// out or fails the request, so callers can fall back to their heuristics
+var ErrUnavailable = errors.New("AI decision service unavailable")
+
+// ErrInvalidDecision is returned when the AI's decision does not parse or is
+// not one the robot can act on, so callers can fall back to their heuristics
+var ErrInvalidDecision = errors.New("invalid AI decision")
+
+// Situation is the tactical picture for a single threat
+type Situation struct {
+	CurrentLocation  common.Location
+	Threat           *common.Threat
+	HealthStatus     map[string]float64
+	AvailableWeapons []string
 }
 
 // CombatDecision represents the AI's decision for combat
 type CombatDecision struct {
-	Action      string  `json:"action"`       // "move", "attack", "defend", "retreat"
-	Target      string  `json:"target"`       // Target ID if applicable
-	Weapon      string  `json:"weapon"`       // Weapon to use if attacking
-	Priority    int     `json:"priority"`     // Priority level (1-10)
-	Confidence  float64 `json:"confidence"`   // Confidence in the decision (0-1)
-	Explanation string  `json:"explanation"`  // Explanation of the decision
+	Action      string  `json:"action"`      // "move", "attack", "defend", "retreat"
+	Target      string  `json:"target"`      // Target ID if applicable
+	Weapon      string  `json:"weapon"`      // Weapon to use if attacking
+	Priority    int     `json:"priority"`    // Priority level (1-10)
+	Confidence  float64 `json:"confidence"`  // Confidence in the decision (0-1)
+	Explanation string  `json:"explanation"` // Explanation of the decision
+}
+
+// combatActions are the actions a CombatDecision may take
+var combatActions = map[string]bool{"move": true, "attack": true, "defend": true, "retreat": true}
+
+// validate checks that the decision names a known action, has a priority of
+// 1-10 and a confidence of 0-1, and names a weapon if it attacks
+func (c *CombatDecision) validate() error {
+	if !combatActions[c.Action] {
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidDecision, c.Action)
+	}
+	if c.Priority < 1 || c.Priority > 10 {
+		return fmt.Errorf("%w: priority %d outside 1-10", ErrInvalidDecision, c.Priority)
+	}
+	if c.Confidence < 0 || c.Confidence > 1 {
+		return fmt.Errorf("%w: confidence %.2f outside 0-1", ErrInvalidDecision, c.Confidence)
+	}
+	if c.Action == "attack" && c.Weapon == "" {
+		return fmt.Errorf("%w: attack without a weapon", ErrInvalidDecision)
+	}
+	return nil
 }
 
 // EngagementDecision represents the AI's decision for threat engagement
@@ -38,26 +139,133 @@ type EngagementDecision struct {
 	Explanation  string  `json:"explanation"`
 }
 
-// NewDecisionMaker creates a new AI decision maker
+// NewDecisionMaker creates a new AI decision maker configured from the environment
 func NewDecisionMaker(logger *monitoring.Logger) (*DecisionMaker, error) {
+	opts, err := OptionsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewDecisionMakerWithOptions(logger, opts)
+}
+
+// NewDecisionMakerWithOptions creates a new AI decision maker with the given
+// request options; the server is still read from OLLAMA_BASE_URL
+func NewDecisionMakerWithOptions(logger *monitoring.Logger, opts Options) (*DecisionMaker, error) {
 	baseURL := os.Getenv("OLLAMA_BASE_URL")
 	if baseURL == "" {
 		baseURL = "http://localhost:11434"
 	}
 
-	model := os.Getenv("OLLAMA_MODEL")
-	if model == "" {
-		model = "llama3.2" // Default model
+	if opts.Model == "" {
+		opts.Model = defaultModel
+	}
+	if opts.Temperature != nil && *opts.Temperature < 0 {
+		return nil, fmt.Errorf("temperature must not be negative")
+	}
+	if opts.MaxTokens < 0 {
+		return nil, fmt.Errorf("max tokens must not be negative")
+	}
+	if opts.MaxAttempts == 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.MaxAttempts < 1 {
+		return nil, fmt.Errorf("max attempts must be at least 1")
 	}
 
 	return &DecisionMaker{
-		baseURL: baseURL,
-		logger:  logger,
-		model:   model,
+		baseURL:        baseURL,
+		logger:         logger,
+		model:          opts.Model,
+		options:        opts,
+		maxConcurrency: defaultMaxConcurrency,
+		client:         &http.Client{Timeout: defaultTimeout},
+		cache:          newDecisionCache(defaultCacheTTL, defaultCacheSize),
 	}, nil
 }
 
-// callOllama makes a request to the Ollama API and returns the cleaned response
+// NewDecisionMakerFromConfig creates an AI decision maker from config
+// settings, ignoring the OLLAMA_* environment variables
+func NewDecisionMakerFromConfig(logger *monitoring.Logger, cfg config.AISettings) (*DecisionMaker, error) {
+	d, err := NewDecisionMakerWithOptions(logger, Options{
+		Model:        cfg.Model,
+		Temperature:  cfg.Temperature,
+		MaxTokens:    cfg.MaxTokens,
+		SystemPrompt: cfg.SystemPrompt,
+		MaxAttempts:  cfg.MaxAttempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.BaseURL != "" {
+		d.baseURL = cfg.BaseURL
+	}
+	if err := d.SetTimeout(time.Duration(cfg.TimeoutSeconds * float64(time.Second))); err != nil {
+		return nil, err
+	}
+	if err := d.SetCache(time.Duration(cfg.CacheTTLSeconds*float64(time.Second)), cfg.CacheSize); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SetTimeout sets how long a single AI request may take before it is abandoned
+func (d *DecisionMaker) SetTimeout(timeout time.Duration) error {
+	if timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	d.client.Timeout = timeout
+	return nil
+}
+
+// SetCache sets how long combat decisions are reused for an unchanged
+// situation and how many situations are remembered; zero for either
+// disables caching. Previously cached decisions are discarded.
+func (d *DecisionMaker) SetCache(ttl time.Duration, size int) error {
+	if ttl < 0 || size < 0 {
+		return fmt.Errorf("cache TTL and size must not be negative")
+	}
+	d.cache = newDecisionCache(ttl, size)
+	return nil
+}
+
+// CacheStats returns how many combat decisions were served from the cache
+// and how many needed an upstream call
+func (d *DecisionMaker) CacheStats() CacheStats {
+	return d.cache.snapshot()
+}
+
+// Healthy probes the Ollama server's model listing, returning ErrUnavailable
+// if it cannot be reached or does not answer successfully
+func (d *DecisionMaker) Healthy(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", d.baseURL+"/api/tags", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", ErrUnavailable, resp.Status)
+	}
+	return nil
+}
+
+// SetMaxConcurrency sets how many AI requests MakeCombatDecisions may have in flight
+func (d *DecisionMaker) SetMaxConcurrency(n int) error {
+	if n < 1 {
+		return fmt.Errorf("max concurrency must be at least 1")
+	}
+	d.maxConcurrency = n
+	return nil
+}
+
+// callOllama makes a request to the Ollama API and parses the cleaned
+// response into result. Network errors, server errors and unparseable
+// decisions are retried with exponential backoff; a 4xx response is not.
 func (d *DecisionMaker) callOllama(ctx context.Context, prompt string, result interface{}) error {
 	// Prepare the request body
 	requestBody := map[string]interface{}{
@@ -66,46 +274,91 @@ func (d *DecisionMaker) callOllama(ctx context.Context, prompt string, result in
 		"stream": false,
 		"format": "json",
 	}
+	if d.options.SystemPrompt != "" {
+		requestBody["system"] = d.options.SystemPrompt
+	}
+	modelOptions := map[string]interface{}{}
+	if d.options.Temperature != nil {
+		modelOptions["temperature"] = *d.options.Temperature
+	}
+	if d.options.MaxTokens > 0 {
+		modelOptions["num_predict"] = d.options.MaxTokens
+	}
+	if len(modelOptions) > 0 {
+		requestBody["options"] = modelOptions
+	}
 
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request: %v", err)
 	}
 
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := d.attemptOllama(ctx, jsonData, result)
+		if err == nil {
+			return nil
+		}
+		if !retry {
+			return err
+		}
+		if attempt >= d.options.MaxAttempts {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		d.logger.Info(fmt.Sprintf("AI request failed (attempt %d of %d), retrying in %s: %v",
+			attempt, d.options.MaxAttempts, backoff, err))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// attemptOllama sends a single request to the Ollama API, reporting whether
+// a failure is worth retrying
+func (d *DecisionMaker) attemptOllama(ctx context.Context, jsonData []byte, result interface{}) (bool, error) {
 	// Create and send the request
-	req, err := http.NewRequestWithContext(ctx, "POST", d.baseURL+"/api/generate", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", d.baseURL+"/api/generate", bytes.NewReader(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
+		return false, fmt.Errorf("failed to create request: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := d.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to get AI decision: %v", err)
+		return ctx.Err() == nil, fmt.Errorf("%w: failed to get AI decision: %v", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		// A 4xx means the request itself is bad, so repeating it cannot help
+		retry := resp.StatusCode < 400 || resp.StatusCode >= 500
+		return retry, fmt.Errorf("%w: failed to get AI decision: %s", ErrUnavailable, resp.Status)
+	}
+
 	// Read and parse the response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %v", err)
+		return true, fmt.Errorf("%w: failed to read response: %v", ErrUnavailable, err)
 	}
 
 	var ollamaResponse struct {
 		Response string `json:"response"`
 	}
 	if err := json.Unmarshal(body, &ollamaResponse); err != nil {
-		return fmt.Errorf("failed to parse Ollama response: %v", err)
+		return true, fmt.Errorf("%w: failed to parse Ollama response: %v", ErrUnavailable, err)
 	}
 
 	// Clean and parse the response
 	cleanResponse := d.cleanResponse(ollamaResponse.Response)
 	if err := json.Unmarshal([]byte(cleanResponse), result); err != nil {
-		return fmt.Errorf("failed to parse AI decision: %v (response: %s)", err, cleanResponse)
+		return true, fmt.Errorf("%w: failed to parse AI decision: %v (response: %s)", ErrInvalidDecision, err, cleanResponse)
 	}
 
-	return nil
+	return false, nil
 }
 
 // cleanResponse removes markdown code block markers and trims whitespace
@@ -117,7 +370,10 @@ func (d *DecisionMaker) cleanResponse(response string) string {
 	return strings.TrimSpace(clean)
 }
 
-// MakeCombatDecision makes a decision based on current state and threats
+// MakeCombatDecision makes a decision based on current state and threats.
+// A decision made for a similar situation within the cache TTL is reused
+// without consulting the AI. A decision the robot cannot act on is rejected
+// with ErrInvalidDecision.
 func (d *DecisionMaker) MakeCombatDecision(
 	ctx context.Context,
 	currentLoc common.Location,
@@ -125,6 +381,15 @@ func (d *DecisionMaker) MakeCombatDecision(
 	healthStatus map[string]float64,
 	availableWeapons []string,
 ) (*CombatDecision, error) {
+	var key string
+	if d.cache.enabled() {
+		key = situationKey(currentLoc, activeThreat, healthStatus, availableWeapons)
+		if decision, ok := d.cache.get(key); ok {
+			d.logger.Debug(fmt.Sprintf("AI Decision (cached): %s for threat %s", decision.Action, activeThreat.ID))
+			return decision, nil
+		}
+	}
+
 	prompt := fmt.Sprintf(`You are the AI core of a T800 combat robot. Analyze the following situation and make a tactical decision.
 
 Current Location: (%.2f, %.2f, %.2f)
@@ -160,6 +425,12 @@ Do not include any text before or after the JSON object.`,
 	if err := d.callOllama(ctx, prompt, &decision); err != nil {
 		return nil, err
 	}
+	if err := decision.validate(); err != nil {
+		return nil, err
+	}
+	if key != "" {
+		d.cache.put(key, decision)
+	}
 
 	d.logger.Info(fmt.Sprintf("AI Decision: %s (Confidence: %.2f) - %s",
 		decision.Action, decision.Confidence, decision.Explanation))
@@ -208,4 +479,41 @@ Do not include any text before or after the JSON object.`,
 		decision.ShouldEngage, decision.Confidence, decision.Explanation))
 
 	return decision.ShouldEngage, nil
-} 
\ No newline at end of file
+}
+
+// MakeCombatDecisions makes a decision for each situation concurrently, with
+// at most maxConcurrency requests in flight. Results preserve the order of
+// situations. A failed decision leaves a nil entry so the caller can fall
+// back to its heuristic for that threat; an error is only returned if the
+// context ends before all decisions are made.
+func (d *DecisionMaker) MakeCombatDecisions(ctx context.Context, situations []Situation) ([]*CombatDecision, error) {
+	decisions := make([]*CombatDecision, len(situations))
+	sem := make(chan struct{}, d.maxConcurrency)
+
+	var wg sync.WaitGroup
+	for i, situation := range situations {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return decisions, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(i int, situation Situation) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			decision, err := d.MakeCombatDecision(ctx, situation.CurrentLocation, situation.Threat,
+				situation.HealthStatus, situation.AvailableWeapons)
+			if err != nil {
+				d.logger.LogError(err, fmt.Sprintf("AI decision failed for threat %s", situation.Threat.ID))
+				return
+			}
+			decisions[i] = decision
+		}(i, situation)
+	}
+	wg.Wait()
+
+	return decisions, ctx.Err()
+}
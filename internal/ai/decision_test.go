@@ -0,0 +1,126 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+	"t800/internal/monitoring"
+)
+
+// attackDecision is a valid decision the test servers answer with
+var attackDecision = CombatDecision{
+	Action:      "attack",
+	Target:      "t1",
+	Weapon:      "plasma_cannon",
+	Priority:    5,
+	Confidence:  0.9,
+	Explanation: "threat in range",
+}
+
+// newTestDecisionMaker returns a decision maker talking to a test server
+// running handler
+func newTestDecisionMaker(t *testing.T, handler http.HandlerFunc) *DecisionMaker {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	d, err := NewDecisionMakerWithOptions(monitoring.NewLogger(), Options{})
+	if err != nil {
+		t.Fatalf("NewDecisionMakerWithOptions: %v", err)
+	}
+	d.baseURL = server.URL
+	return d
+}
+
+// respond writes an Ollama generate response carrying decision as its text
+func respond(t *testing.T, w http.ResponseWriter, decision interface{}) {
+	t.Helper()
+	text, err := json.Marshal(decision)
+	if err != nil {
+		t.Errorf("marshal decision: %v", err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(map[string]string{"response": string(text)}); err != nil {
+		t.Errorf("encode response: %v", err)
+	}
+}
+
+// testSituation returns a situation for a threat with the given ID
+func testSituation(id string) Situation {
+	return Situation{
+		Threat:           &common.Threat{ID: id, Severity: 5, Location: common.Location{X: 30}},
+		HealthStatus:     map[string]float64{"body": 100},
+		AvailableWeapons: []string{"plasma_cannon"},
+	}
+}
+
+func TestMakeCombatDecisionsBoundsConcurrency(t *testing.T) {
+	const situations, limit = 10, 3
+	var (
+		mu             sync.Mutex
+		inFlight, peak int
+	)
+	d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		respond(t, w, attackDecision)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+	if err := d.SetMaxConcurrency(limit); err != nil {
+		t.Fatalf("SetMaxConcurrency: %v", err)
+	}
+
+	var batch []Situation
+	for i := 0; i < situations; i++ {
+		batch = append(batch, testSituation(fmt.Sprintf("t%d", i)))
+	}
+	decisions, err := d.MakeCombatDecisions(context.Background(), batch)
+	if err != nil {
+		t.Fatalf("MakeCombatDecisions: %v", err)
+	}
+
+	if len(decisions) != situations {
+		t.Fatalf("got %d decisions, want %d", len(decisions), situations)
+	}
+	for i, decision := range decisions {
+		if decision == nil || decision.Action != "attack" {
+			t.Errorf("decision %d = %+v, want attack", i, decision)
+		}
+	}
+	if peak > limit {
+		t.Errorf("%d requests in flight at once, want at most %d", peak, limit)
+	}
+	if peak < 2 {
+		t.Errorf("requests never overlapped (peak %d)", peak)
+	}
+}
+
+func TestMakeCombatDecisionsLeavesFailuresNil(t *testing.T) {
+	d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	decisions, err := d.MakeCombatDecisions(context.Background(), []Situation{testSituation("t1"), testSituation("t2")})
+	if err != nil {
+		t.Fatalf("MakeCombatDecisions: %v", err)
+	}
+	for i, decision := range decisions {
+		if decision != nil {
+			t.Errorf("decision %d = %+v, want nil after a failed request", i, decision)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"t800/internal/common"
+	"t800/internal/monitoring"
+)
+
+func TestRequestBodyCarriesOptions(t *testing.T) {
+	temperature := 0.2
+	for _, tc := range []struct {
+		name        string
+		opts        Options
+		model       string
+		temperature interface{}
+		system      interface{}
+	}{
+		{"defaults", Options{}, "llama3.2", nil, nil},
+		{"configured", Options{Model: "mistral", Temperature: &temperature, SystemPrompt: "be terse"}, "mistral", 0.2, "be terse"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var bodies []map[string]interface{}
+			d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decode request: %v", err)
+				}
+				bodies = append(bodies, body)
+				if len(bodies) == 1 {
+					respond(t, w, attackDecision)
+				} else {
+					respond(t, w, EngagementDecision{ShouldEngage: true, Confidence: 0.8})
+				}
+			})
+			configured, err := NewDecisionMakerWithOptions(monitoring.NewLogger(), tc.opts)
+			if err != nil {
+				t.Fatalf("NewDecisionMakerWithOptions: %v", err)
+			}
+			configured.baseURL = d.baseURL
+
+			situation := testSituation("t1")
+			if _, err := configured.MakeCombatDecision(context.Background(), situation.CurrentLocation,
+				situation.Threat, situation.HealthStatus, situation.AvailableWeapons); err != nil {
+				t.Fatalf("MakeCombatDecision: %v", err)
+			}
+			if _, err := configured.ShouldEngageProactively(context.Background(), *situation.Threat,
+				common.Location{}, situation.HealthStatus); err != nil {
+				t.Fatalf("ShouldEngageProactively: %v", err)
+			}
+
+			if len(bodies) != 2 {
+				t.Fatalf("server saw %d requests, want 2", len(bodies))
+			}
+			for i, body := range bodies {
+				if body["model"] != tc.model {
+					t.Errorf("request %d model = %v, want %s", i, body["model"], tc.model)
+				}
+				var got interface{}
+				if options, ok := body["options"].(map[string]interface{}); ok {
+					got = options["temperature"]
+				}
+				if got != tc.temperature {
+					t.Errorf("request %d temperature = %v, want %v", i, got, tc.temperature)
+				}
+				if body["system"] != tc.system {
+					t.Errorf("request %d system = %v, want %v", i, body["system"], tc.system)
+				}
+			}
+		})
+	}
+}
+
+func TestOptionsFromEnv(t *testing.T) {
+	t.Setenv("OLLAMA_MODEL", "mistral")
+	t.Setenv("OLLAMA_TEMPERATURE", "0.4")
+	t.Setenv("OLLAMA_SYSTEM_PROMPT", "be terse")
+
+	opts, err := OptionsFromEnv()
+	if err != nil {
+		t.Fatalf("OptionsFromEnv: %v", err)
+	}
+	if opts.Model != "mistral" || opts.Temperature == nil || *opts.Temperature != 0.4 || opts.SystemPrompt != "be terse" {
+		t.Errorf("OptionsFromEnv = %+v, want mistral at 0.4 with a system prompt", opts)
+	}
+
+	t.Setenv("OLLAMA_TEMPERATURE", "warm")
+	if _, err := OptionsFromEnv(); err == nil {
+		t.Error("OptionsFromEnv accepted a non-numeric temperature")
+	}
+}
@@ -0,0 +1,129 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCallOllamaRetriesUntilSuccess(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fail func(w http.ResponseWriter)
+	}{
+		{"server error", func(w http.ResponseWriter) { w.WriteHeader(http.StatusInternalServerError) }},
+		{"malformed decision", func(w http.ResponseWriter) { w.Write([]byte(`{"response": "{not json"}`)) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= 2 {
+					tc.fail(w)
+					return
+				}
+				respond(t, w, attackDecision)
+			})
+
+			situation := testSituation("t1")
+			decision, err := d.MakeCombatDecision(context.Background(), situation.CurrentLocation,
+				situation.Threat, situation.HealthStatus, situation.AvailableWeapons)
+			if err != nil {
+				t.Fatalf("MakeCombatDecision: %v", err)
+			}
+			if decision.Action != "attack" {
+				t.Errorf("decision = %+v, want attack", decision)
+			}
+			if n := calls.Load(); n != 3 {
+				t.Errorf("server saw %d calls, want 3", n)
+			}
+		})
+	}
+}
+
+func TestCallOllamaDoesNotRetryBadRequest(t *testing.T) {
+	var calls atomic.Int32
+	d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	situation := testSituation("t1")
+	if _, err := d.MakeCombatDecision(context.Background(), situation.CurrentLocation,
+		situation.Threat, situation.HealthStatus, situation.AvailableWeapons); err == nil {
+		t.Fatal("MakeCombatDecision succeeded against a 400")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("server saw %d calls, want 1", n)
+	}
+}
+
+func TestCallOllamaReportsAttemptsWhenExhausted(t *testing.T) {
+	var calls atomic.Int32
+	d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	situation := testSituation("t1")
+	_, err := d.MakeCombatDecision(context.Background(), situation.CurrentLocation,
+		situation.Threat, situation.HealthStatus, situation.AvailableWeapons)
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("MakeCombatDecision error = %v, want ErrUnavailable", err)
+	}
+	if !strings.Contains(err.Error(), "3 attempts") {
+		t.Errorf("error %q does not report 3 attempts", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("server saw %d calls, want 3", n)
+	}
+}
+
+func TestCallOllamaReportsMalformedPayloadAsInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body string
+		want error
+	}{
+		{"malformed decision", `{"response": "{not json"}`, ErrInvalidDecision},
+		{"malformed envelope", `not json`, ErrUnavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tc.body))
+			})
+			d.options.MaxAttempts = 1
+
+			situation := testSituation("t1")
+			if _, err := d.MakeCombatDecision(context.Background(), situation.CurrentLocation,
+				situation.Threat, situation.HealthStatus, situation.AvailableWeapons); !errors.Is(err, tc.want) {
+				t.Errorf("MakeCombatDecision error = %v, want %v", err, tc.want)
+			}
+			if _, err := d.ShouldEngageProactively(context.Background(), *situation.Threat,
+				situation.CurrentLocation, situation.HealthStatus); !errors.Is(err, tc.want) {
+				t.Errorf("ShouldEngageProactively error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCallOllamaStopsRetryingWhenContextEnds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		cancel()
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	situation := testSituation("t1")
+	if _, err := d.MakeCombatDecision(ctx, situation.CurrentLocation,
+		situation.Threat, situation.HealthStatus, situation.AvailableWeapons); err == nil {
+		t.Fatal("MakeCombatDecision succeeded after the context ended")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("server saw %d calls, want 1 before the context ended", n)
+	}
+}
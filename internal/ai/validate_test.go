@@ -0,0 +1,55 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMalformedDecisionsAreRejected(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		modify func(d *CombatDecision)
+		want   string
+	}{
+		{"unknown action", func(d *CombatDecision) { d.Action = "flee" }, `unknown action "flee"`},
+		{"priority too low", func(d *CombatDecision) { d.Priority = 0 }, "priority 0 outside 1-10"},
+		{"priority too high", func(d *CombatDecision) { d.Priority = 11 }, "priority 11 outside 1-10"},
+		{"confidence too high", func(d *CombatDecision) { d.Confidence = 1.7 }, "confidence 1.70 outside 0-1"},
+		{"negative confidence", func(d *CombatDecision) { d.Confidence = -0.1 }, "confidence -0.10 outside 0-1"},
+		{"attack without weapon", func(d *CombatDecision) { d.Weapon = "" }, "attack without a weapon"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			decision := attackDecision
+			tc.modify(&decision)
+			d := newTestDecisionMaker(t, func(w http.ResponseWriter, r *http.Request) {
+				respond(t, w, decision)
+			})
+			d.options.MaxAttempts = 1
+
+			situation := testSituation("t1")
+			got, err := d.MakeCombatDecision(context.Background(), situation.CurrentLocation,
+				situation.Threat, situation.HealthStatus, situation.AvailableWeapons)
+			if !errors.Is(err, ErrInvalidDecision) {
+				t.Fatalf("MakeCombatDecision = %+v, %v; want ErrInvalidDecision", got, err)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error %q does not mention %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidDecisionsPass(t *testing.T) {
+	for _, decision := range []CombatDecision{
+		attackDecision,
+		{Action: "retreat", Priority: 1, Confidence: 0},
+		{Action: "defend", Priority: 10, Confidence: 1},
+	} {
+		if err := decision.validate(); err != nil {
+			t.Errorf("validate(%+v) = %v, want valid", decision, err)
+		}
+	}
+}
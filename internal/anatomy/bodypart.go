@@ -1,22 +1,37 @@
 package anatomy
 
+import (
+	"sync"
+	"time"
+
+	"t800/internal/common"
+)
+
 type PartType string
 
 const (
-	Head  PartType = "head"
-	Body  PartType = "body"
-	Arm   PartType = "arm"
-	Leg   PartType = "leg"
+	Head PartType = "head"
+	Body PartType = "body"
+	Arm  PartType = "arm"
+	Leg  PartType = "leg"
 )
 
-// BodyPart represents a physical component with thread-safe health management
+// BodyPart represents a physical component with thread-safe health and
+// protection management
 type BodyPart struct {
-	Type        PartType
-	Name        string
-	Dimensions  Dimensions
-	Protection  Protection
-	health      *SafeHealth
-	IsCritical  bool
+	Type         PartType
+	Name         string
+	Dimensions   Dimensions
+	protection   Protection
+	protectionMu sync.Mutex // Guards protection, which defenses, recharge and hits all change
+	health       *SafeHealth
+	shield       *SafeShield
+	history      *healthHistory
+	IsCritical   bool
+	Offset       common.Location // Position relative to the robot's center (X forward, Y left, Z up)
+	WeakPoints   []WeakPoint     // Spots critical hits can strike
+
+	DefensePriority int // Higher priority parts receive defensive boosts first
 }
 
 // Protection includes defensive capabilities
@@ -28,15 +43,57 @@ type Protection struct {
 	IsActive        bool
 }
 
-// NewBodyPart creates a new body part with default protection
+// NewBodyPart creates a new body part with default protection and
+// regeneration for its type
 func NewBodyPart(partType PartType, name string, dims Dimensions, isCritical bool) *BodyPart {
+	return NewBodyPartWithRegen(partType, name, dims, isCritical, DefaultRegenRate(partType))
+}
+
+// NewBodyPartWithRegen creates a new body part with default protection that
+// regenerates the given fraction of its maximum health per second
+func NewBodyPartWithRegen(partType PartType, name string, dims Dimensions, isCritical bool, regenRate float64) *BodyPart {
+	protection := DefaultProtection(partType)
 	return &BodyPart{
 		Type:       partType,
 		Name:       name,
 		Dimensions: dims,
-		health:     NewSafeHealth(100),
+		health:     NewSafeHealthWithRegen(100, regenRate),
+		shield:     NewSafeShield(protection.ShieldStrength),
+		history:    newHealthHistory(defaultHistorySize),
 		IsCritical: isCritical,
-		Protection: DefaultProtection(partType),
+		protection: protection,
+		WeakPoints: DefaultWeakPoints(partType),
+
+		DefensePriority: DefaultDefensePriority(partType),
+	}
+}
+
+// DefaultDefensePriority returns the default defense priority for a part type:
+// head highest, then body, arms and finally legs
+func DefaultDefensePriority(partType PartType) int {
+	switch partType {
+	case Head:
+		return 4
+	case Body:
+		return 3
+	case Arm:
+		return 2
+	case Leg:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DefaultRegenRate returns the default fraction of maximum health a part type
+// regenerates per second: the head and body hold delicate systems and
+// regenerate at half the rate of the limbs
+func DefaultRegenRate(partType PartType) float64 {
+	switch partType {
+	case Head, Body:
+		return defaultRegenRate / 2
+	default:
+		return defaultRegenRate
 	}
 }
 
@@ -48,43 +105,150 @@ func DefaultProtection(partType PartType) Protection {
 			ArmorRating:     95,
 			ShieldStrength:  90,
 			DamageThreshold: 50,
-			ArmorType:      "reinforced-titanium",
-			IsActive:       true,
+			ArmorType:       "reinforced-titanium",
+			IsActive:        true,
 		}
 	case Body:
 		return Protection{
 			ArmorRating:     90,
 			ShieldStrength:  85,
 			DamageThreshold: 75,
-			ArmorType:      "titanium",
-			IsActive:       true,
+			ArmorType:       "titanium",
+			IsActive:        true,
 		}
 	default:
 		return Protection{
 			ArmorRating:     80,
 			ShieldStrength:  75,
 			DamageThreshold: 60,
-			ArmorType:      "standard-titanium",
-			IsActive:       true,
+			ArmorType:       "standard-titanium",
+			IsActive:        true,
 		}
 	}
 }
 
+// Protection returns a copy of the part's current protection
+func (bp *BodyPart) Protection() Protection {
+	bp.protectionMu.Lock()
+	defer bp.protectionMu.Unlock()
+	return bp.protection
+}
+
+// SetProtection replaces the part's protection
+func (bp *BodyPart) SetProtection(protection Protection) {
+	bp.protectionMu.Lock()
+	defer bp.protectionMu.Unlock()
+	bp.protection = protection
+}
+
+// UpdateProtection changes the part's protection in place, so a read and the
+// write that depends on it cannot interleave with other changes
+func (bp *BodyPart) UpdateProtection(update func(*Protection)) {
+	bp.protectionMu.Lock()
+	defer bp.protectionMu.Unlock()
+	update(&bp.protection)
+}
+
 // GetHealth returns current health safely
 func (bp *BodyPart) GetHealth() float64 {
 	return bp.health.Get()
 }
 
-// TakeDamage calculates and applies damage with protection
+// RegenRate returns the fraction of maximum health the part regenerates per second
+func (bp *BodyPart) RegenRate() float64 {
+	return bp.health.RegenRate()
+}
+
+// IsDisabled reports whether the part has been destroyed. A disabled part
+// cannot act and does not regenerate until it is repaired.
+func (bp *BodyPart) IsDisabled() bool {
+	return bp.health.Get() <= 0
+}
+
+// RechargeShield recharges the part's shield up to the present time
+func (bp *BodyPart) RechargeShield(now time.Time) {
+	bp.UpdateProtection(func(protection *Protection) {
+		protection.ShieldStrength = bp.shield.Recharge(protection.ShieldStrength, now)
+	})
+}
+
+// TimeToFullHealth returns the seconds of regeneration needed to fully heal,
+// or -1 if the part does not regenerate
+func (bp *BodyPart) TimeToFullHealth() float64 {
+	return bp.health.TimeToFull()
+}
+
+// TakeDamage applies an impact through the part's protection and returns the
+// health actually lost. Impacts below the DamageThreshold are absorbed
+// entirely. Otherwise shields take the hit first, absorbing up to their
+// remaining strength and degrading by as much, and armor then reduces what
+// gets through by its rating as a percentage. Any hit delays shield recharge.
 func (bp *BodyPart) TakeDamage(impact float64) float64 {
-	if !bp.Protection.IsActive {
+	return bp.TakeDamageWithContext(impact, DamageContext{})
+}
+
+// TakeDamageWithContext applies an impact like TakeDamage. A critical hit
+// first scales the impact by its multiplier, and armor reduces what gets
+// through by only half its rating. Every hit is recorded in the part's history.
+func (bp *BodyPart) TakeDamageWithContext(impact float64, ctx DamageContext) float64 {
+	lost := bp.absorbImpact(impact, ctx)
+	if impact > 0 {
+		bp.history.record(HealthDamaged, lost, bp.GetHealth())
+	}
+	return lost
+}
+
+// Expose applies environmental damage, such as fire or radiation, which
+// bypasses armor and shields, and returns the health lost
+func (bp *BodyPart) Expose(amount float64) float64 {
+	lost := bp.health.Reduce(amount)
+	if lost > 0 {
+		bp.history.record(HealthDamaged, lost, bp.GetHealth())
+	}
+	return lost
+}
+
+// Heal restores up to amount health, records it in the part's history and
+// returns the health actually restored
+func (bp *BodyPart) Heal(amount float64) float64 {
+	healed := bp.health.Heal(amount)
+	if healed > 0 {
+		bp.history.record(HealthHealed, healed, bp.GetHealth())
+	}
+	return healed
+}
+
+// absorbImpact applies an impact through the part's protection and returns
+// the health lost
+func (bp *BodyPart) absorbImpact(impact float64, ctx DamageContext) float64 {
+	if ctx.Critical {
+		impact *= ctx.Multiplier
+	}
+
+	bp.protectionMu.Lock()
+	armor := bp.protection.ArmorRating
+	if ctx.Critical {
+		armor *= 1 - critArmorBypass
+	}
+	if !bp.protection.IsActive {
+		bp.protectionMu.Unlock()
 		return bp.health.Reduce(impact)
 	}
+	if impact > 0 {
+		bp.shield.NoteHit()
+	}
+	if impact < bp.protection.DamageThreshold {
+		bp.protectionMu.Unlock()
+		return 0
+	}
 
-	// Calculate damage reduction from armor and shields
-	armorReduction := 1 - (bp.Protection.ArmorRating / 100)
-	shieldReduction := 1 - (bp.Protection.ShieldStrength / 100)
-	
-	finalDamage := impact * armorReduction * shieldReduction
-	return bp.health.Reduce(finalDamage)
-} 
\ No newline at end of file
+	absorbed := min(impact, bp.protection.ShieldStrength)
+	bp.protection.ShieldStrength -= absorbed
+	bp.protectionMu.Unlock()
+
+	remaining := (impact - absorbed) * (1 - armor/100)
+	if remaining <= 0 {
+		return 0
+	}
+	return bp.health.Reduce(remaining)
+}
@@ -0,0 +1,57 @@
+package anatomy
+
+import "testing"
+
+func TestArmorDeflectsHitsBelowDamageThreshold(t *testing.T) {
+	ra := NewRobotAnatomy()
+	body := ra.Body
+	if threshold := body.Protection().DamageThreshold; threshold != 75 {
+		t.Fatalf("body damage threshold = %.0f, want 75", threshold)
+	}
+
+	if lost := body.TakeDamage(50); lost != 0 {
+		t.Errorf("50 damage hit took %.2f health, want it deflected", lost)
+	}
+	if health := body.GetHealth(); health != 100 {
+		t.Errorf("health after deflected hit = %.2f, want 100", health)
+	}
+
+	if lost := body.TakeDamage(300); lost <= 0 {
+		t.Error("300 damage hit was deflected, want it to get through")
+	}
+}
+
+func TestTakeDamageAbsorbsSequentially(t *testing.T) {
+	tests := []struct {
+		name       string
+		impact     float64
+		wantLost   float64
+		wantShield float64
+	}{
+		{"below threshold", 10, 0, 30},
+		{"absorbed by shield", 25, 0, 5},
+		{"depletes shield", 50, 10, 0},
+		{"overwhelming", 1000, 100, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dims, _ := NewDimensions(0.2, 0.7, 0.2, 20)
+			part := NewBodyPart(Arm, "arm", *dims, false)
+			part.UpdateProtection(func(protection *Protection) {
+				protection.ArmorRating = 50
+				protection.ShieldStrength = 30
+				protection.DamageThreshold = 20
+			})
+
+			if lost := part.TakeDamage(tt.impact); lost != tt.wantLost {
+				t.Errorf("health lost = %.2f, want %.2f", lost, tt.wantLost)
+			}
+			if shield := part.Protection().ShieldStrength; shield != tt.wantShield {
+				t.Errorf("shield left = %.2f, want %.2f", shield, tt.wantShield)
+			}
+			if health := part.GetHealth(); health != 100-tt.wantLost {
+				t.Errorf("health = %.2f, want %.2f", health, 100-tt.wantLost)
+			}
+		})
+	}
+}
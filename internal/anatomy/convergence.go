@@ -0,0 +1,39 @@
+package anatomy
+
+import (
+	"math"
+
+	"t800/internal/common"
+)
+
+// ConvergencePoint returns the point, in the robot's frame, at which the
+// arms' fire should converge to strike target. The target is given relative
+// to the robot's center in world axes and heading is the robot's facing in
+// radians, counterclockwise from the X axis.
+func (ra *RobotAnatomy) ConvergencePoint(target common.Location, heading float64) common.Location {
+	sin, cos := math.Sincos(-heading)
+	return common.Location{
+		X: target.X*cos - target.Y*sin,
+		Y: target.X*sin + target.Y*cos,
+		Z: target.Z,
+	}
+}
+
+// AimVector returns the unit direction, in the robot's frame, in which a
+// part must fire for its shot to reach the convergence point for target.
+// Because each arm is mounted off-center its aim is toed in rather than
+// parallel to the others, so the fire of both arms meets at the target.
+func (ra *RobotAnatomy) AimVector(part *BodyPart, target common.Location, heading float64) common.Location {
+	point := ra.ConvergencePoint(target, heading)
+	aim := common.Location{
+		X: point.X - part.Offset.X,
+		Y: point.Y - part.Offset.Y,
+		Z: point.Z - part.Offset.Z,
+	}
+
+	length := math.Sqrt(aim.X*aim.X + aim.Y*aim.Y + aim.Z*aim.Z)
+	if length == 0 {
+		return common.Location{X: 1}
+	}
+	return common.Location{X: aim.X / length, Y: aim.Y / length, Z: aim.Z / length}
+}
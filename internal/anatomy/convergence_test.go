@@ -0,0 +1,41 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestArmAimVectorsMeetAtTargetAhead(t *testing.T) {
+	ra := NewRobotAnatomy()
+	target := common.Location{X: 30, Z: 1.1}
+
+	point := ra.ConvergencePoint(target, 0)
+	if common.CalculateDistance(point, target) > 1e-9 {
+		t.Fatalf("convergence point = %+v, want the target %+v", point, target)
+	}
+
+	left := ra.AimVector(ra.Arms[0], target, 0)
+	right := ra.AimVector(ra.Arms[1], target, 0)
+	if left.Y >= 0 || right.Y <= 0 {
+		t.Errorf("aims left %+v, right %+v, want both toed in toward the center", left, right)
+	}
+	for i, aim := range []common.Location{left, right} {
+		arm := ra.Arms[i]
+		// Follow the arm's aim forward to the target's depth
+		reach := (point.X - arm.Offset.X) / aim.X
+		hit := arm.Offset.Add(aim.Scale(reach))
+		if common.CalculateDistance(hit, point) > 1e-9 {
+			t.Errorf("%s fire passes through %+v, want %+v", arm.Name, hit, point)
+		}
+	}
+}
+
+func TestConvergencePointRotatesIntoRobotFrame(t *testing.T) {
+	ra := NewRobotAnatomy()
+	point := ra.ConvergencePoint(common.Location{Y: 30}, math.Pi/2)
+	if want := (common.Location{X: 30}); common.CalculateDistance(point, want) > 1e-9 {
+		t.Errorf("target to the left while facing left = %+v, want straight ahead %+v", point, want)
+	}
+}
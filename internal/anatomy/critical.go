@@ -0,0 +1,57 @@
+package anatomy
+
+import "fmt"
+
+// Critical hit tuning: the default chance of a hit landing on a weak point,
+// and the share of armor reduction a critical hit bypasses
+const (
+	defaultCritChance = 0.1
+	critArmorBypass   = 0.5
+)
+
+// WeakPoint is a vulnerable spot on a body part; critical hits on it deal
+// Multiplier times the impact
+type WeakPoint struct {
+	Name       string
+	Multiplier float64
+}
+
+// DamageContext describes how an impact lands on a part
+type DamageContext struct {
+	Critical   bool
+	WeakPoint  string  // Weak point struck by a critical hit
+	Multiplier float64 // Impact multiplier of a critical hit
+}
+
+// DefaultWeakPoints returns the default weak points of a part type
+func DefaultWeakPoints(partType PartType) []WeakPoint {
+	switch partType {
+	case Head:
+		return []WeakPoint{{Name: "optics", Multiplier: 2.0}}
+	case Body:
+		return []WeakPoint{{Name: "power_core", Multiplier: 1.5}}
+	default:
+		return []WeakPoint{{Name: "joint", Multiplier: 1.25}}
+	}
+}
+
+// SetCritChance sets the chance (0-1) of a hit striking a weak point
+func (ra *RobotAnatomy) SetCritChance(chance float64) error {
+	if chance < 0 || chance > 1 {
+		return fmt.Errorf("critical hit chance must be between 0 and 1")
+	}
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+	ra.critChance = chance
+	return nil
+}
+
+// rollCritical decides whether a hit on a part strikes one of its weak
+// points; ra.mu must be held for writing
+func (ra *RobotAnatomy) rollCritical(part *BodyPart) DamageContext {
+	if len(part.WeakPoints) == 0 || ra.rng.Float64() >= ra.critChance {
+		return DamageContext{}
+	}
+	weakPoint := part.WeakPoints[ra.rng.Intn(len(part.WeakPoints))]
+	return DamageContext{Critical: true, WeakPoint: weakPoint.Name, Multiplier: weakPoint.Multiplier}
+}
@@ -0,0 +1,74 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCriticalHitBypassesArmorAndMultiplies(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ctx  DamageContext
+		lost float64
+	}{
+		// 40 through 50% armor
+		{"normal", DamageContext{}, 20},
+		// 80 through 25% armor, half of it bypassed
+		{"critical", DamageContext{Critical: true, WeakPoint: "optics", Multiplier: 2}, 60},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			head := NewRobotAnatomy().Head
+			head.SetProtection(Protection{ArmorRating: 50, IsActive: true})
+			if lost := head.TakeDamageWithContext(40, tc.ctx); math.Abs(lost-tc.lost) > 1e-9 {
+				t.Errorf("lost %.2f, want %.2f", lost, tc.lost)
+			}
+		})
+	}
+}
+
+// critSequence returns which of n hits on the head were critical for an
+// anatomy seeded with seed
+func critSequence(t *testing.T, seed int64, chance float64, n int) []bool {
+	t.Helper()
+	ra := unprotected(t)
+	ra.SetSeed(seed)
+	if err := ra.SetCritChance(chance); err != nil {
+		t.Fatalf("SetCritChance: %v", err)
+	}
+	crits := make([]bool, n)
+	for i := range crits {
+		_, ctx, err := ra.ApplyDamageWithPropagation("head", 0.1)
+		if err != nil {
+			t.Fatalf("ApplyDamageWithPropagation: %v", err)
+		}
+		crits[i] = ctx.Critical
+	}
+	return crits
+}
+
+func TestCriticalHitsFollowSeed(t *testing.T) {
+	first, second := critSequence(t, 42, 0.5, 20), critSequence(t, 42, 0.5, 20)
+	crits := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("hit %d critical %v and %v with the same seed", i, first[i], second[i])
+		}
+		if first[i] {
+			crits++
+		}
+	}
+	if crits == 0 || crits == len(first) {
+		t.Errorf("%d of %d hits critical at a 50%% chance", crits, len(first))
+	}
+
+	for _, crit := range critSequence(t, 42, 0, 20) {
+		if crit {
+			t.Fatal("critical hit with a zero chance")
+		}
+	}
+	for _, crit := range critSequence(t, 42, 1, 20) {
+		if !crit {
+			t.Fatal("normal hit with a certain chance")
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package anatomy
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+
+	"t800/internal/common"
+)
 
 // Dimensions represents physical dimensions of a body part
 type Dimensions struct {
@@ -47,4 +52,17 @@ func (d *Dimensions) Scale(factor float64) *Dimensions {
 		Depth:  d.Depth * factor,
 		Weight: d.Weight * factor,
 	}
-} 
\ No newline at end of file
+}
+
+// ProjectedArea returns the cross-sectional area in square meters presented
+// when viewed along dir. Width spans the Y axis, Depth the X axis and Height
+// the Z axis; dir need not be normalized.
+func (d *Dimensions) ProjectedArea(dir common.Location) float64 {
+	length := math.Sqrt(dir.X*dir.X + dir.Y*dir.Y + dir.Z*dir.Z)
+	if length == 0 {
+		return 0
+	}
+	return math.Abs(dir.X/length)*d.Width*d.Height +
+		math.Abs(dir.Y/length)*d.Depth*d.Height +
+		math.Abs(dir.Z/length)*d.Width*d.Depth
+}
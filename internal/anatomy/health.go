@@ -14,16 +14,33 @@ type SafeHealth struct {
 	lastUpdate int64
 }
 
+// defaultRegenRate is the fraction of maximum health regenerated per second
+// when no rate is given
+const defaultRegenRate = 0.1
+
 // NewSafeHealth creates a new SafeHealth instance
 func NewSafeHealth(maximum float64) *SafeHealth {
+	return NewSafeHealthWithRegen(maximum, defaultRegenRate)
+}
+
+// NewSafeHealthWithRegen creates a new SafeHealth instance regenerating the
+// given fraction of maximum health per second; a negative rate is treated as zero
+func NewSafeHealthWithRegen(maximum, regenRate float64) *SafeHealth {
 	return &SafeHealth{
 		current:    maximum,
 		maximum:    maximum,
-		regenRate:  0.1, // 10% regeneration per second
+		regenRate:  max(0, regenRate),
 		lastUpdate: 0,
 	}
 }
 
+// RegenRate returns the fraction of maximum health regenerated per second
+func (h *SafeHealth) RegenRate() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.regenRate
+}
+
 // Get returns the current health value
 func (h *SafeHealth) Get() float64 {
 	h.mu.RLock()
@@ -59,6 +76,13 @@ func (h *SafeHealth) Heal(amount float64) float64 {
 	return actualHealing
 }
 
+// Set overwrites the current health, clamped to [0, maximum]
+func (h *SafeHealth) Set(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.current = min(h.maximum, max(0, value))
+}
+
 // SetRegenRate sets the health regeneration rate
 func (h *SafeHealth) SetRegenRate(rate float64) error {
 	if rate < 0 {
@@ -85,11 +109,39 @@ func (h *SafeHealth) Update(currentTime int64) {
 		return
 	}
 
+	// A destroyed part only comes back through repair
+	if h.current <= 0 {
+		h.lastUpdate = currentTime
+		return
+	}
+
 	regenAmount := h.regenRate * deltaTime * h.maximum
 	h.current = min(h.maximum, h.current+regenAmount)
 	h.lastUpdate = currentTime
 }
 
+// Hold advances the regeneration clock without applying any healing, so
+// time spent with regeneration suspended is not credited later
+func (h *SafeHealth) Hold(currentTime int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastUpdate = currentTime
+}
+
+// TimeToFull returns the seconds of regeneration needed to reach full health
+func (h *SafeHealth) TimeToFull() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.current >= h.maximum {
+		return 0
+	}
+	if h.regenRate == 0 || h.current <= 0 {
+		return -1
+	}
+	return (h.maximum - h.current) / (h.regenRate * h.maximum)
+}
+
 // IsCritical returns true if health is below 20%
 func (h *SafeHealth) IsCritical() bool {
 	h.mu.RLock()
@@ -102,4 +154,4 @@ func (h *SafeHealth) Percentage() float64 {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 	return (h.current / h.maximum) * 100
-} 
\ No newline at end of file
+}
@@ -0,0 +1,50 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUpdateAgreesWithTimeToFull(t *testing.T) {
+	health := NewSafeHealthWithRegen(100, 0.1)
+	health.Reduce(50)
+	health.Update(1000)
+
+	seconds := health.TimeToFull()
+	if math.Abs(seconds-5) > 1e-9 {
+		t.Fatalf("TimeToFull = %v, want 5", seconds)
+	}
+
+	health.Update(1000 + int64(seconds*1000))
+	if got := health.Get(); got != 100 {
+		t.Fatalf("health after TimeToFull seconds = %v, want 100", got)
+	}
+}
+
+func TestUpdateRegeneratesPerSecond(t *testing.T) {
+	health := NewSafeHealthWithRegen(100, 0.1)
+	health.Reduce(50)
+	// Update takes timestamps in milliseconds
+	health.Update(1000)
+	health.Update(3000)
+
+	if got := health.Get(); math.Abs(got-70) > 1e-9 {
+		t.Fatalf("health after 2s = %v, want 70", got)
+	}
+}
+
+func TestDestroyedHealthOnlyRecoversThroughRepair(t *testing.T) {
+	health := NewSafeHealthWithRegen(100, 0.1)
+	health.Reduce(100)
+	health.Update(1000)
+	health.Update(11000)
+	if got := health.Get(); got != 0 {
+		t.Fatalf("destroyed health regenerated to %v", got)
+	}
+
+	health.Heal(10)
+	health.Update(12000)
+	if got := health.Get(); math.Abs(got-20) > 1e-9 {
+		t.Errorf("repaired health after 1s = %v, want 20", got)
+	}
+}
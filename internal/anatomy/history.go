@@ -0,0 +1,99 @@
+package anatomy
+
+import (
+	"sync"
+	"time"
+
+	"t800/internal/common"
+)
+
+// defaultHistorySize is how many health events each part keeps
+const defaultHistorySize = 64
+
+// HealthEventKind distinguishes damage from healing in a part's history
+type HealthEventKind string
+
+const (
+	HealthDamaged HealthEventKind = "damage"
+	HealthHealed  HealthEventKind = "heal"
+)
+
+// HealthEvent records a change to a part's health
+type HealthEvent struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Kind      HealthEventKind `json:"kind"`
+	Amount    float64         `json:"amount"` // Health lost or restored
+	Health    float64         `json:"health"` // Health after the change
+}
+
+// healthHistory is a ring buffer of a part's most recent health events
+type healthHistory struct {
+	mu     sync.Mutex
+	clock  common.Clock
+	events []HealthEvent
+	next   int
+	full   bool
+}
+
+// newHealthHistory creates a history keeping the last size events
+func newHealthHistory(size int) *healthHistory {
+	return &healthHistory{
+		clock:  common.RealClock{},
+		events: make([]HealthEvent, size),
+	}
+}
+
+// setClock replaces the clock events are timestamped with
+func (h *healthHistory) setClock(clock common.Clock) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clock = clock
+}
+
+// record appends an event, overwriting the oldest once full
+func (h *healthHistory) record(kind HealthEventKind, amount, health float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.events[h.next] = HealthEvent{Timestamp: h.clock.Now(), Kind: kind, Amount: amount, Health: health}
+	h.next = (h.next + 1) % len(h.events)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// list returns the recorded events, oldest first
+func (h *healthHistory) list() []HealthEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.full {
+		return append([]HealthEvent(nil), h.events[:h.next]...)
+	}
+	return append(append([]HealthEvent(nil), h.events[h.next:]...), h.events[:h.next]...)
+}
+
+// History returns the part's most recent damage and healing, oldest first
+func (bp *BodyPart) History() []HealthEvent {
+	return bp.history.list()
+}
+
+// PartHistory returns the most recent damage and healing of the named part,
+// oldest first, or nil if there is no such part
+func (ra *RobotAnatomy) PartHistory(name string) []HealthEvent {
+	part, err := ra.GetPart(name)
+	if err != nil {
+		return nil
+	}
+	return part.History()
+}
+
+// SetClock replaces the clock used to timestamp every part's health history
+func (ra *RobotAnatomy) SetClock(clock common.Clock) {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	for _, part := range ra.Parts {
+		part.history.setClock(clock)
+	}
+}
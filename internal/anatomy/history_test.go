@@ -0,0 +1,64 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestPartHistoryRecordsHitsInOrder(t *testing.T) {
+	ra := unprotected(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := common.NewManualClock(start)
+	ra.SetClock(clock)
+
+	head, _ := ra.GetPart("head")
+	for _, impact := range []float64{10, 25, 5} {
+		head.TakeDamage(impact)
+		clock.Advance(time.Second)
+	}
+	head.Heal(15)
+
+	want := []HealthEvent{
+		{Timestamp: start, Kind: HealthDamaged, Amount: 10, Health: 90},
+		{Timestamp: start.Add(time.Second), Kind: HealthDamaged, Amount: 25, Health: 65},
+		{Timestamp: start.Add(2 * time.Second), Kind: HealthDamaged, Amount: 5, Health: 60},
+		{Timestamp: start.Add(3 * time.Second), Kind: HealthHealed, Amount: 15, Health: 75},
+	}
+	history := ra.PartHistory("head")
+	if len(history) != len(want) {
+		t.Fatalf("head history has %d events, want %d: %+v", len(history), len(want), history)
+	}
+	for i, event := range history {
+		if !event.Timestamp.Equal(want[i].Timestamp) || event.Kind != want[i].Kind ||
+			math.Abs(event.Amount-want[i].Amount) > 1e-9 || math.Abs(event.Health-want[i].Health) > 1e-9 {
+			t.Errorf("event %d = %+v, want %+v", i, event, want[i])
+		}
+	}
+
+	if history := ra.PartHistory("body"); len(history) != 0 {
+		t.Errorf("undamaged body has history %+v", history)
+	}
+	if history := ra.PartHistory("tail"); history != nil {
+		t.Errorf("unknown part has history %+v", history)
+	}
+}
+
+func TestPartHistoryIsBounded(t *testing.T) {
+	ra := unprotected(t)
+	arm, _ := ra.GetPart("arm_left")
+	for i := 0; i < defaultHistorySize+10; i++ {
+		arm.TakeDamage(1)
+		arm.Heal(1)
+	}
+
+	history := arm.History()
+	if len(history) != defaultHistorySize {
+		t.Fatalf("history has %d events, want %d", len(history), defaultHistorySize)
+	}
+	if first, last := history[0], history[len(history)-1]; first.Kind != HealthDamaged || last.Kind != HealthHealed {
+		t.Errorf("history runs %s to %s, want the oldest kept damage to the latest heal", first.Kind, last.Kind)
+	}
+}
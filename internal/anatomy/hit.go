@@ -0,0 +1,103 @@
+package anatomy
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"t800/internal/common"
+)
+
+// HitPolicy decides which body part absorbs an incoming hit
+type HitPolicy int
+
+const (
+	// HitRandomBySurfaceArea picks a part at random, weighted by its surface area
+	HitRandomBySurfaceArea HitPolicy = iota
+	// HitWeakest always hits the part with the lowest health
+	HitWeakest
+	// HitDirectional hits the part facing the threat
+	HitDirectional
+)
+
+// SetSeed reseeds the random source used for hit selection
+func (ra *RobotAnatomy) SetSeed(seed int64) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+	ra.rng = rand.New(rand.NewSource(seed))
+}
+
+// SelectHitPart chooses the part that takes an incoming hit under the given
+// policy. threatDir is the direction from the robot to the threat in the
+// robot's frame.
+func (ra *RobotAnatomy) SelectHitPart(policy HitPolicy, threatDir common.Location) *BodyPart {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	parts := ra.sortedParts()
+	if len(parts) == 0 {
+		return nil
+	}
+
+	switch policy {
+	case HitWeakest:
+		weakest := parts[0]
+		for _, part := range parts[1:] {
+			if part.GetHealth() < weakest.GetHealth() {
+				weakest = part
+			}
+		}
+		return weakest
+
+	case HitDirectional:
+		return directionalHit(parts, threatDir)
+
+	default:
+		var total float64
+		for _, part := range parts {
+			total += part.Dimensions.SurfaceArea()
+		}
+		roll := ra.rng.Float64() * total
+		for _, part := range parts {
+			roll -= part.Dimensions.SurfaceArea()
+			if roll < 0 {
+				return part
+			}
+		}
+		return parts[len(parts)-1]
+	}
+}
+
+// directionalHit returns the part sitting furthest toward the threat,
+// preferring the part presenting the largest area when several are level
+func directionalHit(parts []*BodyPart, threatDir common.Location) *BodyPart {
+	const epsilon = 1e-6
+
+	horizontal := math.Hypot(threatDir.X, threatDir.Y)
+	var best *BodyPart
+	bestReach, bestArea := math.Inf(-1), 0.0
+	for _, part := range parts {
+		reach := 0.0
+		if horizontal > 0 {
+			reach = (part.Offset.X*threatDir.X + part.Offset.Y*threatDir.Y) / horizontal
+		}
+		area := part.Dimensions.ProjectedArea(threatDir)
+		if reach > bestReach+epsilon || (math.Abs(reach-bestReach) <= epsilon && area > bestArea) {
+			best, bestReach, bestArea = part, reach, area
+		}
+	}
+	return best
+}
+
+// sortedParts returns all parts ordered by name for deterministic iteration.
+// The caller must hold ra.mu.
+func (ra *RobotAnatomy) sortedParts() []*BodyPart {
+	parts := make([]*BodyPart, 0, len(ra.Parts))
+	for _, part := range ra.Parts {
+		parts = append(parts, part)
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].Name < parts[j].Name
+	})
+	return parts
+}
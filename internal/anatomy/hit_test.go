@@ -0,0 +1,29 @@
+package anatomy
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestDirectionalHitStrikesThreatFacingPart(t *testing.T) {
+	ra := NewRobotAnatomy()
+
+	tests := []struct {
+		name      string
+		threatDir common.Location
+		want      string
+	}{
+		{"left", common.Location{Y: 1}, "arm_left"},
+		{"right", common.Location{Y: -1}, "arm_right"},
+		{"front", common.Location{X: 1}, "body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part := ra.SelectHitPart(HitDirectional, tt.threatDir)
+			if part == nil || part.Name != tt.want {
+				t.Errorf("threat from %+v hit %v, want %s", tt.threatDir, part, tt.want)
+			}
+		})
+	}
+}
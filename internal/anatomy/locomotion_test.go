@@ -0,0 +1,23 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLocomotionFactorFollowsLegHealth(t *testing.T) {
+	ra := NewRobotAnatomy()
+	if got := ra.LocomotionFactor(); got != 1 {
+		t.Errorf("healthy LocomotionFactor = %v, want 1", got)
+	}
+
+	ra.Legs[0].Expose(100)
+	if got := ra.LocomotionFactor(); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("LocomotionFactor with a destroyed leg = %v, want 0.5", got)
+	}
+
+	ra.Legs[1].Expose(95)
+	if got := ra.LocomotionFactor(); got > 0.05+1e-9 {
+		t.Errorf("LocomotionFactor with both legs critical = %v, want at most 0.05", got)
+	}
+}
@@ -0,0 +1,101 @@
+package anatomy
+
+import (
+	"fmt"
+	"sync"
+)
+
+// PowerCore provides a thread-safe energy reservoir shared by the robot's systems
+type PowerCore struct {
+	mu           sync.RWMutex
+	current      float64
+	capacity     float64
+	rechargeRate float64 // units per second
+}
+
+// NewPowerCore creates a fully charged power core
+func NewPowerCore(capacity, rechargeRate float64) *PowerCore {
+	return &PowerCore{
+		current:      capacity,
+		capacity:     capacity,
+		rechargeRate: rechargeRate,
+	}
+}
+
+// Level returns the energy currently stored
+func (pc *PowerCore) Level() float64 {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	return pc.current
+}
+
+// Capacity returns the maximum energy the core can hold
+func (pc *PowerCore) Capacity() float64 {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	return pc.capacity
+}
+
+// RechargeRate returns the recharge rate in units per second
+func (pc *PowerCore) RechargeRate() float64 {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+	return pc.rechargeRate
+}
+
+// SetRechargeRate sets the recharge rate in units per second
+func (pc *PowerCore) SetRechargeRate(rate float64) error {
+	if rate < 0 {
+		return fmt.Errorf("recharge rate cannot be negative")
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.rechargeRate = rate
+	return nil
+}
+
+// SetLevel overwrites the stored energy, clamped to [0, capacity]
+func (pc *PowerCore) SetLevel(level float64) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.current = min(pc.capacity, max(0, level))
+}
+
+// Draw removes the given amount of energy if available and reports whether it was
+func (pc *PowerCore) Draw(amount float64) bool {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if amount < 0 || amount > pc.current {
+		return false
+	}
+	pc.current -= amount
+	return true
+}
+
+// Recharge adds energy up to the core's capacity and returns the amount added
+func (pc *PowerCore) Recharge(amount float64) float64 {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if amount <= 0 {
+		return 0
+	}
+	added := min(amount, pc.capacity-pc.current)
+	pc.current += added
+	return added
+}
+
+// TimeToFull returns the seconds needed to fully recharge at the current rate
+func (pc *PowerCore) TimeToFull() float64 {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	if pc.current >= pc.capacity {
+		return 0
+	}
+	if pc.rechargeRate == 0 {
+		return -1
+	}
+	return (pc.capacity - pc.current) / pc.rechargeRate
+}
@@ -0,0 +1,24 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFrontalProfileDiffersFromSideProfile(t *testing.T) {
+	ra := NewRobotAnatomy()
+
+	front := ra.ProfileArea(0, 0)
+	side := ra.ProfileArea(math.Pi/2, 0)
+	if front <= 0 || side <= 0 {
+		t.Fatalf("profiles front %.3f, side %.3f, want both positive", front, side)
+	}
+	if math.Abs(front-side) < 1e-6 {
+		t.Errorf("frontal and side profiles are both %.3f m², want them to differ", front)
+	}
+
+	// Turning with the observer leaves the profile unchanged
+	if turned := ra.ProfileArea(math.Pi/2, math.Pi/2); math.Abs(turned-front) > 1e-9 {
+		t.Errorf("profile facing the observer after turning = %.3f m², want %.3f", turned, front)
+	}
+}
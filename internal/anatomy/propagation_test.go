@@ -0,0 +1,72 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+)
+
+// unprotected returns an anatomy whose parts take impacts at full strength
+// and never critically
+func unprotected(t *testing.T) *RobotAnatomy {
+	t.Helper()
+	ra := NewRobotAnatomy()
+	if err := ra.SetCritChance(0); err != nil {
+		t.Fatalf("SetCritChance: %v", err)
+	}
+	for _, part := range ra.Parts {
+		part.SetProtection(Protection{})
+	}
+	return ra
+}
+
+func TestBodyHitPropagatesToConnectedParts(t *testing.T) {
+	ra := unprotected(t)
+
+	damage, _, err := ra.ApplyDamageWithPropagation("body", 40)
+	if err != nil {
+		t.Fatalf("ApplyDamageWithPropagation: %v", err)
+	}
+	want := map[string]float64{
+		"body": 40, "head": 10, "arm_left": 10, "arm_right": 10, "leg_left": 10, "leg_right": 10,
+	}
+	for name, lost := range want {
+		if math.Abs(damage[name]-lost) > 1e-9 {
+			t.Errorf("%s lost %.2f, want %.2f", name, damage[name], lost)
+		}
+		part, _ := ra.GetPart(name)
+		if got := part.GetHealth(); math.Abs(got-(100-lost)) > 1e-9 {
+			t.Errorf("%s health = %.2f, want %.2f", name, got, 100-lost)
+		}
+	}
+}
+
+func TestArmHitOnlyPropagatesToBody(t *testing.T) {
+	ra := unprotected(t)
+	if err := ra.SetDamagePropagation(0.5); err != nil {
+		t.Fatalf("SetDamagePropagation: %v", err)
+	}
+
+	damage, _, err := ra.ApplyDamageWithPropagation("arm_left", 40)
+	if err != nil {
+		t.Fatalf("ApplyDamageWithPropagation: %v", err)
+	}
+	if len(damage) != 2 || damage["arm_left"] != 40 || damage["body"] != 20 {
+		t.Errorf("damage = %v, want arm_left 40 and body 20", damage)
+	}
+	if got := ra.Arms[1].GetHealth(); got != 100 {
+		t.Errorf("arm_right health = %.2f, want it untouched", got)
+	}
+}
+
+func TestPropagatedDamageRespectsProtection(t *testing.T) {
+	ra := unprotected(t)
+	ra.Head.SetProtection(Protection{ArmorRating: 100, IsActive: true})
+
+	damage, _, err := ra.ApplyDamageWithPropagation("body", 40)
+	if err != nil {
+		t.Fatalf("ApplyDamageWithPropagation: %v", err)
+	}
+	if damage["head"] >= 10 {
+		t.Errorf("armored head lost %.2f, want less than the unarmored 10", damage["head"])
+	}
+}
@@ -0,0 +1,104 @@
+package anatomy
+
+import (
+	"math"
+
+	"t800/internal/common"
+)
+
+// maxProtectionRating caps both armor rating and shield strength
+const maxProtectionRating = 100.0
+
+// protectionImportance weights how much each part type matters to survival
+var protectionImportance = map[PartType]float64{
+	Head: 2.0,
+	Body: 1.5,
+	Arm:  1.0,
+	Leg:  0.75,
+}
+
+// OptimizeProtection distributes a budget of protection points across the
+// robot's parts to maximize survivability against a threat coming from
+// threatDir (in the robot's frame). Parts presenting more area toward the
+// threat, sitting on the threatened side, and critical to survival receive a
+// larger share. No part is given more than it can absorb before its armor
+// and shields are capped; that share is redistributed to the others. It
+// returns the points to allocate to each part by name and leaves the parts'
+// protection unchanged, so the caller decides how and for how long to apply it.
+func (ra *RobotAnatomy) OptimizeProtection(budget float64, threatDir common.Location) map[string]float64 {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	allocation := make(map[string]float64, len(ra.Parts))
+	if budget <= 0 {
+		return allocation
+	}
+
+	dir := common.Normalize(threatDir)
+	if dir == (common.Location{}) {
+		dir = common.Location{X: 1}
+	}
+
+	weights := make(map[string]float64, len(ra.Parts))
+	headroom := make(map[string]float64, len(ra.Parts))
+	for name, part := range ra.Parts {
+		weights[name] = part.exposureWeight(dir)
+		headroom[name] = part.protectionHeadroom()
+	}
+
+	// Allocate proportionally, redistributing whatever saturated parts can't take
+	remaining := budget
+	for remaining > 1e-9 {
+		var total float64
+		for name := range ra.Parts {
+			if headroom[name] > 0 {
+				total += weights[name]
+			}
+		}
+		if total == 0 {
+			break
+		}
+
+		spent := 0.0
+		for name := range ra.Parts {
+			if headroom[name] <= 0 {
+				continue
+			}
+			share := min(remaining*weights[name]/total, headroom[name])
+			headroom[name] -= share
+			allocation[name] += share
+			spent += share
+		}
+		remaining -= spent
+		if spent == 0 {
+			break
+		}
+	}
+	return allocation
+}
+
+// exposureWeight scores how exposed and important a part is to a threat from dir
+func (bp *BodyPart) exposureWeight(dir common.Location) float64 {
+	area := bp.Dimensions.ProjectedArea(dir)
+
+	// Parts on the threatened side of the chassis are more exposed
+	facing := 1.0
+	if lateral := math.Hypot(bp.Offset.X, bp.Offset.Y); lateral > 0 {
+		facing += 0.5 * (bp.Offset.X*dir.X + bp.Offset.Y*dir.Y) / lateral
+	}
+
+	importance := protectionImportance[bp.Type]
+	if importance == 0 {
+		importance = 1
+	}
+	if bp.IsCritical {
+		importance *= 2
+	}
+	return area * facing * importance
+}
+
+// protectionHeadroom returns how many more protection points the part can absorb
+func (bp *BodyPart) protectionHeadroom() float64 {
+	protection := bp.Protection()
+	return (maxProtectionRating - protection.ArmorRating) + (maxProtectionRating - protection.ShieldStrength)
+}
@@ -0,0 +1,43 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestOptimizeProtectionFavorsExposedCriticalParts(t *testing.T) {
+	ra := NewRobotAnatomy()
+	allocation := ra.OptimizeProtection(40, common.Location{X: 1})
+
+	for _, leg := range []string{"leg_left", "leg_right"} {
+		for _, favored := range []string{"head", "body"} {
+			if allocation[favored] <= allocation[leg] {
+				t.Errorf("%s got %.2f, not more than %s with %.2f", favored, allocation[favored], leg, allocation[leg])
+			}
+		}
+	}
+
+	total := 0.0
+	for _, points := range allocation {
+		total += points
+	}
+	if math.Abs(total-40) > 1e-6 {
+		t.Errorf("allocated %.4f points, want the whole budget of 40", total)
+	}
+}
+
+func TestOptimizeProtectionLeavesPartsUnchanged(t *testing.T) {
+	ra := NewRobotAnatomy()
+	before := ra.Snapshot()
+
+	ra.OptimizeProtection(100, common.Location{})
+
+	after := ra.Snapshot()
+	for name, state := range before.Parts {
+		if after.Parts[name].Protection != state.Protection {
+			t.Errorf("%s protection changed from %+v to %+v", name, state.Protection, after.Parts[name].Protection)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPartsRegenerateAtTheirOwnRates(t *testing.T) {
+	dims, err := NewDimensions(0.2, 0.2, 0.6, 10)
+	if err != nil {
+		t.Fatalf("NewDimensions: %v", err)
+	}
+	slow := NewBodyPartWithRegen(Arm, "slow", *dims, false, 0.02)
+	fast := NewBodyPartWithRegen(Arm, "fast", *dims, false, 0.1)
+
+	for _, part := range []*BodyPart{slow, fast} {
+		part.Expose(60)
+		part.health.Update(1000)
+		part.health.Update(4000)
+	}
+	if got := slow.GetHealth(); math.Abs(got-46) > 1e-9 {
+		t.Errorf("slow part after 3s = %.2f, want 46", got)
+	}
+	if got := fast.GetHealth(); math.Abs(got-70) > 1e-9 {
+		t.Errorf("fast part after 3s = %.2f, want 70", got)
+	}
+}
+
+func TestHeadRegeneratesSlowerThanLimbs(t *testing.T) {
+	ra := NewRobotAnatomy()
+	if head, arm := ra.Head.RegenRate(), ra.Arms[0].RegenRate(); head >= arm {
+		t.Fatalf("head regen %.3f not slower than arm regen %.3f", head, arm)
+	}
+
+	ra.Head.Expose(50)
+	ra.Arms[0].Expose(50)
+	ra.UpdateAllParts(1000)
+	ra.UpdateAllParts(3000)
+	if head, arm := ra.Head.GetHealth(), ra.Arms[0].GetHealth(); head >= arm {
+		t.Errorf("head healed to %.2f, arm to %.2f; want the head behind", head, arm)
+	}
+}
@@ -0,0 +1,213 @@
+package anatomy
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// RepairOrder selects which damaged parts are repaired first
+type RepairOrder int
+
+const (
+	// RepairMostCriticalFirst repairs critical parts before the rest
+	RepairMostCriticalFirst RepairOrder = iota
+	// RepairMostDamagedFirst repairs the parts with the least health first
+	RepairMostDamagedFirst
+	// RepairByDefensePriority repairs parts in defense priority order
+	RepairByDefensePriority
+)
+
+// String returns a human readable name for the repair order
+func (o RepairOrder) String() string {
+	switch o {
+	case RepairMostCriticalFirst:
+		return "most_critical_first"
+	case RepairMostDamagedFirst:
+		return "most_damaged_first"
+	case RepairByDefensePriority:
+		return "defense_priority"
+	default:
+		return fmt.Sprintf("repair_order(%d)", int(o))
+	}
+}
+
+// RecoveryPolicy controls how parts are repaired during maintenance
+type RecoveryPolicy struct {
+	RepairOrder   RepairOrder
+	BudgetPerTick float64 // Health points restored per repair tick, shared by all parts
+	PowerPerPoint float64 // Power drawn from the core per health point restored
+	ExitThreshold float64 // Maintenance ends once every part's health reaches this percentage
+}
+
+// DefaultRecoveryPolicy returns the default recovery policy
+func DefaultRecoveryPolicy() RecoveryPolicy {
+	return RecoveryPolicy{
+		RepairOrder:   RepairMostCriticalFirst,
+		BudgetPerTick: 10.0,
+		PowerPerPoint: 2.0,
+		ExitThreshold: 95.0,
+	}
+}
+
+// NanobotPolicy controls active repair of critical parts outside
+// maintenance, which competes with the weapons for the power core
+type NanobotPolicy struct {
+	Enabled       bool
+	PowerPerTick  float64 // Power the nanobots may draw each repair tick
+	PowerPerPoint float64 // Power drawn from the core per health point restored
+	Threshold     float64 // Only critical parts below this health percentage are repaired
+}
+
+// DefaultNanobotPolicy returns the default nanobot policy; nanobots are
+// disabled until enabled explicitly
+func DefaultNanobotPolicy() NanobotPolicy {
+	return NanobotPolicy{
+		PowerPerTick:  20.0,
+		PowerPerPoint: 2.0,
+		Threshold:     75.0,
+	}
+}
+
+// RepairSystem restores damaged parts within a limited per-tick budget
+type RepairSystem struct {
+	mu       sync.RWMutex
+	anatomy  *RobotAnatomy
+	policy   RecoveryPolicy
+	nanobots NanobotPolicy
+}
+
+// NewRepairSystem creates a repair system for the given anatomy
+func NewRepairSystem(ra *RobotAnatomy, policy RecoveryPolicy) *RepairSystem {
+	return &RepairSystem{
+		anatomy:  ra,
+		policy:   policy,
+		nanobots: DefaultNanobotPolicy(),
+	}
+}
+
+// NanobotPolicy returns the current nanobot policy
+func (rs *RepairSystem) NanobotPolicy() NanobotPolicy {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.nanobots
+}
+
+// SetNanobotPolicy replaces the nanobot policy
+func (rs *RepairSystem) SetNanobotPolicy(policy NanobotPolicy) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.nanobots = policy
+}
+
+// NanobotTick spends one tick of nanobot power on the most damaged critical
+// parts below the policy's threshold. It stops when the core runs dry and
+// returns the health restored to each part by name.
+func (rs *RepairSystem) NanobotTick() map[string]float64 {
+	policy := rs.NanobotPolicy()
+	if !policy.Enabled || policy.PowerPerPoint <= 0 {
+		return nil
+	}
+
+	var parts []*BodyPart
+	for _, part := range rs.anatomy.GetCriticalParts() {
+		if part.GetHealth() < policy.Threshold {
+			parts = append(parts, part)
+		}
+	}
+	sort.SliceStable(parts, func(i, j int) bool {
+		return parts[i].GetHealth() < parts[j].GetHealth()
+	})
+
+	repaired := make(map[string]float64)
+	budget := min(policy.PowerPerTick, rs.anatomy.Power.Level()) / policy.PowerPerPoint
+	for _, part := range parts {
+		if budget <= 0 {
+			break
+		}
+		healed, _ := rs.anatomy.RepairPart(part.Name, budget)
+		if healed > 0 {
+			rs.anatomy.Power.Draw(healed * policy.PowerPerPoint)
+			repaired[part.Name] = healed
+			budget -= healed
+		}
+	}
+	return repaired
+}
+
+// Policy returns the current recovery policy
+func (rs *RepairSystem) Policy() RecoveryPolicy {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	return rs.policy
+}
+
+// SetPolicy replaces the recovery policy
+func (rs *RepairSystem) SetPolicy(policy RecoveryPolicy) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.policy = policy
+}
+
+// RepairTick spends one tick's repair budget on damaged parts in the order
+// set by the policy, fully repairing each before moving to the next. Repairs
+// draw power from the core and stop when it runs dry. It returns the health
+// restored to each part by name.
+func (rs *RepairSystem) RepairTick() map[string]float64 {
+	policy := rs.Policy()
+	repaired := make(map[string]float64)
+
+	budget := policy.BudgetPerTick
+	if policy.PowerPerPoint > 0 {
+		budget = min(budget, rs.anatomy.Power.Level()/policy.PowerPerPoint)
+	}
+	for _, part := range rs.repairQueue(policy.RepairOrder) {
+		if budget <= 0 {
+			break
+		}
+		healed, _ := rs.anatomy.RepairPart(part.Name, budget)
+		if healed > 0 {
+			rs.anatomy.Power.Draw(healed * policy.PowerPerPoint)
+			repaired[part.Name] = healed
+			budget -= healed
+		}
+	}
+	return repaired
+}
+
+// Repaired reports whether every part's health has reached the policy's
+// exit threshold
+func (rs *RepairSystem) Repaired() bool {
+	threshold := rs.Policy().ExitThreshold
+	for _, part := range rs.anatomy.PartsByDefensePriority() {
+		if part.health.Percentage() < threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// repairQueue returns the damaged parts in the order they should be repaired
+func (rs *RepairSystem) repairQueue(order RepairOrder) []*BodyPart {
+	var parts []*BodyPart
+	for _, part := range rs.anatomy.PartsByDefensePriority() {
+		if part.health.Percentage() < 100 {
+			parts = append(parts, part)
+		}
+	}
+
+	switch order {
+	case RepairMostCriticalFirst:
+		sort.SliceStable(parts, func(i, j int) bool {
+			if parts[i].IsCritical != parts[j].IsCritical {
+				return parts[i].IsCritical
+			}
+			return parts[i].GetHealth() < parts[j].GetHealth()
+		})
+	case RepairMostDamagedFirst:
+		sort.SliceStable(parts, func(i, j int) bool {
+			return parts[i].GetHealth() < parts[j].GetHealth()
+		})
+	}
+	return parts
+}
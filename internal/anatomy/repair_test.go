@@ -0,0 +1,83 @@
+package anatomy
+
+import "testing"
+
+func TestRepairOrderDecidesWhichPartRecoversFirst(t *testing.T) {
+	for _, tc := range []struct {
+		order RepairOrder
+		first string
+	}{
+		{RepairMostCriticalFirst, "head"},
+		{RepairMostDamagedFirst, "arm_left"},
+	} {
+		t.Run(tc.order.String(), func(t *testing.T) {
+			ra := NewRobotAnatomy()
+			ra.Head.Expose(30)
+			ra.Arms[0].Expose(60)
+
+			policy := DefaultRecoveryPolicy()
+			policy.RepairOrder = tc.order
+			policy.BudgetPerTick = 10
+			rs := NewRepairSystem(ra, policy)
+
+			repaired := rs.RepairTick()
+			if len(repaired) != 1 || repaired[tc.first] != 10 {
+				t.Errorf("first tick repaired %v, want all 10 points on %s", repaired, tc.first)
+			}
+		})
+	}
+}
+
+func TestCriticalPartFullyRecoversFirst(t *testing.T) {
+	ra := NewRobotAnatomy()
+	ra.Head.Expose(30)
+	ra.Arms[0].Expose(60)
+	policy := DefaultRecoveryPolicy()
+	policy.BudgetPerTick = 10
+	rs := NewRepairSystem(ra, policy)
+
+	for i := 0; i < 3; i++ {
+		rs.RepairTick()
+	}
+	if health := ra.Head.GetHealth(); health != 100 {
+		t.Errorf("head health after 3 ticks = %.1f, want 100", health)
+	}
+	if health := ra.Arms[0].GetHealth(); health != 40 {
+		t.Errorf("arm health after 3 ticks = %.1f, want 40 until the head is done", health)
+	}
+}
+
+func TestNanobotsRepairMostDamagedCriticalPartForPower(t *testing.T) {
+	ra := NewRobotAnatomy()
+	ra.Head.Expose(60)
+	ra.Body.Expose(30)
+	ra.Arms[0].Expose(80)
+	rs := NewRepairSystem(ra, DefaultRecoveryPolicy())
+
+	if repaired := rs.NanobotTick(); len(repaired) != 0 {
+		t.Fatalf("disabled nanobots repaired %v", repaired)
+	}
+
+	policy := DefaultNanobotPolicy()
+	policy.Enabled = true
+	rs.SetNanobotPolicy(policy)
+	power := ra.Power.Level()
+	repaired := rs.NanobotTick()
+	if len(repaired) != 1 || repaired["head"] != 10 {
+		t.Errorf("first tick repaired %v, want all 10 points on the head", repaired)
+	}
+	if drawn := power - ra.Power.Level(); drawn != 20 {
+		t.Errorf("nanobots drew %.1f power, want 20", drawn)
+	}
+
+	ra.Power.SetLevel(5)
+	if repaired := rs.NanobotTick(); repaired["head"] != 2.5 {
+		t.Errorf("tick on 5 power repaired %v, want 2.5 points on the head", repaired)
+	}
+	if level := ra.Power.Level(); level != 0 {
+		t.Errorf("power after the tick = %.1f, want drained", level)
+	}
+	if repaired := rs.NanobotTick(); len(repaired) != 0 {
+		t.Errorf("nanobots repaired %v without power", repaired)
+	}
+}
@@ -0,0 +1,51 @@
+package anatomy
+
+// PartReport describes a single body part for diagnostics and UIs
+type PartReport struct {
+	Type        PartType   `json:"type"`
+	Name        string     `json:"name"`
+	Dimensions  Dimensions `json:"dimensions"`
+	Volume      float64    `json:"volume"`       // cubic meters
+	SurfaceArea float64    `json:"surface_area"` // square meters
+	Density     float64    `json:"density"`      // kg/m³
+	Protection  Protection `json:"protection"`
+	Health      float64    `json:"health"`
+	IsCritical  bool       `json:"is_critical"`
+}
+
+// AnatomyReport is a read-only spec sheet of the whole robot
+type AnatomyReport struct {
+	Parts       []PartReport `json:"parts"`
+	TotalWeight float64      `json:"total_weight"` // kilograms
+	PowerLevel  float64      `json:"power_level"`
+	PowerMax    float64      `json:"power_max"`
+}
+
+// Report describes every part's type, dimensions, protection, health and
+// critical flag, ordered by part name
+func (ra *RobotAnatomy) Report() AnatomyReport {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	report := AnatomyReport{
+		Parts:      make([]PartReport, 0, len(ra.Parts)),
+		PowerLevel: ra.Power.Level(),
+		PowerMax:   ra.Power.Capacity(),
+	}
+	for _, part := range ra.sortedParts() {
+		dims := part.Dimensions
+		report.Parts = append(report.Parts, PartReport{
+			Type:        part.Type,
+			Name:        part.Name,
+			Dimensions:  dims,
+			Volume:      dims.Volume(),
+			SurfaceArea: dims.SurfaceArea(),
+			Density:     dims.Density(),
+			Protection:  part.Protection(),
+			Health:      part.GetHealth(),
+			IsCritical:  part.IsCritical,
+		})
+		report.TotalWeight += dims.Weight
+	}
+	return report
+}
@@ -0,0 +1,38 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReportIncludesEveryDefaultPart(t *testing.T) {
+	report := NewRobotAnatomy().Report()
+
+	wantVolumes := map[string]float64{
+		"head":      0.3 * 0.4 * 0.3,
+		"body":      0.5 * 0.8 * 0.4,
+		"arm_left":  0.2 * 0.7 * 0.2,
+		"arm_right": 0.2 * 0.7 * 0.2,
+		"leg_left":  0.25 * 0.9 * 0.25,
+		"leg_right": 0.25 * 0.9 * 0.25,
+	}
+	if len(report.Parts) != len(wantVolumes) {
+		t.Fatalf("report has %d parts, want %d", len(report.Parts), len(wantVolumes))
+	}
+	for _, part := range report.Parts {
+		want, ok := wantVolumes[part.Name]
+		if !ok {
+			t.Errorf("unexpected part %s in report", part.Name)
+			continue
+		}
+		if math.Abs(part.Volume-want) > 1e-9 {
+			t.Errorf("%s volume = %.4f m³, want %.4f", part.Name, part.Volume, want)
+		}
+		if math.Abs(part.Density-part.Dimensions.Weight/want) > 1e-6 {
+			t.Errorf("%s density = %.2f, want %.2f", part.Name, part.Density, part.Dimensions.Weight/want)
+		}
+	}
+	if report.TotalWeight != 15+45+2*20+2*25 {
+		t.Errorf("total weight = %.1f kg, want 150", report.TotalWeight)
+	}
+}
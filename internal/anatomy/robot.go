@@ -2,7 +2,13 @@ package anatomy
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
+	"sort"
 	"sync"
+	"time"
+
+	"t800/internal/common"
 )
 
 // RobotAnatomy defines the physical structure of the robot
@@ -13,33 +19,54 @@ type RobotAnatomy struct {
 	Arms  []*BodyPart
 	Legs  []*BodyPart
 	Parts map[string]*BodyPart
+	Power *PowerCore
+
+	regenPaused bool
+	rng         *rand.Rand
+	critChance  float64
+
+	// connections lists the parts directly attached to each part
+	connections map[string][]string
+	propagation float64
 }
 
+// defaultDamagePropagation is the fraction of an impact passed on to each
+// directly connected part
+const defaultDamagePropagation = 0.25
+
 // NewRobotAnatomy creates a new robot anatomy with standard T800 specifications
 func NewRobotAnatomy() *RobotAnatomy {
 	ra := &RobotAnatomy{
 		Parts: make(map[string]*BodyPart),
+		Power: NewPowerCore(1000, 20),
+		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
+
+		propagation: defaultDamagePropagation,
+		critChance:  defaultCritChance,
 	}
 
 	// Initialize head
 	headDims, _ := NewDimensions(0.3, 0.4, 0.3, 15.0)
 	ra.Head = NewBodyPart(Head, "head", *headDims, true)
+	ra.Head.Offset = common.Location{X: 0, Y: 0, Z: 1.6}
 	ra.Parts["head"] = ra.Head
 
 	// Initialize body
 	bodyDims, _ := NewDimensions(0.5, 0.8, 0.4, 45.0)
 	ra.Body = NewBodyPart(Body, "body", *bodyDims, true)
+	ra.Body.Offset = common.Location{X: 0, Y: 0, Z: 1.0}
 	ra.Parts["body"] = ra.Body
 
 	// Initialize arms
 	ra.Arms = make([]*BodyPart, 2)
 	armDims, _ := NewDimensions(0.2, 0.7, 0.2, 20.0)
 	for i := range ra.Arms {
-		side := "left"
+		side, lateral := "left", 1.0
 		if i == 1 {
-			side = "right"
+			side, lateral = "right", -1.0
 		}
 		ra.Arms[i] = NewBodyPart(Arm, fmt.Sprintf("arm_%s", side), *armDims, false)
+		ra.Arms[i].Offset = common.Location{X: 0, Y: 0.35 * lateral, Z: 1.1}
 		ra.Parts[fmt.Sprintf("arm_%s", side)] = ra.Arms[i]
 	}
 
@@ -47,17 +74,83 @@ func NewRobotAnatomy() *RobotAnatomy {
 	ra.Legs = make([]*BodyPart, 2)
 	legDims, _ := NewDimensions(0.25, 0.9, 0.25, 25.0)
 	for i := range ra.Legs {
-		side := "left"
+		side, lateral := "left", 1.0
 		if i == 1 {
-			side = "right"
+			side, lateral = "right", -1.0
 		}
 		ra.Legs[i] = NewBodyPart(Leg, fmt.Sprintf("leg_%s", side), *legDims, true)
+		ra.Legs[i].Offset = common.Location{X: 0, Y: 0.15 * lateral, Z: 0.45}
 		ra.Parts[fmt.Sprintf("leg_%s", side)] = ra.Legs[i]
 	}
 
+	// Head and arms attach to the body, and the body to the legs
+	ra.connections = make(map[string][]string)
+	ra.connect(ra.Head, ra.Body)
+	for _, arm := range ra.Arms {
+		ra.connect(arm, ra.Body)
+	}
+	for _, leg := range ra.Legs {
+		ra.connect(ra.Body, leg)
+	}
+
 	return ra
 }
 
+// connect attaches two parts to each other
+func (ra *RobotAnatomy) connect(a, b *BodyPart) {
+	ra.connections[a.Name] = append(ra.connections[a.Name], b.Name)
+	ra.connections[b.Name] = append(ra.connections[b.Name], a.Name)
+}
+
+// ConnectedParts returns the parts directly attached to the named part
+func (ra *RobotAnatomy) ConnectedParts(name string) []*BodyPart {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	var parts []*BodyPart
+	for _, connected := range ra.connections[name] {
+		parts = append(parts, ra.Parts[connected])
+	}
+	return parts
+}
+
+// SetDamagePropagation sets the fraction (0-1) of an impact passed on to
+// each part directly connected to the part hit
+func (ra *RobotAnatomy) SetDamagePropagation(fraction float64) error {
+	if fraction < 0 || fraction > 1 {
+		return fmt.Errorf("damage propagation must be between 0 and 1")
+	}
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+	ra.propagation = fraction
+	return nil
+}
+
+// ApplyDamageWithPropagation applies an impact to the named part and the
+// propagation fraction of it to every directly connected part, each through
+// its own protection. The impact on the named part may strike a weak point
+// as a critical hit; propagated damage never does. It returns the health
+// lost by every part that was hit and how the impact landed on the named part.
+func (ra *RobotAnatomy) ApplyDamageWithPropagation(partName string, impact float64) (map[string]float64, DamageContext, error) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	part, exists := ra.Parts[partName]
+	if !exists {
+		return nil, DamageContext{}, fmt.Errorf("part not found: %s", partName)
+	}
+
+	ctx := ra.rollCritical(part)
+	damage := map[string]float64{partName: part.TakeDamageWithContext(impact, ctx)}
+	if ra.propagation == 0 {
+		return damage, ctx, nil
+	}
+	for _, connected := range ra.connections[partName] {
+		damage[connected] = ra.Parts[connected].TakeDamage(impact * ra.propagation)
+	}
+	return damage, ctx, nil
+}
+
 // GetPart returns a body part by name
 func (ra *RobotAnatomy) GetPart(name string) (*BodyPart, error) {
 	ra.mu.RLock()
@@ -70,6 +163,46 @@ func (ra *RobotAnatomy) GetPart(name string) (*BodyPart, error) {
 	return part, nil
 }
 
+// PartsOfType returns all parts of the given type
+func (ra *RobotAnatomy) PartsOfType(partType PartType) []*BodyPart {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	var parts []*BodyPart
+	for _, part := range ra.sortedParts() {
+		if part.Type == partType {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// PartsByDefensePriority returns all parts ordered from highest to lowest
+// defense priority; parts of equal priority are ordered by name
+func (ra *RobotAnatomy) PartsByDefensePriority() []*BodyPart {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	parts := ra.sortedParts()
+	sort.SliceStable(parts, func(i, j int) bool {
+		return parts[i].DefensePriority > parts[j].DefensePriority
+	})
+	return parts
+}
+
+// SetDefensePriority overrides the defense priority of a part
+func (ra *RobotAnatomy) SetDefensePriority(name string, priority int) error {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	part, exists := ra.Parts[name]
+	if !exists {
+		return fmt.Errorf("part not found: %s", name)
+	}
+	part.DefensePriority = priority
+	return nil
+}
+
 // UpdatePart updates a body part's health
 func (ra *RobotAnatomy) UpdatePart(name string, damage float64) error {
 	ra.mu.Lock()
@@ -84,6 +217,46 @@ func (ra *RobotAnatomy) UpdatePart(name string, damage float64) error {
 	return nil
 }
 
+// RepairPart restores up to amount health to the named part and returns the
+// health actually restored. Repair is the only way to bring back a
+// destroyed part.
+func (ra *RobotAnatomy) RepairPart(name string, amount float64) (float64, error) {
+	part, err := ra.GetPart(name)
+	if err != nil {
+		return 0, err
+	}
+	return part.Heal(amount), nil
+}
+
+// SetRegenRate sets the regeneration rate of every part as a fraction of
+// maximum health per second
+func (ra *RobotAnatomy) SetRegenRate(rate float64) error {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	for _, part := range ra.Parts {
+		if err := part.health.SetRegenRate(rate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetPartTypeRegenRate sets the regeneration rate of every part of a type as
+// a fraction of maximum health per second
+func (ra *RobotAnatomy) SetPartTypeRegenRate(partType PartType, rate float64) error {
+	parts := ra.PartsOfType(partType)
+	if len(parts) == 0 {
+		return fmt.Errorf("no parts of type %s", partType)
+	}
+	for _, part := range parts {
+		if err := part.health.SetRegenRate(rate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetCriticalParts returns all critical body parts
 func (ra *RobotAnatomy) GetCriticalParts() []*BodyPart {
 	ra.mu.RLock()
@@ -98,6 +271,23 @@ func (ra *RobotAnatomy) GetCriticalParts() []*BodyPart {
 	return critical
 }
 
+// LocomotionFactor returns a 0-1 multiplier on movement speed from the
+// average health of the legs: losing one of two legs halves the speed, and
+// with every leg critical the robot is nearly immobilized
+func (ra *RobotAnatomy) LocomotionFactor() float64 {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	if len(ra.Legs) == 0 {
+		return 1
+	}
+	var total float64
+	for _, leg := range ra.Legs {
+		total += leg.GetHealth()
+	}
+	return min(1, max(0, total/float64(len(ra.Legs))/100))
+}
+
 // CalculateTotalWeight returns the total weight of the robot
 func (ra *RobotAnatomy) CalculateTotalWeight() float64 {
 	ra.mu.RLock()
@@ -110,16 +300,75 @@ func (ra *RobotAnatomy) CalculateTotalWeight() float64 {
 	return total
 }
 
+// BoundingRadius returns the radius of the circle enclosing the robot's
+// footprint: half its width across the torso and the arms mounted beside it
+func (ra *RobotAnatomy) BoundingRadius() float64 {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	var width, depth float64
+	for _, part := range ra.Parts {
+		if part.Type == Body || part.Type == Arm {
+			width += part.Dimensions.Width
+		}
+		depth = max(depth, part.Dimensions.Depth)
+	}
+	return max(width, depth) / 2
+}
+
 // UpdateAllParts applies regeneration to all parts
 func (ra *RobotAnatomy) UpdateAllParts(currentTime int64) {
 	ra.mu.Lock()
 	defer ra.mu.Unlock()
 
 	for _, part := range ra.Parts {
+		if ra.regenPaused {
+			part.health.Hold(currentTime)
+			continue
+		}
 		part.health.Update(currentTime)
 	}
 }
 
+// RechargeShields recharges the shields of every part that has not been hit
+// within its recharge delay
+func (ra *RobotAnatomy) RechargeShields(now time.Time) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	for _, part := range ra.Parts {
+		part.RechargeShield(now)
+	}
+}
+
+// SetShieldRecharge sets the shield recharge rate in points per second and
+// the delay after a hit before recharging starts for every part
+func (ra *RobotAnatomy) SetShieldRecharge(rate float64, delay time.Duration) error {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	for _, part := range ra.Parts {
+		if err := part.shield.SetRecharge(rate, delay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetRegenPaused suspends or resumes passive regeneration for all parts
+func (ra *RobotAnatomy) SetRegenPaused(paused bool) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+	ra.regenPaused = paused
+}
+
+// IsRegenPaused reports whether passive regeneration is currently suspended
+func (ra *RobotAnatomy) IsRegenPaused() bool {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+	return ra.regenPaused
+}
+
 // IsPartCritical checks if a part is critical by its name
 func (ra *RobotAnatomy) IsPartCritical(name string) bool {
 	ra.mu.RLock()
@@ -142,4 +391,22 @@ func (ra *RobotAnatomy) GetHealthStatus() map[string]float64 {
 		status[name] = part.GetHealth()
 	}
 	return status
-} 
\ No newline at end of file
+}
+
+// ProfileArea returns the total cross-sectional area in square meters the
+// robot presents to an observer at the given bearing when facing heading.
+// Both angles are in radians, measured counterclockwise from the X axis. A
+// robot turned edge-on to the observer presents less area than face-on.
+func (ra *RobotAnatomy) ProfileArea(bearing float64, heading float64) float64 {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	relative := bearing - heading
+	dir := common.Location{X: math.Cos(relative), Y: math.Sin(relative)}
+
+	var area float64
+	for _, part := range ra.Parts {
+		area += part.Dimensions.ProjectedArea(dir)
+	}
+	return area
+}
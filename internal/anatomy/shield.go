@@ -0,0 +1,90 @@
+package anatomy
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Default shield recharge: points per second once the delay since the last
+// hit has passed
+const (
+	defaultShieldRechargeRate  = 5.0
+	defaultShieldRechargeDelay = 3 * time.Second
+)
+
+// SafeShield provides thread-safe shield recharge timing. Shields recharge
+// toward their capacity at a fixed rate, but only once no hit has landed for
+// the recharge delay.
+type SafeShield struct {
+	mu           sync.Mutex
+	capacity     float64
+	rechargeRate float64
+	delay        time.Duration
+	hitPending   bool
+	lastHit      time.Time
+	lastUpdate   time.Time
+}
+
+// NewSafeShield creates a new SafeShield recharging up to capacity
+func NewSafeShield(capacity float64) *SafeShield {
+	return &SafeShield{
+		capacity:     capacity,
+		rechargeRate: defaultShieldRechargeRate,
+		delay:        defaultShieldRechargeDelay,
+	}
+}
+
+// Capacity returns the strength the shield recharges to
+func (s *SafeShield) Capacity() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.capacity
+}
+
+// SetRecharge sets the recharge rate in points per second and the delay
+// after a hit before recharging starts
+func (s *SafeShield) SetRecharge(rate float64, delay time.Duration) error {
+	if rate < 0 || delay < 0 {
+		return fmt.Errorf("shield recharge rate and delay cannot be negative")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rechargeRate = rate
+	s.delay = delay
+	return nil
+}
+
+// NoteHit records that the shield was hit; the recharge delay restarts from
+// the next Recharge
+func (s *SafeShield) NoteHit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hitPending = true
+}
+
+// Recharge returns the shield strength after recharging current up to the
+// present time. Strength above capacity, such as a defensive boost, is left
+// as is.
+func (s *SafeShield) Recharge(current float64, now time.Time) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.hitPending {
+		s.lastHit, s.hitPending = now, false
+	}
+	if s.lastUpdate.IsZero() {
+		s.lastUpdate = now
+		return current
+	}
+
+	from := s.lastUpdate
+	if ready := s.lastHit.Add(s.delay); ready.After(from) {
+		from = ready
+	}
+	s.lastUpdate = now
+	if !now.After(from) || current >= s.capacity {
+		return current
+	}
+	return min(s.capacity, current+s.rechargeRate*now.Sub(from).Seconds())
+}
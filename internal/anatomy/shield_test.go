@@ -0,0 +1,62 @@
+package anatomy
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestShieldDepletesThenRechargesAfterDelay(t *testing.T) {
+	head := NewRobotAnatomy().Head
+	capacity := head.Protection().ShieldStrength
+	start := time.Unix(1000, 0)
+	head.RechargeShield(start)
+
+	head.TakeDamage(capacity + 50)
+	if shield := head.Protection().ShieldStrength; shield != 0 {
+		t.Fatalf("shield after a heavy hit = %.1f, want depleted", shield)
+	}
+	head.RechargeShield(start)
+
+	// No recharge within the 3s delay after the hit
+	head.RechargeShield(start.Add(2 * time.Second))
+	if shield := head.Protection().ShieldStrength; shield != 0 {
+		t.Errorf("shield 2s after the hit = %.1f, want 0 during the delay", shield)
+	}
+
+	// 5 points per second once the delay has passed
+	head.RechargeShield(start.Add(4 * time.Second))
+	if shield := head.Protection().ShieldStrength; math.Abs(shield-5) > 1e-9 {
+		t.Errorf("shield 4s after the hit = %.1f, want 5", shield)
+	}
+
+	head.RechargeShield(start.Add(time.Minute))
+	if shield := head.Protection().ShieldStrength; shield != capacity {
+		t.Errorf("shield a minute after the hit = %.1f, want full %.1f", shield, capacity)
+	}
+}
+
+func TestHitRestartsRechargeDelay(t *testing.T) {
+	shield := NewSafeShield(100)
+	if err := shield.SetRecharge(10, time.Second); err != nil {
+		t.Fatalf("SetRecharge: %v", err)
+	}
+	start := time.Unix(1000, 0)
+	shield.Recharge(0, start)
+
+	current := shield.Recharge(0, start.Add(2*time.Second))
+	if current != 20 {
+		t.Fatalf("shield after 2s = %.1f, want 20", current)
+	}
+	shield.NoteHit()
+	current = shield.Recharge(current, start.Add(2*time.Second))
+	if current = shield.Recharge(current, start.Add(2500*time.Millisecond)); current != 20 {
+		t.Errorf("shield 0.5s after a hit = %.1f, want 20 held", current)
+	}
+	if current = shield.Recharge(current, start.Add(4*time.Second)); current != 30 {
+		t.Errorf("shield 2s after a hit = %.1f, want 30", current)
+	}
+	if err := shield.SetRecharge(-1, 0); err == nil {
+		t.Error("negative recharge rate accepted")
+	}
+}
@@ -0,0 +1,57 @@
+package anatomy
+
+import "fmt"
+
+// PartState is the mutable state of a single body part
+type PartState struct {
+	Health     float64    `json:"health"`
+	Protection Protection `json:"protection"`
+}
+
+// AnatomySnapshot captures the mutable state of the whole robot anatomy
+type AnatomySnapshot struct {
+	Parts       map[string]PartState `json:"parts"`
+	Power       float64              `json:"power"`
+	RegenPaused bool                 `json:"regen_paused"`
+}
+
+// Snapshot captures the current state of every part and the power core
+func (ra *RobotAnatomy) Snapshot() AnatomySnapshot {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	snapshot := AnatomySnapshot{
+		Parts:       make(map[string]PartState, len(ra.Parts)),
+		Power:       ra.Power.Level(),
+		RegenPaused: ra.regenPaused,
+	}
+	for name, part := range ra.Parts {
+		snapshot.Parts[name] = PartState{
+			Health:     part.GetHealth(),
+			Protection: part.Protection(),
+		}
+	}
+	return snapshot
+}
+
+// Restore applies a previously captured snapshot. Every part in the snapshot
+// must exist in this anatomy.
+func (ra *RobotAnatomy) Restore(snapshot AnatomySnapshot) error {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+
+	for name := range snapshot.Parts {
+		if _, exists := ra.Parts[name]; !exists {
+			return fmt.Errorf("part not found: %s", name)
+		}
+	}
+
+	for name, state := range snapshot.Parts {
+		part := ra.Parts[name]
+		part.health.Set(state.Health)
+		part.SetProtection(state.Protection)
+	}
+	ra.Power.SetLevel(snapshot.Power)
+	ra.regenPaused = snapshot.RegenPaused
+	return nil
+}
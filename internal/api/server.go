@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"t800/internal/common"
+	"t800/internal/processor"
+)
+
+// maxBodyBytes bounds the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// Server exposes a processor over HTTP
+type Server struct {
+	proc *processor.Processor
+	mux  *http.ServeMux
+
+	shutdownOnce sync.Once
+	done         chan struct{}
+}
+
+// StatusResponse is the body of GET /status
+type StatusResponse struct {
+	Mode           string          `json:"mode"`
+	Posture        string          `json:"posture"`
+	Active         bool            `json:"active"`
+	Location       common.Location `json:"location"`
+	ActiveThreatID string          `json:"active_threat_id,omitempty"`
+}
+
+// ErrorResponse is the body of every error reply
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewServer creates an HTTP server wrapping the given processor
+func NewServer(proc *processor.Processor) *Server {
+	s := &Server{
+		proc: proc,
+		mux:  http.NewServeMux(),
+		done: make(chan struct{}),
+	}
+	s.mux.HandleFunc("/threats", s.handleThreats)
+	s.mux.HandleFunc("/status", s.handleStatus)
+	s.mux.HandleFunc("/health", s.handleHealth)
+	s.mux.HandleFunc("/shutdown", s.handleShutdown)
+	return s
+}
+
+// ServeHTTP dispatches a request to its endpoint
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Done is closed once the processor has been shut down through the API, so
+// the embedding program can stop serving
+func (s *Server) Done() <-chan struct{} {
+	return s.done
+}
+
+// handleThreats reports a threat on POST and lists live threats on GET
+func (s *Server) handleThreats(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.proc.ListThreats())
+	case http.MethodPost:
+		var threat common.Threat
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&threat); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid threat: %v", err))
+			return
+		}
+		if err := threat.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := s.proc.ReportThreat(threat); err != nil {
+			writeError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, threat)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+// handleStatus reports the processor's mode, activity and location
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+	metrics := s.proc.Metrics()
+	writeJSON(w, http.StatusOK, StatusResponse{
+		Mode:           metrics.Mode.String(),
+		Posture:        metrics.Posture.String(),
+		Active:         s.proc.IsActive(),
+		Location:       metrics.Location,
+		ActiveThreatID: metrics.ActiveThreatID,
+	})
+}
+
+// handleHealth reports the health of every body part
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.proc.GetAnatomy().GetHealthStatus())
+}
+
+// handleShutdown stops the processor; repeated requests are harmless
+func (s *Server) handleShutdown(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+	var err error
+	s.shutdownOnce.Do(func() {
+		err = s.proc.Stop(r.Context())
+		close(s.done)
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// methodNotAllowed replies 405 listing the allowed methods
+func methodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	for _, method := range allowed {
+		w.Header().Add("Allow", method)
+	}
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+}
+
+// writeError replies with an error as JSON
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
+
+// writeJSON replies with a value encoded as JSON
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
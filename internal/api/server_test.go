@@ -0,0 +1,188 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+	"t800/internal/monitoring"
+	"t800/internal/processor"
+)
+
+// newTestProcessor returns a quiet processor on a manual clock
+func newTestProcessor(t *testing.T) *processor.Processor {
+	t.Helper()
+	logger := monitoring.NewLogger()
+	logger.SetOutput(io.Discard)
+	proc, err := processor.NewProcessorWithConfig(context.Background(), processor.DefaultProcessorConfig(),
+		processor.WithClock(common.NewManualClock(time.Unix(1_700_000_000, 0))),
+		processor.WithSeed(1),
+		processor.WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("NewProcessorWithConfig: %v", err)
+	}
+	return proc
+}
+
+// newTestServer returns an API server wrapping a test processor, started
+// unless told otherwise
+func newTestServer(t *testing.T, start bool) *httptest.Server {
+	t.Helper()
+	proc := newTestProcessor(t)
+	if start {
+		if err := proc.Start(); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		t.Cleanup(func() { proc.Stop(context.Background()) })
+	}
+
+	server := httptest.NewServer(NewServer(proc))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// getStatus fetches and decodes GET /status
+func getStatus(t *testing.T, server *httptest.Server) StatusResponse {
+	t.Helper()
+	resp, err := http.Get(server.URL + "/status")
+	if err != nil {
+		t.Fatalf("GET /status: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /status = %s, want 200", resp.Status)
+	}
+	var status StatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("decode status: %v", err)
+	}
+	return status
+}
+
+// postThreat reports a threat and returns the response status code
+func postThreat(t *testing.T, server *httptest.Server, body string) int {
+	t.Helper()
+	resp, err := http.Post(server.URL+"/threats", "application/json", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("POST /threats: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestReportedThreatSwitchesToCombat(t *testing.T) {
+	server := newTestServer(t, true)
+	if status := getStatus(t, server); status.Mode != "normal" || !status.Active {
+		t.Fatalf("status before the threat = %+v, want active and normal", status)
+	}
+
+	code := postThreat(t, server, `{"ID": "t1", "Type": "physical", "Severity": 7, "Health": 100, "Location": {"X": 30}}`)
+	if code != http.StatusCreated {
+		t.Fatalf("POST /threats = %d, want 201", code)
+	}
+
+	status := getStatus(t, server)
+	if status.Mode != "combat" || status.ActiveThreatID != "t1" {
+		t.Errorf("status after the threat = %+v, want combat against t1", status)
+	}
+
+	resp, err := http.Get(server.URL + "/threats")
+	if err != nil {
+		t.Fatalf("GET /threats: %v", err)
+	}
+	defer resp.Body.Close()
+	var threats []common.Threat
+	if err := json.NewDecoder(resp.Body).Decode(&threats); err != nil {
+		t.Fatalf("decode threats: %v", err)
+	}
+	if len(threats) != 1 || threats[0].ID != "t1" {
+		t.Errorf("GET /threats = %+v, want t1", threats)
+	}
+}
+
+func TestReportThreatErrors(t *testing.T) {
+	server := newTestServer(t, true)
+	for _, tc := range []struct {
+		name string
+		body string
+		code int
+	}{
+		{"malformed", `{"ID": `, http.StatusBadRequest},
+		{"unknown field", `{"ID": "t1", "Severity": 5, "Color": "red"}`, http.StatusBadRequest},
+		{"severity out of range", `{"ID": "t1", "Severity": 50}`, http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if code := postThreat(t, server, tc.body); code != tc.code {
+				t.Errorf("POST /threats = %d, want %d", code, tc.code)
+			}
+		})
+	}
+
+	stopped := newTestServer(t, false)
+	if code := postThreat(t, stopped, `{"ID": "t1", "Severity": 5}`); code != http.StatusServiceUnavailable {
+		t.Errorf("POST /threats to a stopped processor = %d, want 503", code)
+	}
+}
+
+func TestHealthAndMethods(t *testing.T) {
+	server := newTestServer(t, false)
+
+	resp, err := http.Get(server.URL + "/health")
+	if err != nil {
+		t.Fatalf("GET /health: %v", err)
+	}
+	var health map[string]float64
+	err = json.NewDecoder(resp.Body).Decode(&health)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("decode health: %v", err)
+	}
+	if len(health) != 6 || health["head"] != 100 {
+		t.Errorf("GET /health = %v, want six healthy parts", health)
+	}
+
+	resp, err = http.Post(server.URL+"/status", "application/json", nil)
+	if err != nil {
+		t.Fatalf("POST /status: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get("Allow") != http.MethodGet {
+		t.Errorf("POST /status = %s allowing %q, want 405 allowing GET", resp.Status, resp.Header.Get("Allow"))
+	}
+}
+
+func TestShutdownStopsProcessor(t *testing.T) {
+	proc := newTestProcessor(t)
+	if err := proc.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	api := NewServer(proc)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.Post(server.URL+"/shutdown", "application/json", nil)
+		if err != nil {
+			t.Fatalf("POST /shutdown: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("POST /shutdown #%d = %s, want 204", i+1, resp.Status)
+		}
+	}
+	select {
+	case <-api.Done():
+	default:
+		t.Error("Done not closed after shutdown")
+	}
+	if proc.IsActive() {
+		t.Error("processor still active after shutdown")
+	}
+}
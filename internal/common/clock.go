@@ -0,0 +1,125 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts the source of the current time so behaviour can be driven
+// deterministically in simulations and tests
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks at intervals measured by its clock
+type Ticker interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// RealClock is a Clock backed by the system time
+type RealClock struct{}
+
+// Now returns the current system time
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker returns a ticker backed by the system time
+func (RealClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// realTicker adapts a time.Ticker to the Ticker interface
+type realTicker struct {
+	*time.Ticker
+}
+
+// C returns the channel ticks are delivered on
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// ManualClock is a Clock that only moves when advanced, for simulations
+type ManualClock struct {
+	mu      sync.RWMutex
+	now     time.Time
+	tickers map[*manualTicker]struct{}
+}
+
+// NewManualClock creates a manual clock starting at the given time
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start, tickers: make(map[*manualTicker]struct{})}
+}
+
+// Now returns the clock's current time
+func (c *ManualClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d, firing every ticker that falls due.
+// Like a time.Ticker, a ticker whose last tick has not been received drops
+// further ticks.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+
+	for ticker := range c.tickers {
+		for !ticker.next.After(c.now) {
+			select {
+			case ticker.c <- ticker.next:
+			default:
+			}
+			ticker.next = ticker.next.Add(ticker.period)
+		}
+	}
+}
+
+// NewTicker returns a ticker that fires as the clock is advanced
+func (c *ManualClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for ManualClock.NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ticker := &manualTicker{clock: c, c: make(chan time.Time, 1), period: d, next: c.now.Add(d)}
+	c.tickers[ticker] = struct{}{}
+	return ticker
+}
+
+// manualTicker is a Ticker driven by a ManualClock
+type manualTicker struct {
+	clock  *ManualClock
+	c      chan time.Time
+	period time.Duration
+	next   time.Time
+}
+
+// C returns the channel ticks are delivered on
+func (t *manualTicker) C() <-chan time.Time {
+	return t.c
+}
+
+// Reset changes the ticker's period, with the next tick one period from now
+func (t *manualTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.period, t.next = d, t.clock.now.Add(d)
+	t.clock.tickers[t] = struct{}{}
+}
+
+// Stop turns off the ticker; no more ticks are delivered
+func (t *manualTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	delete(t.clock.tickers, t)
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManualTickerFiresOnlyWhenAdvanced(t *testing.T) {
+	start := time.Unix(1000, 0)
+	clock := NewManualClock(start)
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-ticker.C():
+		t.Fatal("ticker fired before its period elapsed")
+	default:
+	}
+
+	clock.Advance(time.Millisecond)
+	select {
+	case at := <-ticker.C():
+		if want := start.Add(time.Second); !at.Equal(want) {
+			t.Errorf("tick at %v, want %v", at, want)
+		}
+	default:
+		t.Fatal("ticker did not fire after its period")
+	}
+
+	ticker.Reset(5 * time.Second)
+	clock.Advance(2 * time.Second)
+	select {
+	case <-ticker.C():
+		t.Error("ticker fired before its new period elapsed")
+	default:
+	}
+
+	ticker.Stop()
+	clock.Advance(time.Minute)
+	select {
+	case <-ticker.C():
+		t.Error("stopped ticker fired")
+	default:
+	}
+	if got := clock.Now(); !got.Equal(start.Add(time.Minute + 3*time.Second)) {
+		t.Errorf("Now = %v, want %v", got, start.Add(time.Minute+3*time.Second))
+	}
+}
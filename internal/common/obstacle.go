@@ -0,0 +1,65 @@
+package common
+
+import "math"
+
+// Obstacle is an axis-aligned box in the environment that blocks movement and line of sight
+type Obstacle struct {
+	Min Location
+	Max Location
+}
+
+// Center returns the center point of the obstacle
+func (o Obstacle) Center() Location {
+	return Location{
+		X: (o.Min.X + o.Max.X) / 2,
+		Y: (o.Min.Y + o.Max.Y) / 2,
+		Z: (o.Min.Z + o.Max.Z) / 2,
+	}
+}
+
+// Contains reports whether a point lies inside the obstacle
+func (o Obstacle) Contains(loc Location) bool {
+	return loc.X >= o.Min.X && loc.X <= o.Max.X &&
+		loc.Y >= o.Min.Y && loc.Y <= o.Max.Y &&
+		loc.Z >= o.Min.Z && loc.Z <= o.Max.Z
+}
+
+// IntersectsSegment reports whether the segment from -> to passes through the obstacle
+func (o Obstacle) IntersectsSegment(from, to Location) bool {
+	tMin, tMax := 0.0, 1.0
+	axes := [3][4]float64{
+		{from.X, to.X - from.X, o.Min.X, o.Max.X},
+		{from.Y, to.Y - from.Y, o.Min.Y, o.Max.Y},
+		{from.Z, to.Z - from.Z, o.Min.Z, o.Max.Z},
+	}
+	for _, axis := range axes {
+		origin, delta, lo, hi := axis[0], axis[1], axis[2], axis[3]
+		if delta == 0 {
+			// Parallel to this slab: it must already lie within it
+			if origin < lo || origin > hi {
+				return false
+			}
+			continue
+		}
+		t1, t2 := (lo-origin)/delta, (hi-origin)/delta
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		tMin, tMax = math.Max(tMin, t1), math.Min(tMax, t2)
+		if tMin > tMax {
+			return false
+		}
+	}
+	return true
+}
+
+// HasLineOfSight reports whether the straight line between two locations is
+// clear of all obstacles
+func HasLineOfSight(from, to Location, obstacles []Obstacle) bool {
+	for _, obstacle := range obstacles {
+		if obstacle.IntersectsSegment(from, to) {
+			return false
+		}
+	}
+	return true
+}
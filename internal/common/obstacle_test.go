@@ -0,0 +1,28 @@
+package common
+
+import "testing"
+
+func TestHasLineOfSight(t *testing.T) {
+	wall := Obstacle{Min: Location{X: 9, Y: -1, Z: -1}, Max: Location{X: 11, Y: 1, Z: 3}}
+	for _, tc := range []struct {
+		name     string
+		from, to Location
+		clear    bool
+	}{
+		{"through the wall", Location{}, Location{X: 20}, false},
+		{"beside the wall", Location{}, Location{X: 20, Y: 5}, true},
+		{"over the wall", Location{Z: 5}, Location{X: 20, Z: 5}, true},
+		{"short of the wall", Location{}, Location{X: 8}, true},
+		{"diagonally through the wall", Location{X: 0, Y: -5}, Location{X: 20, Y: 5}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := HasLineOfSight(tc.from, tc.to, []Obstacle{wall}); got != tc.clear {
+				t.Errorf("HasLineOfSight = %v, want %v", got, tc.clear)
+			}
+		})
+	}
+
+	if !HasLineOfSight(Location{}, Location{X: 20}, nil) {
+		t.Error("no obstacles blocked the line of sight")
+	}
+}
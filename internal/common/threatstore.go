@@ -0,0 +1,151 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ThreatStore is a thread-safe registry of all known threats, shared between
+// the scanner and the processor so both work from a single source of truth
+type ThreatStore struct {
+	mu      sync.RWMutex
+	threats map[string]Threat
+}
+
+// NewThreatStore creates an empty threat store
+func NewThreatStore() *ThreatStore {
+	return &ThreatStore{
+		threats: make(map[string]Threat),
+	}
+}
+
+// Add inserts a threat, replacing any existing threat with the same ID
+func (ts *ThreatStore) Add(threat Threat) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.threats[threat.ID] = threat
+}
+
+// Update replaces an existing threat
+func (ts *ThreatStore) Update(threat Threat) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if _, exists := ts.threats[threat.ID]; !exists {
+		return fmt.Errorf("threat not found: %s", threat.ID)
+	}
+	ts.threats[threat.ID] = threat
+	return nil
+}
+
+// Relocate moves an existing threat to a new location, leaving the rest of
+// it untouched, and returns the updated threat
+func (ts *ThreatStore) Relocate(id string, location Location) (Threat, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	threat, exists := ts.threats[id]
+	if !exists {
+		return Threat{}, fmt.Errorf("threat not found: %s", id)
+	}
+	threat.Location = location
+	ts.threats[id] = threat
+	return threat, nil
+}
+
+// Observe applies a fresh sighting to an existing threat and returns the
+// updated threat. The threat takes the sighting's location, severity and
+// timestamp, keeps the higher of the two confidences and, when the sighting
+// has a type, its type and description. Health and everything else are left
+// untouched, so damage dealt concurrently is never lost.
+func (ts *ThreatStore) Observe(id string, sighting Threat) (Threat, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	threat, exists := ts.threats[id]
+	if !exists {
+		return Threat{}, fmt.Errorf("threat not found: %s", id)
+	}
+	threat.Location = sighting.Location
+	threat.Severity = sighting.Severity
+	threat.Timestamp = sighting.Timestamp
+	threat.Confidence = max(threat.Confidence, sighting.Confidence)
+	if sighting.Type != "" {
+		threat.Type = sighting.Type
+		threat.Description = sighting.Description
+	}
+	ts.threats[id] = threat
+	return threat, nil
+}
+
+// Damage reduces an existing threat's health by up to amount, never below
+// zero, and returns the updated threat and the damage actually dealt
+func (ts *ThreatStore) Damage(id string, amount float64) (Threat, float64, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	threat, exists := ts.threats[id]
+	if !exists {
+		return Threat{}, 0, fmt.Errorf("threat not found: %s", id)
+	}
+	damage := min(max(0, amount), threat.Health)
+	threat.Health -= damage
+	ts.threats[id] = threat
+	return threat, damage, nil
+}
+
+// Remove deletes a threat and reports whether it was present
+func (ts *ThreatStore) Remove(id string) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	_, exists := ts.threats[id]
+	delete(ts.threats, id)
+	return exists
+}
+
+// Get returns a copy of the threat with the given ID
+func (ts *ThreatStore) Get(id string) (Threat, bool) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	threat, exists := ts.threats[id]
+	return threat, exists
+}
+
+// List returns copies of all known threats ordered by detection time
+func (ts *ThreatStore) List() []Threat {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	threats := make([]Threat, 0, len(ts.threats))
+	for _, threat := range ts.threats {
+		threats = append(threats, threat)
+	}
+	sort.Slice(threats, func(i, j int) bool {
+		if threats[i].Timestamp != threats[j].Timestamp {
+			return threats[i].Timestamp < threats[j].Timestamp
+		}
+		return threats[i].ID < threats[j].ID
+	})
+	return threats
+}
+
+// Reset replaces the contents of the store with the given threats
+func (ts *ThreatStore) Reset(threats []Threat) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.threats = make(map[string]Threat, len(threats))
+	for _, threat := range threats {
+		ts.threats[threat.ID] = threat
+	}
+}
+
+// Len returns the number of known threats
+func (ts *ThreatStore) Len() int {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	return len(ts.threats)
+}
@@ -0,0 +1,81 @@
+package common
+
+import "testing"
+
+func TestThreatStoreCRUD(t *testing.T) {
+	store := NewThreatStore()
+	store.Add(Threat{ID: "late", Timestamp: 20})
+	store.Add(Threat{ID: "early", Timestamp: 10})
+
+	if got := store.Len(); got != 2 {
+		t.Fatalf("Len = %d, want 2", got)
+	}
+	if list := store.List(); list[0].ID != "early" || list[1].ID != "late" {
+		t.Errorf("List order = %s, %s, want early, late", list[0].ID, list[1].ID)
+	}
+
+	if err := store.Update(Threat{ID: "early", Timestamp: 10, Severity: 9}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if threat, _ := store.Get("early"); threat.Severity != 9 {
+		t.Errorf("severity after Update = %d, want 9", threat.Severity)
+	}
+	if err := store.Update(Threat{ID: "missing"}); err == nil {
+		t.Error("Update of an unknown threat succeeded")
+	}
+
+	if moved, err := store.Relocate("late", Location{X: 3}); err != nil || moved.Location.X != 3 {
+		t.Errorf("Relocate = %+v, %v", moved, err)
+	}
+
+	if !store.Remove("late") || store.Remove("late") {
+		t.Error("Remove did not report presence correctly")
+	}
+	if _, exists := store.Get("late"); exists {
+		t.Error("removed threat still present")
+	}
+
+	store.Reset([]Threat{{ID: "a"}, {ID: "b"}, {ID: "c"}})
+	if got := store.Len(); got != 3 {
+		t.Errorf("Len after Reset = %d, want 3", got)
+	}
+}
+
+func TestObserveKeepsDamage(t *testing.T) {
+	store := NewThreatStore()
+	store.Add(Threat{ID: "t1", Type: "physical", Health: 100, Confidence: 0.9})
+
+	if _, _, err := store.Damage("t1", 30); err != nil {
+		t.Fatalf("Damage: %v", err)
+	}
+	observed, err := store.Observe("t1", Threat{Location: Location{X: 5}, Severity: 7, Confidence: 0.5})
+	if err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+
+	if observed.Health != 70 {
+		t.Errorf("health = %v, want 70", observed.Health)
+	}
+	if observed.Location.X != 5 || observed.Severity != 7 {
+		t.Errorf("sighting not applied: %+v", observed)
+	}
+	if observed.Confidence != 0.9 || observed.Type != "physical" {
+		t.Errorf("confidence or type overwritten: %+v", observed)
+	}
+}
+
+func TestDamageStopsAtZero(t *testing.T) {
+	store := NewThreatStore()
+	store.Add(Threat{ID: "t1", Health: 20})
+
+	threat, dealt, err := store.Damage("t1", 50)
+	if err != nil {
+		t.Fatalf("Damage: %v", err)
+	}
+	if dealt != 20 || threat.Health != 0 {
+		t.Errorf("dealt %v leaving %v, want 20 leaving 0", dealt, threat.Health)
+	}
+	if _, _, err := store.Damage("missing", 10); err == nil {
+		t.Error("Damage on an unknown threat succeeded")
+	}
+}
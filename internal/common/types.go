@@ -1,6 +1,9 @@
 package common
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 // Location represents 3D coordinates
 type Location struct {
@@ -11,43 +14,70 @@ type Location struct {
 
 // MovementSpeed represents the robot's movement capabilities
 type MovementSpeed struct {
-	Linear  float64 // meters per second
-	Angular float64 // radians per second
+	Linear   float64 // meters per second
+	Angular  float64 // radians per second
+	MaxAccel float64 // meters per second squared; zero reaches full speed instantly
 }
 
 // DefaultSpeed returns the default movement speed configuration
 func DefaultSpeed() MovementSpeed {
 	return MovementSpeed{
-		Linear:  5.0,  // 5 meters per second
-		Angular: math.Pi / 2, // 90 degrees per second
+		Linear:   5.0,         // 5 meters per second
+		Angular:  math.Pi / 2, // 90 degrees per second
+		MaxAccel: 2.5,         // 2 seconds from standstill to full speed
 	}
 }
 
+// AccelerateTowards advances a body with the given velocity toward a target
+// for deltaTime seconds, changing its velocity by at most maxAccel*deltaTime.
+// It heads for the target at up to maxSpeed, braking so as to stop on it,
+// and returns the new position and velocity. A non-positive maxAccel changes
+// velocity instantly, as MoveTowards does.
+func (loc *Location) AccelerateTowards(target, velocity Location, maxSpeed, maxAccel, deltaTime float64) (Location, Location) {
+	distance := CalculateDistance(*loc, target)
+	if maxAccel <= 0 {
+		next := loc.MoveTowards(target, maxSpeed, deltaTime)
+		if deltaTime <= 0 {
+			return next, Location{}
+		}
+		return next, next.Sub(*loc).Scale(1 / deltaTime)
+	}
+
+	// Desired velocity: toward the target, no faster than allows stopping on
+	// it in whole steps of deltaTime
+	var desired Location
+	if distance > 0 {
+		step := maxAccel * deltaTime
+		speed := math.Min(maxSpeed, step*(math.Sqrt(0.25+2*distance/(step*deltaTime))-0.5))
+		desired = Normalize(target.Sub(*loc)).Scale(speed)
+	}
+
+	// Steer toward it within the acceleration limit
+	change := desired.Sub(velocity)
+	if limit := maxAccel * deltaTime; CalculateDistance(Location{}, change) > limit {
+		change = Normalize(change).Scale(limit)
+	}
+	previous := velocity
+	velocity = velocity.Add(change)
+
+	next := loc.Add(velocity.Scale(deltaTime))
+	// Settle on the target once it is within a step and the body can stop
+	if distance <= CalculateDistance(*loc, next) && CalculateDistance(Location{}, previous) <= maxAccel*deltaTime {
+		return target, Location{}
+	}
+	return next, velocity
+}
+
 // MoveTowards calculates new position when moving towards a target
 func (loc *Location) MoveTowards(target Location, speed float64, deltaTime float64) Location {
-	direction := Location{
-		X: target.X - loc.X,
-		Y: target.Y - loc.Y,
-		Z: target.Z - loc.Z,
-	}
-	
 	distance := CalculateDistance(*loc, target)
 	if distance == 0 {
 		return *loc
 	}
-	
-	// Normalize direction and multiply by speed and time
-	moveDistance := speed * deltaTime
-	if moveDistance > distance {
-		moveDistance = distance
-	}
-	
-	scale := moveDistance / distance
-	return Location{
-		X: loc.X + direction.X * scale,
-		Y: loc.Y + direction.Y * scale,
-		Z: loc.Z + direction.Z * scale,
-	}
+
+	// Step along the direction by speed and time, stopping on the target
+	moveDistance := math.Min(speed*deltaTime, distance)
+	return loc.Add(Normalize(target.Sub(*loc)).Scale(moveDistance))
 }
 
 // Threat represents a potential threat to the robot
@@ -58,7 +88,49 @@ type Threat struct {
 	Severity    int
 	Timestamp   int64
 	Description string
-	Health      float64 // Health percentage (0-100)
+	Health      float64  // Health percentage (0-100)
+	Confidence  float64  // Detection confidence (0-1)
+	Velocity    Location // Velocity in meters per second
+	Radius      float64  // Bounding radius in meters; zero assumes the robot's own size
+}
+
+const (
+	// MinSeverity and MaxSeverity bound a threat's severity
+	MinSeverity = 1
+	MaxSeverity = 10
+)
+
+// ClampSeverity limits a severity to the range MinSeverity to MaxSeverity
+func ClampSeverity(severity int) int {
+	return min(max(severity, MinSeverity), MaxSeverity)
+}
+
+// Validate checks that every coordinate is a finite number
+func (l Location) Validate() error {
+	for i, v := range [...]float64{l.X, l.Y, l.Z} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%c coordinate must be finite, got %v", "xyz"[i], v)
+		}
+	}
+	return nil
+}
+
+// Validate checks that a threat has an ID, a severity within range and a
+// finite location
+func (t Threat) Validate() error {
+	if t.ID == "" {
+		return fmt.Errorf("threat ID is required")
+	}
+	if t.Severity < MinSeverity || t.Severity > MaxSeverity {
+		return fmt.Errorf("threat %s: severity must be between %d and %d, got %d", t.ID, MinSeverity, MaxSeverity, t.Severity)
+	}
+	if err := t.Location.Validate(); err != nil {
+		return fmt.Errorf("threat %s: invalid location: %v", t.ID, err)
+	}
+	if t.Radius < 0 {
+		return fmt.Errorf("threat %s: radius must not be negative", t.ID)
+	}
+	return nil
 }
 
 // OperationMode defines the current operation mode
@@ -69,8 +141,66 @@ const (
 	Combat
 	Emergency
 	Maintenance
+	Standby
+	Retreating
 )
 
+// String returns a human readable name for the operation mode
+func (m OperationMode) String() string {
+	switch m {
+	case Normal:
+		return "normal"
+	case Combat:
+		return "combat"
+	case Emergency:
+		return "emergency"
+	case Maintenance:
+		return "maintenance"
+	case Standby:
+		return "standby"
+	case Retreating:
+		return "retreating"
+	default:
+		return fmt.Sprintf("mode(%d)", int(m))
+	}
+}
+
+// WouldCollide reports whether two bodies with the given bounding radii
+// overlap when centered at a and b
+func WouldCollide(a Location, ra float64, b Location, rb float64) bool {
+	return CalculateDistance(a, b) < ra+rb
+}
+
+// StopShortOf limits a move from -> to so a body of the given radius halts
+// at contact with another body instead of overlapping or passing through
+// it. A body already overlapping may only move further away.
+func StopShortOf(from, to Location, radius float64, other Location, otherRadius float64) Location {
+	contact := radius + otherRadius
+	start := CalculateDistance(from, other)
+	if start < contact {
+		if CalculateDistance(to, other) < start {
+			return from
+		}
+		return to
+	}
+
+	// Find the earliest t in [0, 1] with |from + t*d - other| = contact
+	d := to.Sub(from)
+	f := from.Sub(other)
+	a := d.X*d.X + d.Y*d.Y + d.Z*d.Z
+	b := 2 * (f.X*d.X + f.Y*d.Y + f.Z*d.Z)
+	c := f.X*f.X + f.Y*f.Y + f.Z*f.Z - contact*contact
+	disc := b*b - 4*a*c
+	if a == 0 || disc < 0 {
+		return to
+	}
+	t := (-b - math.Sqrt(disc)) / (2 * a)
+	if t < 0 || t > 1 {
+		return to
+	}
+	return from.Add(d.Scale(t))
+}
+
 // CalculateDistance computes the Euclidean distance between two locations
 func CalculateDistance(loc1, loc2 Location) float64 {
 	return math.Sqrt(
@@ -78,4 +208,77 @@ func CalculateDistance(loc1, loc2 Location) float64 {
 			math.Pow(loc1.Y-loc2.Y, 2) +
 			math.Pow(loc1.Z-loc2.Z, 2),
 	)
-} 
\ No newline at end of file
+}
+
+// Bearing returns the direction from one location to another in radians,
+// counterclockwise from the X axis, in (-π, π]
+func Bearing(from, to Location) float64 {
+	return math.Atan2(to.Y-from.Y, to.X-from.X)
+}
+
+// Add returns the vector sum of two locations
+func (loc Location) Add(other Location) Location {
+	return Location{X: loc.X + other.X, Y: loc.Y + other.Y, Z: loc.Z + other.Z}
+}
+
+// Sub returns the vector from other to the location
+func (loc Location) Sub(other Location) Location {
+	return Location{X: loc.X - other.X, Y: loc.Y - other.Y, Z: loc.Z - other.Z}
+}
+
+// Scale returns the location multiplied by a factor
+func (loc Location) Scale(factor float64) Location {
+	return Location{X: loc.X * factor, Y: loc.Y * factor, Z: loc.Z * factor}
+}
+
+// Normalize returns the unit vector in the direction of v, or the zero
+// vector if v has no length
+func Normalize(v Location) Location {
+	length := CalculateDistance(Location{}, v)
+	if length == 0 {
+		return Location{}
+	}
+	return v.Scale(1 / length)
+}
+
+// AngleBetween returns the unsigned angle in radians, in [0, π], between two
+// vectors, or zero if either has no length. Unlike TurnAngle, which compares
+// headings, it measures between directions in 3D.
+func AngleBetween(a, b Location) float64 {
+	if a == (Location{}) || b == (Location{}) {
+		return 0
+	}
+	// atan2 of the cross and dot products stays accurate near 0 and π, where acos does not
+	cross := Location{X: a.Y*b.Z - a.Z*b.Y, Y: a.Z*b.X - a.X*b.Z, Z: a.X*b.Y - a.Y*b.X}
+	dot := a.X*b.X + a.Y*b.Y + a.Z*b.Z
+	return math.Atan2(CalculateDistance(Location{}, cross), dot)
+}
+
+// NormalizeAngle wraps an angle in radians into (-π, π]
+func NormalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle, 2*math.Pi)
+	switch {
+	case angle <= -math.Pi:
+		angle += 2 * math.Pi
+	case angle > math.Pi:
+		angle -= 2 * math.Pi
+	}
+	return angle
+}
+
+// TurnAngle returns the signed shortest rotation in radians from one
+// heading to another, positive counterclockwise
+func TurnAngle(from, to float64) float64 {
+	return NormalizeAngle(to - from)
+}
+
+// Rotate returns the location rotated about the Z axis by angle radians,
+// counterclockwise
+func (loc Location) Rotate(angle float64) Location {
+	sin, cos := math.Sincos(angle)
+	return Location{
+		X: loc.X*cos - loc.Y*sin,
+		Y: loc.X*sin + loc.Y*cos,
+		Z: loc.Z,
+	}
+}
@@ -0,0 +1,217 @@
+package common
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func TestBearing(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		from, to Location
+		want     float64
+	}{
+		{"ahead", Location{}, Location{X: 1}, 0},
+		{"left", Location{}, Location{Y: 1}, math.Pi / 2},
+		{"behind", Location{}, Location{X: -1}, math.Pi},
+		{"right", Location{X: 2, Y: 2}, Location{X: 2, Y: -3}, -math.Pi / 2},
+		{"height ignored", Location{}, Location{X: 1, Z: 5}, 0},
+		{"same point", Location{X: 3, Y: 4}, Location{X: 3, Y: 4}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Bearing(tc.from, tc.to); math.Abs(got-tc.want) > epsilon {
+				t.Errorf("Bearing = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVectorArithmetic(t *testing.T) {
+	a, b := Location{X: 1, Y: 2, Z: 3}, Location{X: -4, Y: 0.5, Z: 2}
+
+	if got, want := a.Add(b), (Location{X: -3, Y: 2.5, Z: 5}); got != want {
+		t.Errorf("Add = %+v, want %+v", got, want)
+	}
+	if got, want := a.Sub(b), (Location{X: 5, Y: 1.5, Z: 1}); got != want {
+		t.Errorf("Sub = %+v, want %+v", got, want)
+	}
+	if got, want := a.Scale(-2), (Location{X: -2, Y: -4, Z: -6}); got != want {
+		t.Errorf("Scale = %+v, want %+v", got, want)
+	}
+	if got := a.Scale(0); got != (Location{}) {
+		t.Errorf("Scale(0) = %+v, want the zero vector", got)
+	}
+	if got := a.Sub(a); got != (Location{}) {
+		t.Errorf("a.Sub(a) = %+v, want the zero vector", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize(Location{}); got != (Location{}) {
+		t.Errorf("Normalize(zero) = %+v, want the zero vector", got)
+	}
+
+	for _, v := range []Location{{X: 3, Y: 4}, {Z: -0.001}, {X: 1e6, Y: -1e6, Z: 1e6}} {
+		unit := Normalize(v)
+		if length := CalculateDistance(Location{}, unit); math.Abs(length-1) > epsilon {
+			t.Errorf("Normalize(%+v) has length %v, want 1", v, length)
+		}
+		if angle := AngleBetween(unit, v); angle > 1e-6 {
+			t.Errorf("Normalize(%+v) turned the vector by %v", v, angle)
+		}
+	}
+}
+
+func TestAngleBetween(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		a, b Location
+		want float64
+	}{
+		{"same direction", Location{X: 1}, Location{X: 5}, 0},
+		{"perpendicular", Location{X: 1}, Location{Y: 2}, math.Pi / 2},
+		{"opposite", Location{X: 1, Y: 1}, Location{X: -2, Y: -2}, math.Pi},
+		{"out of plane", Location{X: 1}, Location{X: 1, Z: 1}, math.Pi / 4},
+		{"zero first", Location{}, Location{X: 1}, 0},
+		{"zero second", Location{Y: 1}, Location{}, 0},
+		{"both zero", Location{}, Location{}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AngleBetween(tc.a, tc.b); math.Abs(got-tc.want) > epsilon {
+				t.Errorf("AngleBetween = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTurnAngle(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		from, to float64
+		want     float64
+	}{
+		{"left", 0, math.Pi / 2, math.Pi / 2},
+		{"right", 0, -math.Pi / 2, -math.Pi / 2},
+		{"across the wrap", 3 * math.Pi / 4, -3 * math.Pi / 4, math.Pi / 2},
+		{"full turns", 0, 4 * math.Pi, 0},
+		{"about face", 0, math.Pi, math.Pi},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := TurnAngle(tc.from, tc.to); math.Abs(got-tc.want) > epsilon {
+				t.Errorf("TurnAngle = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAccelerateTowardsLimitsAccelerationAndSettles(t *testing.T) {
+	const maxSpeed, maxAccel, dt = 5.0, 2.5, 0.1
+	target := Location{X: 20}
+	var loc, velocity Location
+
+	arrived := false
+	for tick := 0; tick < 200; tick++ {
+		next, nextVelocity := loc.AccelerateTowards(target, velocity, maxSpeed, maxAccel, dt)
+		if change := CalculateDistance(velocity, nextVelocity); change > maxAccel*dt+epsilon {
+			t.Fatalf("tick %d: velocity changed by %.4f, want at most %.4f", tick, change, maxAccel*dt)
+		}
+		if speed := CalculateDistance(Location{}, nextVelocity); speed > maxSpeed+epsilon {
+			t.Fatalf("tick %d: speed %.4f exceeds %.1f", tick, speed, maxSpeed)
+		}
+		if next.X > target.X+epsilon {
+			t.Fatalf("tick %d: overshot the target to %.4f", tick, next.X)
+		}
+		loc, velocity = next, nextVelocity
+		if loc == target && velocity == (Location{}) {
+			arrived = true
+			break
+		}
+	}
+	if !arrived {
+		t.Fatalf("robot at %+v moving at %+v after 200 ticks, want it at rest on the target", loc, velocity)
+	}
+}
+
+func TestAccelerateTowardsWithoutLimitMovesInstantly(t *testing.T) {
+	loc := Location{}
+	next, velocity := loc.AccelerateTowards(Location{X: 20}, Location{}, 5, 0, 0.1)
+	if math.Abs(next.X-0.5) > epsilon || math.Abs(velocity.X-5) > epsilon {
+		t.Errorf("AccelerateTowards = %+v, %+v; want full speed at once", next, velocity)
+	}
+}
+
+func TestThreatValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		threat Threat
+		valid  bool
+	}{
+		{"valid", Threat{ID: "t1", Severity: 5, Location: Location{X: 10}}, true},
+		{"bounds", Threat{ID: "t1", Severity: MaxSeverity}, true},
+		{"missing ID", Threat{Severity: 5}, false},
+		{"severity too low", Threat{ID: "t1", Severity: 0}, false},
+		{"negative severity", Threat{ID: "t1", Severity: -3}, false},
+		{"severity too high", Threat{ID: "t1", Severity: 11}, false},
+		{"NaN coordinate", Threat{ID: "t1", Severity: 5, Location: Location{Y: math.NaN()}}, false},
+		{"infinite coordinate", Threat{ID: "t1", Severity: 5, Location: Location{Z: math.Inf(-1)}}, false},
+		{"negative radius", Threat{ID: "t1", Severity: 5, Radius: -1}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.threat.Validate(); (err == nil) != tc.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestWouldCollide(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		b    Location
+		want bool
+	}{
+		{"overlapping", Location{X: 1}, true},
+		{"touching", Location{X: 1.5}, false},
+		{"apart", Location{Y: 3}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := WouldCollide(Location{}, 0.5, tc.b, 1); got != tc.want {
+				t.Errorf("WouldCollide = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStopShortOf(t *testing.T) {
+	other := Location{X: 10}
+	for _, tc := range []struct {
+		name     string
+		from, to Location
+		want     Location
+	}{
+		{"stops at contact", Location{}, Location{X: 20}, Location{X: 8.5}},
+		{"short move unaffected", Location{}, Location{X: 5}, Location{X: 5}},
+		{"passing wide unaffected", Location{Y: 5}, Location{X: 20, Y: 5}, Location{X: 20, Y: 5}},
+		{"overlapping may back away", Location{X: 9}, Location{X: 8}, Location{X: 8}},
+		{"overlapping may not close in", Location{X: 9}, Location{X: 9.5}, Location{X: 9}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := StopShortOf(tc.from, tc.to, 0.5, other, 1)
+			if CalculateDistance(got, tc.want) > epsilon {
+				t.Errorf("StopShortOf = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClampSeverity(t *testing.T) {
+	for _, tc := range []struct{ in, want int }{
+		{-5, MinSeverity}, {0, MinSeverity}, {1, 1}, {6, 6}, {10, 10}, {11, MaxSeverity}, {9999, MaxSeverity},
+	} {
+		if got := ClampSeverity(tc.in); got != tc.want {
+			t.Errorf("ClampSeverity(%d) = %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// Config gathers the tunables of every subsystem. Fields missing from a
+// config file keep their defaults.
+type Config struct {
+	Processor ProcessorSettings         `json:"processor"`
+	Scanner   ScannerSettings           `json:"scanner"`
+	Anatomy   AnatomySettings           `json:"anatomy"`
+	AI        AISettings                `json:"ai"`
+	Weapons   map[string]WeaponSettings `json:"weapons"`
+	Logging   LoggingSettings           `json:"logging"`
+}
+
+// ProcessorSettings tunes engagement and movement
+type ProcessorSettings struct {
+	EngagementDistance float64 `json:"engagement_distance"` // Meters within which threats are engaged
+	LinearSpeed        float64 `json:"linear_speed"`        // Meters per second
+	AngularSpeed       float64 `json:"angular_speed"`       // Radians per second
+	MaxAccel           float64 `json:"max_accel"`           // Meters per second squared; zero is instant
+}
+
+// ScannerSettings tunes threat detection
+type ScannerSettings struct {
+	Range               float64 `json:"range"`                // Detection range in meters
+	Resolution          float64 `json:"resolution"`           // Detection resolution in meters
+	PredictionThreshold float64 `json:"prediction_threshold"` // Probability (0-1) at which predictions become threats
+	MergeRadius         float64 `json:"merge_radius"`         // Meters within which detections are one threat
+	FieldOfView         float64 `json:"field_of_view"`        // Degrees scanned about the heading; 0 or 360 sweeps all around
+}
+
+// AnatomySettings tunes regeneration, shields and the power core
+type AnatomySettings struct {
+	RegenRate           float64            `json:"regen_rate"`            // Fraction of maximum health regenerated per second
+	RegenRates          map[string]float64 `json:"regen_rates"`           // Per part type regeneration, overriding regen_rate
+	CritChance          float64            `json:"crit_chance"`           // Chance (0-1) of a hit striking a weak point
+	ShieldRechargeRate  float64            `json:"shield_recharge_rate"`  // Shield points recharged per second
+	ShieldRechargeDelay float64            `json:"shield_recharge_delay"` // Seconds after a hit before shields recharge
+	PowerCapacity       float64            `json:"power_capacity"`        // Energy the power core holds
+	PowerRechargeRate   float64            `json:"power_recharge_rate"`   // Energy recharged per second
+}
+
+// AISettings configures the Ollama decision maker
+type AISettings struct {
+	Enabled         bool     `json:"enabled"`
+	BaseURL         string   `json:"base_url"`
+	Model           string   `json:"model"`
+	Temperature     *float64 `json:"temperature,omitempty"` // Nil leaves the model default
+	MaxTokens       int      `json:"max_tokens"`            // Zero leaves the model default
+	SystemPrompt    string   `json:"system_prompt"`
+	MaxAttempts     int      `json:"max_attempts"`
+	TimeoutSeconds  float64  `json:"timeout_seconds"`
+	CacheTTLSeconds float64  `json:"cache_ttl_seconds"` // Seconds a combat decision is reused; zero disables caching
+	CacheSize       int      `json:"cache_size"`        // Situations remembered by the decision cache
+}
+
+// LoggingSettings selects the log level, format and output
+type LoggingSettings struct {
+	Level      string  `json:"level"`       // "debug", "info", "warn" or "error"
+	Format     string  `json:"format"`      // "text" or "json"
+	Output     string  `json:"output"`      // "stdout", "stderr" or a file path
+	MaxSizeMB  float64 `json:"max_size_mb"` // Size at which a log file is rotated; zero never rotates
+	MaxBackups int     `json:"max_backups"` // Rotated log files kept
+}
+
+// WeaponSettings tunes a single weapon
+type WeaponSettings struct {
+	PowerUsage  float64 `json:"power_usage"`
+	MinRange    float64 `json:"min_range"`
+	Range       float64 `json:"range"`
+	Magazine    int     `json:"magazine"` // Rounds held when reloaded; zero keeps the weapon's default
+	HeatPerShot float64 `json:"heat_per_shot"`
+}
+
+// Default returns the configuration matching the built-in defaults
+func Default() *Config {
+	return &Config{
+		Processor: ProcessorSettings{
+			EngagementDistance: 50.0,
+			LinearSpeed:        5.0,
+			AngularSpeed:       math.Pi / 2,
+			MaxAccel:           2.5,
+		},
+		Scanner: ScannerSettings{
+			Range:               100.0,
+			Resolution:          0.1,
+			PredictionThreshold: 0.7,
+			MergeRadius:         2.0,
+		},
+		Anatomy: AnatomySettings{
+			RegenRate:           0.1,
+			RegenRates:          map[string]float64{"head": 0.05, "body": 0.05, "arm": 0.1, "leg": 0.1},
+			CritChance:          0.1,
+			ShieldRechargeRate:  5.0,
+			ShieldRechargeDelay: 3.0,
+			PowerCapacity:       1000.0,
+			PowerRechargeRate:   20.0,
+		},
+		AI: AISettings{
+			BaseURL:         "http://localhost:11434",
+			Model:           "llama3.2",
+			MaxAttempts:     3,
+			TimeoutSeconds:  5,
+			CacheTTLSeconds: 2,
+			CacheSize:       128,
+		},
+		Weapons: map[string]WeaponSettings{
+			"plasma_cannon": {PowerUsage: 75.0, Range: 50.0, HeatPerShot: 35.0},
+			"missile":       {PowerUsage: 90.0, MinRange: 10.0, Range: 100.0, Magazine: 8},
+			"emp_pulse":     {PowerUsage: 85.0, Range: 30.0},
+			"laser_beam":    {PowerUsage: 60.0, Range: 40.0, HeatPerShot: 25.0},
+		},
+		Logging: LoggingSettings{
+			Level:      "info",
+			Format:     "text",
+			Output:     "stdout",
+			MaxBackups: 3,
+		},
+	}
+}
+
+// LoadConfig reads a JSON config file over the defaults and validates it.
+// A weapon entry only overrides the fields it sets.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %v", err)
+	}
+
+	cfg := Default()
+	file := struct {
+		*Config
+		Weapons map[string]json.RawMessage `json:"weapons"`
+	}{Config: cfg}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	for name, raw := range file.Weapons {
+		weapon := cfg.Weapons[name]
+		if err := json.Unmarshal(raw, &weapon); err != nil {
+			return nil, fmt.Errorf("failed to parse weapon %s: %v", name, err)
+		}
+		cfg.Weapons[name] = weapon
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate reports the first invalid value in the configuration
+func (c *Config) Validate() error {
+	switch {
+	case c.Processor.EngagementDistance < 0:
+		return fmt.Errorf("engagement distance must not be negative")
+	case c.Processor.LinearSpeed < 0 || c.Processor.AngularSpeed < 0 || c.Processor.MaxAccel < 0:
+		return fmt.Errorf("speeds must not be negative")
+	case c.Scanner.Range <= 0:
+		return fmt.Errorf("scanner range must be positive")
+	case c.Scanner.Resolution <= 0:
+		return fmt.Errorf("scanner resolution must be positive")
+	case c.Scanner.PredictionThreshold < 0 || c.Scanner.PredictionThreshold > 1:
+		return fmt.Errorf("prediction threshold must be between 0 and 1")
+	case c.Scanner.MergeRadius < 0:
+		return fmt.Errorf("merge radius must not be negative")
+	case c.Scanner.FieldOfView < 0 || c.Scanner.FieldOfView > 360:
+		return fmt.Errorf("field of view must be between 0 and 360 degrees")
+	case c.Anatomy.RegenRate < 0:
+		return fmt.Errorf("regeneration rate must not be negative")
+	case c.Anatomy.CritChance < 0 || c.Anatomy.CritChance > 1:
+		return fmt.Errorf("critical hit chance must be between 0 and 1")
+	case c.Anatomy.ShieldRechargeRate < 0 || c.Anatomy.ShieldRechargeDelay < 0:
+		return fmt.Errorf("shield recharge rate and delay must not be negative")
+	case c.Anatomy.PowerCapacity <= 0:
+		return fmt.Errorf("power capacity must be positive")
+	case c.Anatomy.PowerRechargeRate < 0:
+		return fmt.Errorf("power recharge rate must not be negative")
+	case c.AI.Temperature != nil && *c.AI.Temperature < 0:
+		return fmt.Errorf("temperature must not be negative")
+	case c.AI.MaxTokens < 0:
+		return fmt.Errorf("max tokens must not be negative")
+	case c.AI.MaxAttempts < 1:
+		return fmt.Errorf("max attempts must be at least 1")
+	case c.AI.TimeoutSeconds <= 0:
+		return fmt.Errorf("AI timeout must be positive")
+	case c.AI.CacheTTLSeconds < 0 || c.AI.CacheSize < 0:
+		return fmt.Errorf("AI cache TTL and size must not be negative")
+	case c.Logging.MaxSizeMB < 0 || c.Logging.MaxBackups < 0:
+		return fmt.Errorf("log rotation size and backups must not be negative")
+	}
+	switch c.Logging.Level {
+	case "", "debug", "info", "warn", "warning", "error":
+	default:
+		return fmt.Errorf("unknown log level %q", c.Logging.Level)
+	}
+	switch c.Logging.Format {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("unknown log format %q", c.Logging.Format)
+	}
+
+	partTypes := make([]string, 0, len(c.Anatomy.RegenRates))
+	for partType := range c.Anatomy.RegenRates {
+		partTypes = append(partTypes, partType)
+	}
+	sort.Strings(partTypes)
+	for _, partType := range partTypes {
+		if c.Anatomy.RegenRates[partType] < 0 {
+			return fmt.Errorf("%s regeneration rate must not be negative", partType)
+		}
+	}
+
+	names := make([]string, 0, len(c.Weapons))
+	for name := range c.Weapons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		weapon := c.Weapons[name]
+		switch {
+		case weapon.PowerUsage < 0:
+			return fmt.Errorf("weapon %s: power usage must not be negative", name)
+		case weapon.MinRange < 0 || weapon.Range < 0:
+			return fmt.Errorf("weapon %s: ranges must not be negative", name)
+		case weapon.MinRange > weapon.Range:
+			return fmt.Errorf("weapon %s: minimum range exceeds range", name)
+		case weapon.Magazine < 0:
+			return fmt.Errorf("weapon %s: magazine must not be negative", name)
+		case weapon.HeatPerShot < 0:
+			return fmt.Errorf("weapon %s: heat per shot must not be negative", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a config file into a temporary directory
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadFullConfig(t *testing.T) {
+	path := writeConfig(t, `{
+		"processor": {"engagement_distance": 30, "linear_speed": 4, "angular_speed": 1, "max_accel": 2},
+		"scanner": {"range": 80, "resolution": 0.5, "prediction_threshold": 0.6, "merge_radius": 3, "field_of_view": 120},
+		"anatomy": {"regen_rate": 0.2, "regen_rates": {"head": 0.1}, "crit_chance": 0.05,
+			"shield_recharge_rate": 4, "shield_recharge_delay": 2, "power_capacity": 800, "power_recharge_rate": 15},
+		"ai": {"enabled": true, "base_url": "http://ollama:11434", "model": "mistral", "temperature": 0.3,
+			"max_tokens": 256, "system_prompt": "be terse", "max_attempts": 2, "timeout_seconds": 3,
+			"cache_ttl_seconds": 1, "cache_size": 16},
+		"weapons": {"plasma_cannon": {"power_usage": 60, "range": 45, "heat_per_shot": 30}},
+		"logging": {"level": "debug", "format": "json", "output": "stderr", "max_size_mb": 10, "max_backups": 5}
+	}`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	temperature := 0.3
+	want := Default()
+	want.Processor = ProcessorSettings{EngagementDistance: 30, LinearSpeed: 4, AngularSpeed: 1, MaxAccel: 2}
+	want.Scanner = ScannerSettings{Range: 80, Resolution: 0.5, PredictionThreshold: 0.6, MergeRadius: 3, FieldOfView: 120}
+	want.Anatomy = AnatomySettings{
+		RegenRate: 0.2, RegenRates: map[string]float64{"head": 0.1, "body": 0.05, "arm": 0.1, "leg": 0.1}, CritChance: 0.05,
+		ShieldRechargeRate: 4, ShieldRechargeDelay: 2, PowerCapacity: 800, PowerRechargeRate: 15,
+	}
+	want.AI = AISettings{
+		Enabled: true, BaseURL: "http://ollama:11434", Model: "mistral", Temperature: &temperature,
+		MaxTokens: 256, SystemPrompt: "be terse", MaxAttempts: 2, TimeoutSeconds: 3,
+		CacheTTLSeconds: 1, CacheSize: 16,
+	}
+	want.Weapons["plasma_cannon"] = WeaponSettings{PowerUsage: 60, Range: 45, HeatPerShot: 30}
+	want.Logging = LoggingSettings{Level: "debug", Format: "json", Output: "stderr", MaxSizeMB: 10, MaxBackups: 5}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("LoadConfig =\n%+v\nwant\n%+v", cfg, want)
+	}
+}
+
+func TestLoadPartialConfigKeepsDefaults(t *testing.T) {
+	path := writeConfig(t, `{
+		"processor": {"engagement_distance": 30},
+		"weapons": {"missile": {"range": 120}}
+	}`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	want := Default()
+	want.Processor.EngagementDistance = 30
+	missile := want.Weapons["missile"]
+	missile.Range = 120
+	want.Weapons["missile"] = missile
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("LoadConfig =\n%+v\nwant\n%+v", cfg, want)
+	}
+}
+
+func TestLoadInvalidConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		contents string
+		message  string
+	}{
+		{"negative scanner range", `{"scanner": {"range": -5}}`, "scanner range"},
+		{"negative weapon range", `{"weapons": {"laser_beam": {"range": -1}}}`, "laser_beam"},
+		{"minimum past maximum", `{"weapons": {"missile": {"min_range": 150}}}`, "minimum range"},
+		{"unknown log level", `{"logging": {"level": "loud"}}`, "log level"},
+		{"malformed", `{"processor": `, "parse"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, tc.contents))
+			if err == nil {
+				t.Fatal("LoadConfig accepted an invalid config")
+			}
+			if !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("error %q does not mention %q", err, tc.message)
+			}
+		})
+	}
+}
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("default config is invalid: %v", err)
+	}
+}
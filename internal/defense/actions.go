@@ -13,7 +13,9 @@ func ActivateEmergencyShields(part *anatomy.BodyPart, threat *common.Threat) err
 	}
 
 	// Increase shield strength temporarily
-	part.Protection.ShieldStrength = min(100, part.Protection.ShieldStrength*1.5)
+	part.UpdateProtection(func(protection *anatomy.Protection) {
+		protection.ShieldStrength = min(100, protection.ShieldStrength*1.5)
+	})
 	return nil
 }
 
@@ -39,34 +41,61 @@ func ReinforceCriticalSystems(part *anatomy.BodyPart, threat *common.Threat) err
 	}
 
 	// Increase armor rating temporarily
-	part.Protection.ArmorRating = min(100, part.Protection.ArmorRating*1.3)
+	part.UpdateProtection(func(protection *anatomy.Protection) {
+		protection.ArmorRating = min(100, protection.ArmorRating*1.3)
+	})
 	return nil
 }
 
-// DistributeShieldPower optimizes shield power distribution
+// maxSeverityShieldBoost is the shield boost DistributeShieldPower gives
+// against a threat of maximum severity, matching emergency shielding
+const maxSeverityShieldBoost = 0.5
+
+// DistributeShieldPower boosts shields in proportion to the threat's
+// severity, from nearly nothing for the least severe threats up to the
+// emergency boost for the most severe; it never weakens them
 func DistributeShieldPower(part *anatomy.BodyPart, threat *common.Threat) error {
 	if part == nil {
 		return fmt.Errorf("invalid body part")
 	}
+	if threat == nil {
+		return fmt.Errorf("invalid threat")
+	}
 
-	// Optimize shield strength based on threat type and severity
-	threatMultiplier := float64(threat.Severity) / 10.0
-	part.Protection.ShieldStrength = min(100, part.Protection.ShieldStrength*threatMultiplier)
+	severity := float64(common.ClampSeverity(threat.Severity)) / common.MaxSeverity
+	part.UpdateProtection(func(protection *anatomy.Protection) {
+		protection.ShieldStrength = min(100, protection.ShieldStrength*(1+maxSeverityShieldBoost*severity))
+	})
 	return nil
 }
 
+// EmergencyShielding returns the strategy used to shield every critical part
+// when the robot is critically damaged
+func EmergencyShielding() Strategy {
+	return Strategy{
+		Priority:      1,
+		Action:        ActivateEmergencyShields,
+		Description:   "Emergency shielding of critical parts",
+		BoostDuration: defaultBoostDuration,
+		PowerUsage:    emergencyShieldPower,
+	}
+}
+
 // getDefaultStrategies returns default defensive strategies
 func (sm *StrategyManager) getDefaultStrategies() []Strategy {
 	return []Strategy{
 		{
-			Priority:    1,
-			Action:      ActivateEmergencyShields,
-			Description: "Standard shield activation",
+			Priority:      1,
+			Action:        ActivateEmergencyShields,
+			Description:   "Standard shield activation",
+			BoostDuration: defaultBoostDuration,
+			PowerUsage:    emergencyShieldPower,
 		},
 		{
 			Priority:    2,
 			Action:      InitiateEvasiveManeuver,
 			Description: "Basic evasive movement",
+			PowerUsage:  evasivePower,
 		},
 	}
 }
@@ -77,4 +106,4 @@ func min(a, b float64) float64 {
 		return a
 	}
 	return b
-} 
\ No newline at end of file
+}
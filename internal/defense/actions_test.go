@@ -0,0 +1,47 @@
+package defense
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+func TestDistributeShieldPowerScalesWithClampedSeverity(t *testing.T) {
+	shieldAgainst := func(severity int) float64 {
+		part := anatomy.NewRobotAnatomy().Body
+		part.SetProtection(anatomy.Protection{ShieldStrength: 40})
+		if err := DistributeShieldPower(part, &common.Threat{ID: "t1", Severity: severity}); err != nil {
+			t.Fatalf("DistributeShieldPower(severity %d): %v", severity, err)
+		}
+		return part.Protection().ShieldStrength
+	}
+
+	previous := 40.0
+	for severity := common.MinSeverity; severity <= common.MaxSeverity; severity++ {
+		shield := shieldAgainst(severity)
+		if shield < previous || shield > 100 {
+			t.Errorf("shield against severity %d = %.2f, want between %.2f and 100", severity, shield, previous)
+		}
+		previous = shield
+	}
+	if got := shieldAgainst(common.MaxSeverity); math.Abs(got-60) > 1e-9 {
+		t.Errorf("shield against the most severe threat = %.2f, want the emergency boost to 60", got)
+	}
+	if got, want := shieldAgainst(9999), shieldAgainst(common.MaxSeverity); got != want {
+		t.Errorf("shield against severity 9999 = %.2f, want %.2f as for the maximum", got, want)
+	}
+	if got, want := shieldAgainst(-5), shieldAgainst(common.MinSeverity); got != want {
+		t.Errorf("shield against severity -5 = %.2f, want %.2f as for the minimum", got, want)
+	}
+
+	full := anatomy.NewRobotAnatomy().Body
+	full.SetProtection(anatomy.Protection{ShieldStrength: 90})
+	if err := DistributeShieldPower(full, &common.Threat{ID: "t1", Severity: 10}); err != nil {
+		t.Fatalf("DistributeShieldPower: %v", err)
+	}
+	if got := full.Protection().ShieldStrength; got != 100 {
+		t.Errorf("boosted shield = %.2f, want capped at 100", got)
+	}
+}
@@ -0,0 +1,57 @@
+package defense
+
+import (
+	"testing"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+// brace is a custom defensive action for registration tests
+func brace(*anatomy.BodyPart, *common.Threat) error { return nil }
+
+func TestRegisteredStrategyPrecedesLowerPriorityDefaults(t *testing.T) {
+	sm := NewStrategyManager()
+	robot := anatomy.NewRobotAnatomy()
+	defaults := sm.GetDefensiveStrategies(robot.Legs[0])
+	if len(defaults) == 0 {
+		t.Fatal("legs have no default strategies")
+	}
+
+	urgent := Strategy{Priority: 0, Action: brace, Description: "Brace for impact"}
+	if err := sm.RegisterStrategy(anatomy.Leg, urgent); err != nil {
+		t.Fatalf("RegisterStrategy: %v", err)
+	}
+	late := Strategy{Priority: 2, Action: brace, Description: "Shift weight"}
+	if err := sm.RegisterStrategy(anatomy.Leg, late); err != nil {
+		t.Fatalf("RegisterStrategy: %v", err)
+	}
+
+	strategies := sm.GetDefensiveStrategies(robot.Legs[0])
+	if len(strategies) != len(defaults)+2 {
+		t.Fatalf("leg has %d strategies, want the %d defaults plus 2", len(strategies), len(defaults))
+	}
+	if strategies[0].Description != urgent.Description {
+		t.Errorf("first leg strategy = %q, want the registered %q", strategies[0].Description, urgent.Description)
+	}
+	last := strategies[len(strategies)-1]
+	if last.Description != late.Description {
+		t.Errorf("last leg strategy = %q, want %q after the equal-priority default", last.Description, late.Description)
+	}
+
+	for _, strategy := range sm.GetDefensiveStrategies(robot.Arms[0]) {
+		if strategy.Description == urgent.Description {
+			t.Error("leg strategy registered for arms too")
+		}
+	}
+}
+
+func TestRegisterStrategyRejectsInvalid(t *testing.T) {
+	sm := NewStrategyManager()
+	if err := sm.RegisterStrategy(anatomy.Arm, Strategy{Description: "nothing"}); err == nil {
+		t.Error("strategy without an action accepted")
+	}
+	if err := sm.RegisterStrategy(anatomy.Arm, Strategy{Action: brace, PowerUsage: -1}); err == nil {
+		t.Error("strategy with negative power usage accepted")
+	}
+}
@@ -1,29 +1,60 @@
 package defense
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
 	"t800/internal/anatomy"
 	"t800/internal/common"
 )
 
+// defaultBoostDuration is how long protection boosts last
+const defaultBoostDuration = 10 * time.Second
+
+// Power drawn from the core by each defensive action
+const (
+	emergencyShieldPower  = 30.0
+	reinforcePower        = 25.0
+	shieldDistributePower = 20.0
+	evasivePower          = 10.0
+)
+
 // Strategy defines a defensive strategy
 type Strategy struct {
-	Priority    int
-	Action      DefensiveAction
-	Description string
+	Priority      int
+	Action        DefensiveAction
+	Description   string
+	BoostDuration time.Duration // How long any protection boost lasts; zero is permanent
+	PowerUsage    float64       // Power drawn from the core each time the action runs
 }
 
 // DefensiveAction represents a defensive action function
 type DefensiveAction func(*anatomy.BodyPart, *common.Threat) error
 
+// boost records a part's protection before a temporary boost and when it expires
+type boost struct {
+	part    *anatomy.BodyPart
+	shield  float64
+	armor   float64
+	expires time.Time
+}
+
 // StrategyManager handles defensive strategies
 type StrategyManager struct {
-	strategies map[anatomy.PartType][]Strategy
+	strategiesMu sync.RWMutex
+	strategies   map[anatomy.PartType][]Strategy
+
+	mu     sync.Mutex
+	boosts map[string]*boost
 }
 
 // NewStrategyManager creates a new strategy manager
 func NewStrategyManager() *StrategyManager {
 	sm := &StrategyManager{
 		strategies: make(map[anatomy.PartType][]Strategy),
+		boosts:     make(map[string]*boost),
 	}
 	sm.initializeStrategies()
 	return sm
@@ -34,38 +65,195 @@ func (sm *StrategyManager) initializeStrategies() {
 	// Head strategies
 	sm.strategies[anatomy.Head] = []Strategy{
 		{
-			Priority:    1,
-			Action:      ActivateEmergencyShields,
-			Description: "Emergency shield activation for critical head protection",
+			Priority:      1,
+			Action:        ActivateEmergencyShields,
+			Description:   "Emergency shield activation for critical head protection",
+			BoostDuration: defaultBoostDuration,
+			PowerUsage:    emergencyShieldPower,
 		},
 		{
 			Priority:    2,
 			Action:      InitiateEvasiveManeuver,
 			Description: "Rapid evasive movement to protect head",
+			PowerUsage:  evasivePower,
 		},
 	}
 
 	// Body strategies
 	sm.strategies[anatomy.Body] = []Strategy{
 		{
-			Priority:    1,
-			Action:      ReinforceCriticalSystems,
-			Description: "Reinforcing critical system protection",
+			Priority:      1,
+			Action:        ReinforceCriticalSystems,
+			Description:   "Reinforcing critical system protection",
+			BoostDuration: defaultBoostDuration,
+			PowerUsage:    reinforcePower,
+		},
+		{
+			Priority:      2,
+			Action:        DistributeShieldPower,
+			Description:   "Optimizing shield distribution",
+			BoostDuration: defaultBoostDuration,
+			PowerUsage:    shieldDistributePower,
+		},
+	}
+
+	// Arm strategies
+	sm.strategies[anatomy.Arm] = []Strategy{
+		{
+			Priority:      1,
+			Action:        DistributeShieldPower,
+			Description:   "Redistributing shield power to exposed arm",
+			BoostDuration: defaultBoostDuration,
+			PowerUsage:    shieldDistributePower,
 		},
 		{
 			Priority:    2,
-			Action:      DistributeShieldPower,
-			Description: "Optimizing shield distribution",
+			Action:      InitiateEvasiveManeuver,
+			Description: "Pulling arm out of the line of fire",
+			PowerUsage:  evasivePower,
 		},
 	}
 
-	// Add more strategies for other part types...
+	// Leg strategies
+	sm.strategies[anatomy.Leg] = []Strategy{
+		{
+			Priority:    1,
+			Action:      InitiateEvasiveManeuver,
+			Description: "Evasive footwork to avoid incoming fire",
+			PowerUsage:  evasivePower,
+		},
+		{
+			Priority:      2,
+			Action:        DistributeShieldPower,
+			Description:   "Redistributing shield power to load-bearing leg",
+			BoostDuration: defaultBoostDuration,
+			PowerUsage:    shieldDistributePower,
+		},
+	}
+}
+
+// RegisterStrategy adds a strategy for a part type, merged with the part
+// type's existing strategies (or the defaults if it has none) and kept in
+// priority order, with equal priorities in registration order
+func (sm *StrategyManager) RegisterStrategy(partType anatomy.PartType, strategy Strategy) error {
+	if strategy.Action == nil {
+		return fmt.Errorf("strategy %q: action is required", strategy.Description)
+	}
+	if strategy.BoostDuration < 0 {
+		return fmt.Errorf("strategy %q: boost duration must not be negative", strategy.Description)
+	}
+	if strategy.PowerUsage < 0 {
+		return fmt.Errorf("strategy %q: power usage must not be negative", strategy.Description)
+	}
+
+	sm.strategiesMu.Lock()
+	defer sm.strategiesMu.Unlock()
+
+	strategies, exists := sm.strategies[partType]
+	if !exists {
+		strategies = sm.getDefaultStrategies()
+	}
+	strategies = append(strategies, strategy)
+	sort.SliceStable(strategies, func(i, j int) bool {
+		return strategies[i].Priority < strategies[j].Priority
+	})
+	sm.strategies[partType] = strategies
+	return nil
 }
 
 // GetDefensiveStrategies returns prioritized strategies for a body part
 func (sm *StrategyManager) GetDefensiveStrategies(part *anatomy.BodyPart) []Strategy {
+	sm.strategiesMu.RLock()
+	defer sm.strategiesMu.RUnlock()
+
 	if strategies, exists := sm.strategies[part.Type]; exists {
-		return strategies
+		return append([]Strategy(nil), strategies...)
 	}
 	return sm.getDefaultStrategies()
-} 
\ No newline at end of file
+}
+
+// Apply runs a strategy against a part at time now. Any
+// protection boost it grants is temporary: the part's shield and armor
+// before the first active boost are recorded and restored by Tick once the
+// strategy's boost duration has elapsed. Repeated boosts extend the window.
+func (sm *StrategyManager) Apply(part *anatomy.BodyPart, strategy Strategy, threat *common.Threat, now time.Time) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	before := part.Protection()
+	if err := strategy.Action(part, threat); err != nil {
+		return err
+	}
+	if strategy.BoostDuration <= 0 {
+		return nil
+	}
+	if after := part.Protection(); after.ShieldStrength == before.ShieldStrength && after.ArmorRating == before.ArmorRating {
+		return nil
+	}
+	shield, armor := before.ShieldStrength, before.ArmorRating
+
+	expires := now.Add(strategy.BoostDuration)
+	if active, exists := sm.boosts[part.Name]; exists {
+		if expires.After(active.expires) {
+			active.expires = expires
+		}
+		return nil
+	}
+	sm.boosts[part.Name] = &boost{part: part, shield: shield, armor: armor, expires: expires}
+	return nil
+}
+
+// Tick restores the protection of parts whose boost has expired at time
+// now. Protection lost to damage while boosted stays lost.
+func (sm *StrategyManager) Tick(now time.Time) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	for name, active := range sm.boosts {
+		if now.Before(active.expires) {
+			continue
+		}
+		active.part.UpdateProtection(func(protection *anatomy.Protection) {
+			protection.ShieldStrength = min(protection.ShieldStrength, active.shield)
+			protection.ArmorRating = min(protection.ArmorRating, active.armor)
+		})
+		delete(sm.boosts, name)
+	}
+}
+
+// BoostState is an active protection boost on a part: the protection to
+// restore and when
+type BoostState struct {
+	Shield  float64   `json:"shield"`
+	Armor   float64   `json:"armor"`
+	Expires time.Time `json:"expires"`
+}
+
+// BoostStatus returns every active boost by part name
+func (sm *StrategyManager) BoostStatus() map[string]BoostState {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	status := make(map[string]BoostState, len(sm.boosts))
+	for name, active := range sm.boosts {
+		status[name] = BoostState{Shield: active.shield, Armor: active.armor, Expires: active.expires}
+	}
+	return status
+}
+
+// SetBoostStatus replaces the active boosts with a status previously
+// returned by BoostStatus, leaving the parts' protection as it is. Boosts on
+// parts the anatomy lacks are dropped; an empty status clears every boost.
+func (sm *StrategyManager) SetBoostStatus(ra *anatomy.RobotAnatomy, status map[string]BoostState) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.boosts = make(map[string]*boost, len(status))
+	for name, state := range status {
+		part, err := ra.GetPart(name)
+		if err != nil {
+			continue
+		}
+		sm.boosts[name] = &boost{part: part, shield: state.Shield, armor: state.Armor, expires: state.Expires}
+	}
+}
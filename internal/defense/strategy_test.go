@@ -0,0 +1,84 @@
+package defense
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+func TestApplyConcurrentWithRecharge(t *testing.T) {
+	robot := anatomy.NewRobotAnatomy()
+	sm := NewStrategyManager()
+	head := robot.Head
+	threat := &common.Threat{ID: "t1", Severity: 5}
+	start := time.Unix(1000, 0)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			if err := sm.Apply(head, EmergencyShielding(), threat, start); err != nil {
+				t.Error(err)
+			}
+			sm.Tick(start.Add(time.Duration(i) * time.Second))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			robot.RechargeShields(start.Add(time.Duration(i) * time.Second))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			head.TakeDamage(80)
+		}
+	}()
+	wg.Wait()
+}
+
+func TestApplySubSecondBoostLastsItsDuration(t *testing.T) {
+	robot := anatomy.NewRobotAnatomy()
+	sm := NewStrategyManager()
+	head := robot.Head
+	head.SetProtection(anatomy.Protection{ShieldStrength: 50, ArmorRating: 90, IsActive: true})
+	strategy := EmergencyShielding()
+	strategy.BoostDuration = 500 * time.Millisecond
+
+	start := time.Unix(1000, 0)
+	if err := sm.Apply(head, strategy, nil, start); err != nil {
+		t.Fatal(err)
+	}
+	boosted := head.Protection().ShieldStrength
+	if boosted <= 50 {
+		t.Fatalf("shield %.1f not boosted", boosted)
+	}
+
+	sm.Tick(start.Add(400 * time.Millisecond))
+	if got := head.Protection().ShieldStrength; got != boosted {
+		t.Errorf("shield after 400ms = %.1f, want boost %.1f to hold", got, boosted)
+	}
+	sm.Tick(start.Add(500 * time.Millisecond))
+	if got := head.Protection().ShieldStrength; got != 50 {
+		t.Errorf("shield after 500ms = %.1f, want 50 restored", got)
+	}
+}
+
+func TestDefaultStrategiesDrawPower(t *testing.T) {
+	sm := NewStrategyManager()
+	for _, part := range anatomy.NewRobotAnatomy().Parts {
+		for _, strategy := range sm.GetDefensiveStrategies(part) {
+			if strategy.PowerUsage <= 0 {
+				t.Errorf("%s strategy %q draws no power", part.Name, strategy.Description)
+			}
+		}
+	}
+	if usage := EmergencyShielding().PowerUsage; usage <= 0 {
+		t.Errorf("emergency shielding draws %.1f power, want some", usage)
+	}
+}
@@ -0,0 +1,196 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"t800/internal/common"
+)
+
+// Type identifies the kind of event
+type Type string
+
+const (
+	ThreatDetected   Type = "threat_detected"
+	ThreatEliminated Type = "threat_eliminated"
+	ModeChanged      Type = "mode_changed"
+	PartDamaged      Type = "part_damaged"
+	WeaponFired      Type = "weapon_fired"
+)
+
+// Event is a state change published by the processor
+type Event struct {
+	Type      Type
+	Timestamp time.Time
+	ThreatID  string
+	Part      string
+	Weapon    string
+	Mode      common.OperationMode
+	Value     float64 // Damage dealt or taken, where applicable
+	Health    float64 // Resulting health of the threat or part, where applicable
+	Message   string
+}
+
+// Policy decides what happens when a subscriber's buffer is full
+type Policy int
+
+const (
+	// DropNewest discards the event being published
+	DropNewest Policy = iota
+	// DropOldest discards the oldest buffered event to make room
+	DropOldest
+	// Block waits up to the subscriber's block timeout for room
+	Block
+)
+
+const (
+	defaultBufferSize   = 64
+	defaultBlockTimeout = 100 * time.Millisecond
+)
+
+// subscriberConfig holds per-subscriber delivery settings
+type subscriberConfig struct {
+	bufferSize   int
+	policy       Policy
+	blockTimeout time.Duration
+}
+
+// SubscribeOption configures a subscription
+type SubscribeOption func(*subscriberConfig)
+
+// WithBuffer sets the number of events buffered for the subscriber
+func WithBuffer(size int) SubscribeOption {
+	return func(c *subscriberConfig) {
+		if size >= 0 {
+			c.bufferSize = size
+		}
+	}
+}
+
+// WithPolicy sets how events are handled when the subscriber falls behind
+func WithPolicy(policy Policy) SubscribeOption {
+	return func(c *subscriberConfig) {
+		c.policy = policy
+	}
+}
+
+// WithBlockTimeout bounds how long a Block subscriber may stall publishing
+func WithBlockTimeout(timeout time.Duration) SubscribeOption {
+	return func(c *subscriberConfig) {
+		if timeout > 0 {
+			c.blockTimeout = timeout
+		}
+	}
+}
+
+// subscriber is a single registered consumer
+type subscriber struct {
+	ch     chan Event
+	config subscriberConfig
+}
+
+// Bus is a lightweight in-process publish/subscribe event bus
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[int]*subscriber
+	nextID      int
+}
+
+// NewBus creates an event bus with no subscribers
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[int]*subscriber),
+	}
+}
+
+// Subscribe registers a consumer and returns its event channel together with
+// a function that unsubscribes and closes the channel
+func (b *Bus) Subscribe(opts ...SubscribeOption) (<-chan Event, func()) {
+	config := subscriberConfig{
+		bufferSize:   defaultBufferSize,
+		policy:       DropNewest,
+		blockTimeout: defaultBlockTimeout,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	sub := &subscriber{
+		ch:     make(chan Event, config.bufferSize),
+		config: config,
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = sub
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, id)
+			b.mu.Unlock()
+			close(sub.ch)
+		})
+	}
+	return sub.ch, unsubscribe
+}
+
+// SubscribeFunc registers a handler run for every event on its own
+// goroutine, in publish order, so a slow handler never blocks publishers; it
+// only falls behind as its subscription's policy allows. The returned
+// function unsubscribes; events already buffered are still handled.
+func (b *Bus) SubscribeFunc(handler func(Event), opts ...SubscribeOption) func() {
+	stream, unsubscribe := b.Subscribe(opts...)
+	go func() {
+		for event := range stream {
+			handler(event)
+		}
+	}()
+	return unsubscribe
+}
+
+// Publish delivers an event to every subscriber according to its policy
+func (b *Bus) Publish(event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		sub.deliver(event)
+	}
+}
+
+// deliver sends an event to the subscriber, applying its overflow policy.
+// DropNewest subscribers simply miss events published while they are full.
+func (s *subscriber) deliver(event Event) {
+	select {
+	case s.ch <- event:
+		return
+	default:
+	}
+
+	switch s.config.policy {
+	case DropOldest:
+		select {
+		case <-s.ch:
+		default:
+		}
+		select {
+		case s.ch <- event:
+		default:
+		}
+	case Block:
+		timer := time.NewTimer(s.config.blockTimeout)
+		defer timer.Stop()
+		select {
+		case s.ch <- event:
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlockSubscriberReceivesEveryEvent(t *testing.T) {
+	const published = 50
+	bus := NewBus()
+
+	blocking, unsubscribeBlocking := bus.Subscribe(WithBuffer(1), WithPolicy(Block), WithBlockTimeout(5*time.Second))
+	dropping, unsubscribeDropping := bus.Subscribe(WithBuffer(1), WithPolicy(DropNewest))
+	defer unsubscribeDropping()
+
+	received := make(chan int)
+	go func() {
+		count := 0
+		for range blocking {
+			count++
+			time.Sleep(time.Millisecond)
+		}
+		received <- count
+	}()
+
+	for i := 0; i < published; i++ {
+		bus.Publish(Event{Type: WeaponFired, Value: float64(i)})
+	}
+	unsubscribeBlocking()
+
+	if count := <-received; count != published {
+		t.Errorf("Block subscriber received %d events, want %d", count, published)
+	}
+
+	missed := published - len(dropping)
+	if missed == 0 {
+		t.Error("DropNewest subscriber that never read missed no events")
+	}
+	if first := <-dropping; first.Value != 0 {
+		t.Errorf("DropNewest kept event %.0f, want the first", first.Value)
+	}
+}
+
+func TestDropOldestKeepsLatestEvents(t *testing.T) {
+	bus := NewBus()
+	stream, unsubscribe := bus.Subscribe(WithBuffer(2), WithPolicy(DropOldest))
+	defer unsubscribe()
+
+	for i := 0; i < 5; i++ {
+		bus.Publish(Event{Type: WeaponFired, Value: float64(i)})
+	}
+	for _, want := range []float64{3, 4} {
+		if event := <-stream; event.Value != want {
+			t.Errorf("received event %.0f, want %.0f", event.Value, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package events
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Narrator turns events into human-readable battle commentary
+type Narrator struct {
+	name string
+	w    io.Writer
+}
+
+// NewNarrator creates a narrator that writes one line per event to w,
+// referring to the robot by name
+func NewNarrator(w io.Writer, name string) *Narrator {
+	return &Narrator{
+		name: name,
+		w:    w,
+	}
+}
+
+// Run narrates events from the channel until it is closed
+func (n *Narrator) Run(events <-chan Event) {
+	for event := range events {
+		if line := n.Narrate(event); line != "" {
+			fmt.Fprintln(n.w, line)
+		}
+	}
+}
+
+// Narrate returns the commentary line for a single event
+func (n *Narrator) Narrate(event Event) string {
+	switch event.Type {
+	case ThreatDetected:
+		return fmt.Sprintf("%s detected %s (severity %d)", n.name, event.ThreatID, int(event.Value))
+	case WeaponFired:
+		return fmt.Sprintf("%s fired %s at %s, dealing %.0f damage; %s at %.0f%% health",
+			n.name, humanize(event.Weapon), event.ThreatID, event.Value, event.ThreatID, event.Health)
+	case ThreatEliminated:
+		return fmt.Sprintf("%s eliminated %s", n.name, event.ThreatID)
+	case PartDamaged:
+		return fmt.Sprintf("%s took %.0f damage to its %s from %s; %s at %.0f%% health",
+			n.name, event.Value, humanize(event.Part), event.ThreatID, humanize(event.Part), event.Health)
+	case ModeChanged:
+		return fmt.Sprintf("%s switched to %s mode", n.name, event.Mode)
+	default:
+		return event.Message
+	}
+}
+
+// humanize turns identifiers such as "plasma_cannon" into "plasma cannon"
+func humanize(name string) string {
+	return strings.ReplaceAll(name, "_", " ")
+}
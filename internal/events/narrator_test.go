@@ -0,0 +1,45 @@
+package events
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestNarratorDescribesScriptedEngagement(t *testing.T) {
+	bus := NewBus()
+	var out bytes.Buffer
+	stream, unsubscribe := bus.Subscribe(WithPolicy(Block))
+	done := make(chan struct{})
+	go func() {
+		NewNarrator(&out, "T800").Run(stream)
+		close(done)
+	}()
+
+	for _, event := range []Event{
+		{Type: ThreatDetected, ThreatID: "THREAT-001", Value: 7},
+		{Type: ModeChanged, Mode: common.Combat},
+		{Type: WeaponFired, ThreatID: "THREAT-001", Weapon: "plasma_cannon", Value: 42, Health: 58},
+		{Type: PartDamaged, ThreatID: "THREAT-001", Part: "arm_left", Value: 12, Health: 88},
+		{Type: WeaponFired, ThreatID: "THREAT-001", Weapon: "laser_beam", Value: 58, Health: 0},
+		{Type: ThreatEliminated, ThreatID: "THREAT-001"},
+	} {
+		bus.Publish(event)
+	}
+	unsubscribe()
+	<-done
+
+	want := []string{
+		"T800 detected THREAT-001 (severity 7)",
+		"T800 switched to combat mode",
+		"T800 fired plasma cannon at THREAT-001, dealing 42 damage; THREAT-001 at 58% health",
+		"T800 took 12 damage to its arm left from THREAT-001; arm left at 88% health",
+		"T800 fired laser beam at THREAT-001, dealing 58 damage; THREAT-001 at 0% health",
+		"T800 eliminated THREAT-001",
+	}
+	if got := strings.TrimSpace(out.String()); got != strings.Join(want, "\n") {
+		t.Errorf("narration:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
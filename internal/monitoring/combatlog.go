@@ -0,0 +1,193 @@
+package monitoring
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CombatEventType identifies the kind of combat event
+type CombatEventType string
+
+const (
+	CombatThreatDetected CombatEventType = "threat_detected"
+	CombatWeaponFired    CombatEventType = "weapon_fired"
+	CombatDamageDealt    CombatEventType = "damage_dealt"
+	CombatPartDisabled   CombatEventType = "part_disabled"
+	CombatModeChange     CombatEventType = "mode_change"
+)
+
+// defaultCombatLogCapacity is how many events the combat log retains
+const defaultCombatLogCapacity = 1024
+
+// CombatPayload carries the details of a combat event; fields that do not
+// apply to an event type are left empty
+type CombatPayload struct {
+	ThreatID string  `json:"threat_id,omitempty"`
+	Part     string  `json:"part,omitempty"`
+	Weapon   string  `json:"weapon,omitempty"`
+	Mode     string  `json:"mode,omitempty"`
+	Value    float64 `json:"value,omitempty"`  // Damage dealt or severity, where applicable
+	Health   float64 `json:"health,omitempty"` // Resulting health of the threat or part
+}
+
+// CombatEvent is a single entry in the combat log
+type CombatEvent struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Type      CombatEventType `json:"type"`
+	Payload   CombatPayload   `json:"payload"`
+}
+
+// combatLogHeader is the header row of CSV exports
+var combatLogHeader = []string{"timestamp", "type", "threat_id", "part", "weapon", "mode", "value", "health"}
+
+// CombatLog is an append-only ring buffer of combat events for after-action
+// analysis; once full, the oldest events are overwritten
+type CombatLog struct {
+	mu     sync.Mutex
+	events []CombatEvent
+	next   int
+	full   bool
+}
+
+// NewCombatLog creates a combat log retaining up to capacity events
+func NewCombatLog(capacity int) *CombatLog {
+	return &CombatLog{events: make([]CombatEvent, max(capacity, 1))}
+}
+
+// Record appends an event, stamping it with the current time if it has none
+func (c *CombatLog) Record(event CombatEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events[c.next] = event
+	c.next = (c.next + 1) % len(c.events)
+	if c.next == 0 {
+		c.full = true
+	}
+}
+
+// Events returns the retained events, oldest first
+func (c *CombatLog) Events() []CombatEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.full {
+		return append([]CombatEvent(nil), c.events[:c.next]...)
+	}
+	return append(append([]CombatEvent(nil), c.events[c.next:]...), c.events[:c.next]...)
+}
+
+// Export writes the retained events to a file as "json" or "csv"
+func (c *CombatLog) Export(path, format string) error {
+	events := c.Events()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create combat log: %v", err)
+	}
+	defer file.Close()
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(events); err != nil {
+			return fmt.Errorf("failed to write combat log: %v", err)
+		}
+	case "csv":
+		writer := csv.NewWriter(file)
+		records := [][]string{combatLogHeader}
+		for _, event := range events {
+			records = append(records, []string{
+				event.Timestamp.Format(time.RFC3339Nano),
+				string(event.Type),
+				event.Payload.ThreatID,
+				event.Payload.Part,
+				event.Payload.Weapon,
+				event.Payload.Mode,
+				strconv.FormatFloat(event.Payload.Value, 'g', -1, 64),
+				strconv.FormatFloat(event.Payload.Health, 'g', -1, 64),
+			})
+		}
+		if err := writer.WriteAll(records); err != nil {
+			return fmt.Errorf("failed to write combat log: %v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported combat log format: %s", format)
+	}
+	return file.Close()
+}
+
+// ReadCombatLog reads events exported as "json" or "csv" back from a file
+func ReadCombatLog(path, format string) ([]CombatEvent, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open combat log: %v", err)
+	}
+	defer file.Close()
+
+	var events []CombatEvent
+	switch format {
+	case "json":
+		if err := json.NewDecoder(file).Decode(&events); err != nil {
+			return nil, fmt.Errorf("failed to parse combat log: %v", err)
+		}
+	case "csv":
+		records, err := csv.NewReader(file).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse combat log: %v", err)
+		}
+		for i, record := range records {
+			if i == 0 {
+				continue
+			}
+			event, err := parseCombatRecord(record)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse combat log line %d: %v", i+1, err)
+			}
+			events = append(events, event)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported combat log format: %s", format)
+	}
+	return events, nil
+}
+
+// parseCombatRecord decodes one CSV row of an exported combat log
+func parseCombatRecord(record []string) (CombatEvent, error) {
+	if len(record) != len(combatLogHeader) {
+		return CombatEvent{}, fmt.Errorf("expected %d fields, got %d", len(combatLogHeader), len(record))
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, record[0])
+	if err != nil {
+		return CombatEvent{}, err
+	}
+	value, err := strconv.ParseFloat(record[6], 64)
+	if err != nil {
+		return CombatEvent{}, err
+	}
+	health, err := strconv.ParseFloat(record[7], 64)
+	if err != nil {
+		return CombatEvent{}, err
+	}
+	return CombatEvent{
+		Timestamp: timestamp,
+		Type:      CombatEventType(record[1]),
+		Payload: CombatPayload{
+			ThreatID: record[2],
+			Part:     record[3],
+			Weapon:   record[4],
+			Mode:     record[5],
+			Value:    value,
+			Health:   health,
+		},
+	}, nil
+}
@@ -0,0 +1,66 @@
+package monitoring
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExportCombatLogRoundTrips(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 123456789, time.UTC)
+	logger := NewLogger()
+	logger.RecordCombat(start, CombatThreatDetected, CombatPayload{ThreatID: "t1", Value: 7, Health: 100})
+	logger.RecordCombat(start.Add(time.Second), CombatWeaponFired, CombatPayload{ThreatID: "t1", Part: "arm_left", Weapon: "plasma_cannon"})
+	logger.RecordCombat(start.Add(2*time.Second), CombatDamageDealt, CombatPayload{ThreatID: "t1", Weapon: "plasma_cannon", Value: 22.5, Health: 77.5})
+	logger.RecordCombat(start.Add(3*time.Second), CombatPartDisabled, CombatPayload{Part: "arm_right"})
+	logger.RecordCombat(start.Add(4*time.Second), CombatModeChange, CombatPayload{Mode: "combat"})
+	recorded := logger.CombatLog().Events()
+
+	for _, format := range []string{"json", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "combat."+format)
+			if err := logger.ExportCombatLog(path, format); err != nil {
+				t.Fatalf("ExportCombatLog: %v", err)
+			}
+			events, err := ReadCombatLog(path, format)
+			if err != nil {
+				t.Fatalf("ReadCombatLog: %v", err)
+			}
+
+			if len(events) != len(recorded) {
+				t.Fatalf("read %d events, want %d", len(events), len(recorded))
+			}
+			for i, event := range events {
+				want := recorded[i]
+				if !event.Timestamp.Equal(want.Timestamp) || event.Type != want.Type || event.Payload != want.Payload {
+					t.Errorf("event %d = %+v, want %+v", i, event, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExportCombatLogRejectsUnknownFormat(t *testing.T) {
+	logger := NewLogger()
+	if err := logger.ExportCombatLog(filepath.Join(t.TempDir(), "combat.xml"), "xml"); err == nil {
+		t.Error("ExportCombatLog accepted an unknown format")
+	}
+}
+
+func TestCombatLogOverwritesOldestWhenFull(t *testing.T) {
+	log := NewCombatLog(3)
+	start := time.Unix(1000, 0)
+	for i := 0; i < 5; i++ {
+		log.Record(CombatEvent{Timestamp: start.Add(time.Duration(i) * time.Second), Type: CombatWeaponFired})
+	}
+
+	events := log.Events()
+	if len(events) != 3 {
+		t.Fatalf("log holds %d events, want 3", len(events))
+	}
+	for i, event := range events {
+		if want := start.Add(time.Duration(i+2) * time.Second); !event.Timestamp.Equal(want) {
+			t.Errorf("event %d at %v, want %v", i, event.Timestamp, want)
+		}
+	}
+}
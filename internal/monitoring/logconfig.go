@@ -0,0 +1,115 @@
+package monitoring
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Level is the severity of a log message
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the label written for the level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARNING"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+}
+
+// ParseLevel parses "debug", "info", "warn" or "error"; empty means info
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug, nil
+	case "", "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", s)
+	}
+}
+
+// Format selects how log lines are written
+type Format string
+
+const (
+	// FormatText writes "[time] LEVEL: message" lines
+	FormatText Format = "text"
+	// FormatJSON writes one JSON object per line
+	FormatJSON Format = "json"
+)
+
+// LoggerConfig selects a logger's level, format and output
+type LoggerConfig struct {
+	// Level is the least severe level written; "debug", "info", "warn" or "error"
+	Level string
+	// Format is "text" or "json"; empty means text
+	Format Format
+	// Output is "stdout", "stderr" or a file path; empty means stdout
+	Output string
+	// MaxSizeBytes rotates an output file once it would grow past this size;
+	// zero never rotates
+	MaxSizeBytes int64
+	// MaxBackups is how many rotated files are kept; zero keeps one
+	MaxBackups int
+}
+
+// DefaultLoggerConfig returns the configuration used by NewLogger
+func DefaultLoggerConfig() LoggerConfig {
+	return LoggerConfig{Level: "info", Format: FormatText, Output: "stdout"}
+}
+
+// NewLoggerWithConfig creates a logger with the given level, format and
+// output. A file output is appended to and rotated by size.
+func NewLoggerWithConfig(cfg LoggerConfig) (*Logger, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	switch cfg.Format {
+	case "":
+		cfg.Format = FormatText
+	case FormatText, FormatJSON:
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+	if cfg.MaxSizeBytes < 0 || cfg.MaxBackups < 0 {
+		return nil, fmt.Errorf("log rotation size and backups must not be negative")
+	}
+
+	var out io.Writer
+	switch cfg.Output {
+	case "", "stdout":
+		out = os.Stdout
+	case "stderr":
+		out = os.Stderr
+	default:
+		if out, err = NewRotatingFile(cfg.Output, cfg.MaxSizeBytes, cfg.MaxBackups); err != nil {
+			return nil, err
+		}
+	}
+
+	logger := NewLogger()
+	logger.out, logger.level, logger.format = out, level, cfg.Format
+	return logger, nil
+}
@@ -1,7 +1,11 @@
 package monitoring
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sync"
 	"time"
 
 	"t800/internal/common"
@@ -9,28 +13,133 @@ import (
 
 // Logger handles system logging
 type Logger struct {
-	// Add any logger-specific fields here
+	mu     sync.Mutex
+	out    io.Writer
+	level  Level
+	format Format
+	combat *CombatLog
 }
 
-// NewLogger creates a new logger instance
+// NewLogger creates a new logger instance writing text at info level to
+// standard output
 func NewLogger() *Logger {
-	return &Logger{}
+	return &Logger{out: os.Stdout, level: LevelInfo, format: FormatText, combat: NewCombatLog(defaultCombatLogCapacity)}
+}
+
+// CombatLog returns the log of structured combat events
+func (l *Logger) CombatLog() *CombatLog {
+	return l.combat
+}
+
+// RecordCombat appends a structured event to the combat log
+func (l *Logger) RecordCombat(at time.Time, eventType CombatEventType, payload CombatPayload) {
+	l.combat.Record(CombatEvent{Timestamp: at, Type: eventType, Payload: payload})
+}
+
+// ExportCombatLog writes the combat log to a file as "json" or "csv"
+func (l *Logger) ExportCombatLog(path, format string) error {
+	return l.combat.Export(path, format)
+}
+
+// SetOutput redirects log output, e.g. to io.Discard for headless runs
+func (l *Logger) SetOutput(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out = w
+}
+
+// SetLevel sets the least severe level written
+func (l *Logger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+// Close closes the output if it is a file
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if closer, ok := l.out.(io.Closer); ok && l.out != os.Stdout && l.out != os.Stderr {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Field is a named value attached to a structured log line
+type Field struct {
+	Key   string
+	Value string
+}
+
+// logEntry is a log line in the JSON format
+type logEntry struct {
+	Time    string            `json:"time"`
+	Level   string            `json:"level"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// log writes a message at the given level unless the level is filtered out.
+// Fields are written as a JSON object, or as key=value pairs after the
+// message in text.
+func (l *Logger) log(level Level, msg string, fields ...Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level < l.level {
+		return
+	}
+
+	now := time.Now().Format("2006-01-02 15:04:05")
+	if l.format == FormatJSON {
+		entry := logEntry{Time: now, Level: level.String(), Message: msg}
+		if len(fields) > 0 {
+			entry.Fields = make(map[string]string, len(fields))
+			for _, field := range fields {
+				entry.Fields[field.Key] = field.Value
+			}
+		}
+		line, _ := json.Marshal(entry)
+		fmt.Fprintf(l.out, "%s\n", line)
+		return
+	}
+	for _, field := range fields {
+		msg += fmt.Sprintf(" %s=%s", field.Key, field.Value)
+	}
+	fmt.Fprintf(l.out, "[%s] %s: %s\n", now, level, msg)
+}
+
+// Debug logs a diagnostic message
+func (l *Logger) Debug(msg string) {
+	l.log(LevelDebug, msg)
 }
 
 // Info logs an informational message
 func (l *Logger) Info(msg string) {
-	fmt.Printf("[%s] INFO: %s\n", time.Now().Format("2006-01-02 15:04:05"), msg)
+	l.log(LevelInfo, msg)
+}
+
+// Warn logs a warning message
+func (l *Logger) Warn(msg string) {
+	l.log(LevelWarn, msg)
 }
 
-// LogThreat logs a detected threat
+// LogThreat logs a detected threat and records it in the combat log
 func (l *Logger) LogThreat(threatID string, severity int, location common.Location) {
-	fmt.Printf("[%s] WARNING: Threat detected - ID: %s, Severity: %d, Location: (%.2f, %.2f, %.2f)\n",
-		time.Now().Format("2006-01-02 15:04:05"),
+	l.RecordCombat(time.Time{}, CombatThreatDetected, CombatPayload{ThreatID: threatID, Value: float64(severity)})
+	l.log(LevelWarn, fmt.Sprintf("Threat detected - ID: %s, Severity: %d, Location: (%.2f, %.2f, %.2f)",
 		threatID,
 		severity,
 		location.X,
 		location.Y,
-		location.Z)
+		location.Z))
+}
+
+// LogWeaponFired logs a weapon fired from a part at a target
+func (l *Logger) LogWeaponFired(weapon, part, target string) {
+	l.log(LevelInfo, "Weapon fired",
+		Field{Key: "weapon", Value: weapon},
+		Field{Key: "part", Value: part},
+		Field{Key: "target", Value: target})
 }
 
 // LogDefensiveAction logs a defensive action
@@ -39,11 +148,7 @@ func (l *Logger) LogDefensiveAction(action, target string, success bool) {
 	if !success {
 		status = "FAILED"
 	}
-	fmt.Printf("[%s] INFO: Defensive Action - %s on %s: %s\n",
-		time.Now().Format("2006-01-02 15:04:05"),
-		action,
-		target,
-		status)
+	l.log(LevelInfo, fmt.Sprintf("Defensive Action - %s on %s: %s", action, target, status))
 }
 
 // LogHealthStatus logs the health status of a part
@@ -52,24 +157,15 @@ func (l *Logger) LogHealthStatus(partName string, health float64, isCritical boo
 	if isCritical {
 		critical = " (CRITICAL)"
 	}
-	fmt.Printf("[%s] INFO: Health Status - %s: %.2f%%%s\n",
-		time.Now().Format("2006-01-02 15:04:05"),
-		partName,
-		health,
-		critical)
+	l.log(LevelInfo, fmt.Sprintf("Health Status - %s: %.2f%%%s", partName, health, critical))
 }
 
 // LogSystemStatus logs the overall system status
 func (l *Logger) LogSystemStatus(status string) {
-	fmt.Printf("[%s] INFO: System Status - %s\n",
-		time.Now().Format("2006-01-02 15:04:05"),
-		status)
+	l.log(LevelInfo, "System Status - "+status)
 }
 
 // LogError logs an error message
 func (l *Logger) LogError(err error, context string) {
-	fmt.Printf("[%s] ERROR: %s - %v\n",
-		time.Now().Format("2006-01-02 15:04:05"),
-		context,
-		err)
-} 
\ No newline at end of file
+	l.log(LevelError, fmt.Sprintf("%s - %v", context, err))
+}
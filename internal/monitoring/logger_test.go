@@ -0,0 +1,95 @@
+package monitoring
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoggerFiltersBelowLevel(t *testing.T) {
+	logger, err := NewLoggerWithConfig(LoggerConfig{Level: "info"})
+	if err != nil {
+		t.Fatalf("NewLoggerWithConfig: %v", err)
+	}
+	var out bytes.Buffer
+	logger.SetOutput(&out)
+
+	logger.Debug("hidden diagnostics")
+	logger.Info("visible status")
+	if got := out.String(); strings.Contains(got, "hidden diagnostics") || !strings.Contains(got, "INFO: visible status") {
+		t.Errorf("info-level output = %q, want only the info message", got)
+	}
+
+	out.Reset()
+	logger.SetLevel(LevelDebug)
+	logger.Debug("now visible")
+	if !strings.Contains(out.String(), "DEBUG: now visible") {
+		t.Errorf("debug-level output = %q, want the debug message", out.String())
+	}
+}
+
+func TestLoggerWritesJSON(t *testing.T) {
+	logger, err := NewLoggerWithConfig(LoggerConfig{Level: "warn", Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("NewLoggerWithConfig: %v", err)
+	}
+	var out bytes.Buffer
+	logger.SetOutput(&out)
+
+	logger.Info("filtered")
+	logger.Warn("overheating")
+	var entry logEntry
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("output %q is not a single JSON line: %v", out.String(), err)
+	}
+	if entry.Level != "WARNING" || entry.Message != "overheating" {
+		t.Errorf("entry = %+v, want the warning", entry)
+	}
+}
+
+func TestLoggerConfigRejectsInvalidSettings(t *testing.T) {
+	for _, cfg := range []LoggerConfig{
+		{Level: "verbose"},
+		{Format: "xml"},
+		{MaxSizeBytes: -1},
+		{Output: filepath.Join(t.TempDir(), "missing", "t800.log")},
+	} {
+		if _, err := NewLoggerWithConfig(cfg); err == nil {
+			t.Errorf("NewLoggerWithConfig(%+v) succeeded", cfg)
+		}
+	}
+}
+
+func TestLogFileRotatesPastSizeLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "t800.log")
+	const limit = 200
+	logger, err := NewLoggerWithConfig(LoggerConfig{Level: "info", Output: path, MaxSizeBytes: limit, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewLoggerWithConfig: %v", err)
+	}
+	defer logger.Close()
+
+	logger.Info("first message")
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("rotated before reaching the limit: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		logger.Info("filling the log file past its size limit")
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatalf("%s: %v", filepath.Base(name), err)
+		}
+		if info.Size() > limit {
+			t.Errorf("%s is %d bytes, want at most %d", filepath.Base(name), info.Size(), limit)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("kept more than 2 backups: %v", err)
+	}
+}
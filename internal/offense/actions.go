@@ -1,9 +1,16 @@
 package offense
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"sync"
 	"t800/internal/anatomy"
 	"t800/internal/common"
+	"t800/internal/config"
+	"t800/internal/monitoring"
+	"time"
 )
 
 // AttackAction represents an offensive action function
@@ -11,12 +18,39 @@ type AttackAction func(*anatomy.BodyPart, *common.Threat) error
 
 // AttackStrategy defines an offensive strategy
 type AttackStrategy struct {
-	Priority    int
-	Action      AttackAction
-	Description string
-	PowerUsage  float64
-	Range       float64
-	Preemptive  bool
+	Weapon       string
+	Priority     int
+	Action       AttackAction
+	Description  string
+	PowerUsage   float64
+	MinRange     float64
+	Range        float64
+	Preemptive   bool
+	TravelTime   time.Duration // Delay between firing and impact
+	RecoveryTime time.Duration // Time the firing part is occupied after firing
+	Cooldown     time.Duration // Minimum time between shots of this weapon from the same part
+	FiringArc    float64       // Width in radians of the arc about the heading it can fire into; zero is any direction
+	HeatPerShot  float64       // Heat each shot adds to the weapon; zero never overheats
+}
+
+// ErrOutOfRange is returned when a target lies outside a weapon's range
+var ErrOutOfRange = errors.New("target out of range")
+
+// ErrOutOfAmmo is returned when an ammunition-limited weapon has no rounds left
+var ErrOutOfAmmo = errors.New("out of ammo")
+
+// defaultMissileMagazine is the number of missiles the body bay holds
+const defaultMissileMagazine = 8
+
+// CheckRange returns ErrOutOfRange unless a target at the given location is
+// within the weapon's minimum and maximum range of the firing position
+func (s AttackStrategy) CheckRange(from, target common.Location) error {
+	distance := common.CalculateDistance(from, target)
+	if distance < s.MinRange || distance > s.Range {
+		return fmt.Errorf("%w: %s reaches %.1f-%.1f meters, target at %.2f meters",
+			ErrOutOfRange, s.Weapon, s.MinRange, s.Range, distance)
+	}
+	return nil
 }
 
 // PlasmaCannonAttack fires a concentrated plasma beam
@@ -29,9 +63,6 @@ func PlasmaCannonAttack(part *anatomy.BodyPart, threat *common.Threat) error {
 	if part.Type != anatomy.Arm {
 		return fmt.Errorf("plasma cannon can only be fired from arms")
 	}
-
-	// Log attack execution
-	fmt.Printf("Firing plasma cannon from %s at threat %s\n", part.Name, threat.ID)
 	return nil
 }
 
@@ -44,9 +75,6 @@ func MissileLaunch(part *anatomy.BodyPart, threat *common.Threat) error {
 	if part.Type != anatomy.Body {
 		return fmt.Errorf("missiles can only be launched from body")
 	}
-
-	// Log missile launch
-	fmt.Printf("Launching missiles from %s at threat %s\n", part.Name, threat.ID)
 	return nil
 }
 
@@ -59,9 +87,6 @@ func EMPPulse(part *anatomy.BodyPart, threat *common.Threat) error {
 	if part.Type != anatomy.Body {
 		return fmt.Errorf("EMP can only be generated from body")
 	}
-
-	// Log EMP activation
-	fmt.Printf("Activating EMP pulse from %s at threat %s\n", part.Name, threat.ID)
 	return nil
 }
 
@@ -74,81 +99,473 @@ func LaserBeam(part *anatomy.BodyPart, threat *common.Threat) error {
 	if part.Type != anatomy.Head {
 		return fmt.Errorf("laser can only be fired from head")
 	}
-
-	// Log laser activation
-	fmt.Printf("Firing laser beam from %s at threat %s\n", part.Name, threat.ID)
 	return nil
 }
 
 // OffenseManager handles offensive strategies
 type OffenseManager struct {
-	strategies map[anatomy.PartType][]AttackStrategy
+	strategiesMu sync.RWMutex
+	strategies   map[anatomy.PartType][]AttackStrategy
+
+	mu             sync.Mutex
+	ammo           map[string]int         // remaining rounds for ammunition-limited weapons
+	magazine       map[string]int         // rounds each ammunition-limited weapon holds when reloaded
+	weaponPriority map[string][]string    // operator weapon order per threat type
+	lastFired      map[string]time.Time   // last shot per part and weapon, for cooldowns
+	effectiveness  EffectivenessMatrix    // weapon multipliers per threat type
+	heat           map[string]*weaponHeat // heat per part and weapon
+	heatModel      HeatModel
+	payloads       map[MissileType]Payload
+	logger         *monitoring.Logger
 }
 
 // NewOffenseManager creates a new offense manager
 func NewOffenseManager() *OffenseManager {
 	om := &OffenseManager{
-		strategies: make(map[anatomy.PartType][]AttackStrategy),
+		logger:         monitoring.NewLogger(),
+		strategies:     make(map[anatomy.PartType][]AttackStrategy),
+		ammo:           make(map[string]int),
+		magazine:       make(map[string]int),
+		weaponPriority: make(map[string][]string),
+		lastFired:      make(map[string]time.Time),
+		effectiveness:  DefaultEffectiveness(),
+		heat:           make(map[string]*weaponHeat),
+		heatModel:      DefaultHeatModel(),
+		payloads:       DefaultPayloads(),
+	}
+	for missile, payload := range om.payloads {
+		om.magazine[AmmoPool(MissileWeapon, missile)] = payload.Magazine
+		om.ammo[AmmoPool(MissileWeapon, missile)] = payload.Magazine
 	}
 	om.initializeStrategies()
 	return om
 }
 
+// SetLogger replaces the logger that fired weapons are reported to
+func (om *OffenseManager) SetLogger(logger *monitoring.Logger) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.logger = logger
+}
+
+// Fire performs a strategy's attack action from a part at a threat and logs
+// the weapon fired
+func (om *OffenseManager) Fire(part *anatomy.BodyPart, strategy AttackStrategy, threat *common.Threat) error {
+	if err := strategy.Action(part, threat); err != nil {
+		return err
+	}
+
+	om.mu.Lock()
+	logger := om.logger
+	om.mu.Unlock()
+	logger.LogWeaponFired(strategy.Weapon, part.Name, threat.ID)
+	return nil
+}
+
+// NewOffenseManagerFromConfig creates an offense manager whose weapons take
+// the power usage, ranges, heat and magazine sizes of the given settings
+func NewOffenseManagerFromConfig(weapons map[string]config.WeaponSettings) (*OffenseManager, error) {
+	om := NewOffenseManager()
+	for weapon := range weapons {
+		if _, _, exists := om.Strategy(weapon); !exists {
+			return nil, fmt.Errorf("unknown weapon: %s", weapon)
+		}
+	}
+
+	for _, strategies := range om.strategies {
+		for i := range strategies {
+			settings, exists := weapons[strategies[i].Weapon]
+			if !exists {
+				continue
+			}
+			if settings.PowerUsage < 0 || settings.MinRange < 0 || settings.MinRange > settings.Range || settings.HeatPerShot < 0 {
+				return nil, fmt.Errorf("invalid settings for weapon %s", strategies[i].Weapon)
+			}
+			strategies[i].PowerUsage = settings.PowerUsage
+			strategies[i].MinRange = settings.MinRange
+			strategies[i].Range = settings.Range
+			strategies[i].HeatPerShot = settings.HeatPerShot
+		}
+	}
+	for weapon, settings := range weapons {
+		if settings.Magazine > 0 {
+			om.SetMagazine(weapon, settings.Magazine)
+		}
+	}
+	return om, nil
+}
+
+// Ammo returns the remaining rounds for a weapon or ammunition pool and
+// whether it is ammunition-limited at all. For the missile launcher it is
+// the missiles left of every type.
+func (om *OffenseManager) Ammo(weapon string) (int, bool) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	if weapon == MissileWeapon {
+		return om.missileRounds(), true
+	}
+	rounds, limited := om.ammo[weapon]
+	return rounds, limited
+}
+
+// SetAmmo sets the remaining rounds for a weapon or ammunition pool, making
+// it ammunition-limited; the missile launcher alone sets its high-explosive
+// rounds
+func (om *OffenseManager) SetAmmo(weapon string, rounds int) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.ammo[ammoKey(weapon)] = max(0, rounds)
+}
+
+// SetMagazine sets how many rounds a weapon or ammunition pool holds when
+// reloaded and loads it fully, making it ammunition-limited; the missile
+// launcher alone sets its high-explosive magazine
+func (om *OffenseManager) SetMagazine(weapon string, capacity int) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	key := ammoKey(weapon)
+	om.magazine[key] = max(0, capacity)
+	om.ammo[key] = om.magazine[key]
+}
+
+// Reload refills every ammunition-limited weapon to its magazine capacity and
+// returns the rounds loaded per weapon. Rounds above capacity, such as those
+// salvaged, are kept.
+func (om *OffenseManager) Reload() map[string]int {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	loaded := make(map[string]int)
+	for weapon, capacity := range om.magazine {
+		if rounds := om.ammo[weapon]; rounds < capacity {
+			loaded[weapon] = capacity - rounds
+			om.ammo[weapon] = capacity
+		}
+	}
+	return loaded
+}
+
+// AmmoStatus returns the remaining rounds of every ammunition-limited
+// weapon, with missiles listed per type
+func (om *OffenseManager) AmmoStatus() map[string]int {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	status := make(map[string]int, len(om.ammo))
+	for weapon, rounds := range om.ammo {
+		status[weapon] = rounds
+	}
+	return status
+}
+
+// ConsumeAmmo spends one round from an ammunition pool, as named by
+// AmmoPool; pools without ammunition limits always succeed
+func (om *OffenseManager) ConsumeAmmo(pool string) error {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	key := ammoKey(pool)
+	rounds, limited := om.ammo[key]
+	if !limited {
+		return nil
+	}
+	if rounds <= 0 {
+		return fmt.Errorf("%s: %w", key, ErrOutOfAmmo)
+	}
+	om.ammo[key] = rounds - 1
+	return nil
+}
+
 // initializeStrategies sets up default attack strategies for each part type
 func (om *OffenseManager) initializeStrategies() {
 	// Arm strategies
 	om.strategies[anatomy.Arm] = []AttackStrategy{
 		{
-			Priority:    1,
-			Action:      PlasmaCannonAttack,
-			Description: "Plasma cannon attack",
-			PowerUsage:  75.0,
-			Range:       50.0,
-			Preemptive:  true,
+			Weapon:       "plasma_cannon",
+			Priority:     1,
+			Action:       PlasmaCannonAttack,
+			Description:  "Plasma cannon attack",
+			PowerUsage:   75.0,
+			Range:        50.0,
+			Preemptive:   true,
+			RecoveryTime: 500 * time.Millisecond,
+			Cooldown:     2 * time.Second,
+			FiringArc:    math.Pi / 2,
+			HeatPerShot:  35.0,
 		},
 	}
 
 	// Body strategies
 	om.strategies[anatomy.Body] = []AttackStrategy{
 		{
-			Priority:    2,
-			Action:      MissileLaunch,
-			Description: "Guided missile launch",
-			PowerUsage:  90.0,
-			Range:       100.0,
-			Preemptive:  true,
+			Weapon:       MissileWeapon,
+			Priority:     2,
+			Action:       MissileLaunch,
+			Description:  "Guided missile launch",
+			PowerUsage:   90.0,
+			MinRange:     10.0,
+			Range:        100.0,
+			Preemptive:   true,
+			TravelTime:   1500 * time.Millisecond,
+			RecoveryTime: time.Second,
+			Cooldown:     3 * time.Second,
 		},
 		{
-			Priority:    3,
-			Action:      EMPPulse,
-			Description: "EMP pulse",
-			PowerUsage:  85.0,
-			Range:       30.0,
-			Preemptive:  true,
+			Weapon:       "emp_pulse",
+			Priority:     3,
+			Action:       EMPPulse,
+			Description:  "EMP pulse",
+			PowerUsage:   85.0,
+			Range:        30.0,
+			Preemptive:   true,
+			RecoveryTime: 2 * time.Second,
+			Cooldown:     5 * time.Second,
 		},
 	}
 
 	// Head strategies
 	om.strategies[anatomy.Head] = []AttackStrategy{
 		{
-			Priority:    4,
-			Action:      LaserBeam,
-			Description: "Laser beam attack",
-			PowerUsage:  60.0,
-			Range:       40.0,
-			Preemptive:  true,
+			Weapon:       "laser_beam",
+			Priority:     4,
+			Action:       LaserBeam,
+			Description:  "Laser beam attack",
+			PowerUsage:   60.0,
+			Range:        40.0,
+			Preemptive:   true,
+			RecoveryTime: 250 * time.Millisecond,
+			Cooldown:     time.Second,
+			FiringArc:    math.Pi / 3,
+			HeatPerShot:  25.0,
 		},
 	}
 }
 
-// GetOffensiveStrategies returns available attack strategies for a body part
-func (om *OffenseManager) GetOffensiveStrategies(part *anatomy.BodyPart) []AttackStrategy {
-	if strategies, exists := om.strategies[part.Type]; exists {
-		return strategies
+// RegisterStrategy adds a weapon fired from the given part type. The
+// strategy must have a unique weapon name, an action, positive power usage
+// and range, and a minimum range within its range. Strategies for a part
+// type stay ordered by priority, with equal priorities in registration order.
+func (om *OffenseManager) RegisterStrategy(partType anatomy.PartType, strategy AttackStrategy) error {
+	switch {
+	case strategy.Weapon == "":
+		return fmt.Errorf("weapon name is required")
+	case strategy.Action == nil:
+		return fmt.Errorf("weapon %s: action is required", strategy.Weapon)
+	case strategy.PowerUsage <= 0:
+		return fmt.Errorf("weapon %s: power usage must be positive", strategy.Weapon)
+	case strategy.Range <= 0:
+		return fmt.Errorf("weapon %s: range must be positive", strategy.Weapon)
+	case strategy.MinRange < 0 || strategy.MinRange > strategy.Range:
+		return fmt.Errorf("weapon %s: minimum range must be between 0 and range", strategy.Weapon)
+	}
+
+	om.strategiesMu.Lock()
+	defer om.strategiesMu.Unlock()
+	for _, registered := range om.strategies {
+		for _, existing := range registered {
+			if existing.Weapon == strategy.Weapon {
+				return fmt.Errorf("weapon %s is already registered", strategy.Weapon)
+			}
+		}
 	}
+	strategies := append(om.strategies[partType], strategy)
+	sort.SliceStable(strategies, func(i, j int) bool {
+		return strategies[i].Priority < strategies[j].Priority
+	})
+	om.strategies[partType] = strategies
 	return nil
 }
 
+// strategiesFor returns a copy of the strategies fired from a part type
+func (om *OffenseManager) strategiesFor(partType anatomy.PartType) []AttackStrategy {
+	om.strategiesMu.RLock()
+	defer om.strategiesMu.RUnlock()
+	return append([]AttackStrategy(nil), om.strategies[partType]...)
+}
+
+// GetOffensiveStrategies returns available attack strategies for a body
+// part in priority order, leaving out overheated weapons; a disabled part
+// has none
+func (om *OffenseManager) GetOffensiveStrategies(part *anatomy.BodyPart) []AttackStrategy {
+	if part.IsDisabled() {
+		return nil
+	}
+	var available []AttackStrategy
+	for _, strategy := range om.strategiesFor(part.Type) {
+		if !om.Overheated(part, strategy.Weapon) {
+			available = append(available, strategy)
+		}
+	}
+	return available
+}
+
+// Strategy looks up a weapon's strategy and the part type that fires it
+func (om *OffenseManager) Strategy(weapon string) (AttackStrategy, anatomy.PartType, bool) {
+	om.strategiesMu.RLock()
+	defer om.strategiesMu.RUnlock()
+
+	for partType, strategies := range om.strategies {
+		for _, strategy := range strategies {
+			if strategy.Weapon == weapon {
+				return strategy, partType, true
+			}
+		}
+	}
+	return AttackStrategy{}, "", false
+}
+
+// AllStrategies returns every configured attack strategy ordered by priority
+func (om *OffenseManager) AllStrategies() []AttackStrategy {
+	om.strategiesMu.RLock()
+	all := make([]AttackStrategy, 0)
+	for _, strategies := range om.strategies {
+		all = append(all, strategies...)
+	}
+	om.strategiesMu.RUnlock()
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Priority < all[j].Priority
+	})
+	return all
+}
+
+// SetWeaponPriority sets the order in which weapons are tried against a
+// threat type. Listed weapons come first in the given order; unlisted
+// weapons follow in their default priority. A nil order clears the override.
+func (om *OffenseManager) SetWeaponPriority(threatType string, order []string) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	if order == nil {
+		delete(om.weaponPriority, threatType)
+		return
+	}
+	om.weaponPriority[threatType] = append([]string(nil), order...)
+}
+
+// WeaponPriority returns the operator weapon order for a threat type, if any
+func (om *OffenseManager) WeaponPriority(threatType string) []string {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	return append([]string(nil), om.weaponPriority[threatType]...)
+}
+
+// cooldownKey identifies a weapon mounted on a particular part
+func cooldownKey(part *anatomy.BodyPart, weapon string) string {
+	return part.Name + "/" + weapon
+}
+
+// MarkFired starts the cooldown of a part's weapon from the given time and
+// adds the heat of the shot
+func (om *OffenseManager) MarkFired(part *anatomy.BodyPart, weapon string, at time.Time) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.lastFired[cooldownKey(part, weapon)] = at
+	om.addHeat(part, weapon)
+}
+
+// CooldownRemaining returns how long until a part's weapon may fire again
+func (om *OffenseManager) CooldownRemaining(part *anatomy.BodyPart, strategy AttackStrategy, now time.Time) time.Duration {
+	om.mu.Lock()
+	last, fired := om.lastFired[cooldownKey(part, strategy.Weapon)]
+	om.mu.Unlock()
+
+	if !fired {
+		return 0
+	}
+	return max(0, last.Add(strategy.Cooldown).Sub(now))
+}
+
+// ReadyStrategies returns the strategies for a part, ordered for use against
+// the threat, that can fire now: off cooldown, affordable with the given
+// power and with ammunition left. Every weapon selection goes through it.
+func (om *OffenseManager) ReadyStrategies(part *anatomy.BodyPart, threat *common.Threat, power float64, now time.Time) []AttackStrategy {
+	var ready []AttackStrategy
+	for _, strategy := range om.GetOffensiveStrategiesFor(part, threat) {
+		if strategy.PowerUsage > power || om.CooldownRemaining(part, strategy, now) > 0 {
+			continue
+		}
+		if rounds, limited := om.Ammo(strategy.Weapon); limited && rounds <= 0 {
+			continue
+		}
+		ready = append(ready, strategy)
+	}
+	return ready
+}
+
+// GetOffensiveStrategiesFor returns the attack strategies for a body part
+// that are effective against the given threat, ordered for use against it
+// and honouring any weapon priority override for the threat's type
+func (om *OffenseManager) GetOffensiveStrategiesFor(part *anatomy.BodyPart, threat *common.Threat) []AttackStrategy {
+	var strategies []AttackStrategy
+	for _, strategy := range om.GetOffensiveStrategies(part) {
+		if om.strategyScore(strategy, threat) > 0 {
+			strategies = append(strategies, strategy)
+		}
+	}
+	return om.orderForThreat(strategies, threat)
+}
+
+// orderForThreat sorts strategies by the threat type's weapon override,
+// falling back to priority weighted by effectiveness for unlisted weapons
+func (om *OffenseManager) orderForThreat(strategies []AttackStrategy, threat *common.Threat) []AttackStrategy {
+	rank := make(map[string]int)
+	if threat != nil {
+		for i, weapon := range om.WeaponPriority(threat.Type) {
+			rank[weapon] = i
+		}
+	}
+
+	sort.SliceStable(strategies, func(i, j int) bool {
+		ri, listedI := rank[strategies[i].Weapon]
+		rj, listedJ := rank[strategies[j].Weapon]
+		switch {
+		case listedI && listedJ:
+			return ri < rj
+		case listedI != listedJ:
+			return listedI
+		default:
+			return om.strategyScore(strategies[i], threat) > om.strategyScore(strategies[j], threat)
+		}
+	})
+	return strategies
+}
+
+// ValidateLoadout checks that every strategy in the loadout can be used by
+// the given anatomy: the part type it fires from must exist and at least one
+// such part must not be destroyed
+func (om *OffenseManager) ValidateLoadout(robot *anatomy.RobotAnatomy) []error {
+	om.strategiesMu.RLock()
+	partTypes := make([]anatomy.PartType, 0, len(om.strategies))
+	for partType := range om.strategies {
+		partTypes = append(partTypes, partType)
+	}
+	om.strategiesMu.RUnlock()
+	sort.Slice(partTypes, func(i, j int) bool { return partTypes[i] < partTypes[j] })
+
+	var errs []error
+	for _, partType := range partTypes {
+		parts := robot.PartsOfType(partType)
+		operational := false
+		for _, part := range parts {
+			if !part.IsDisabled() {
+				operational = true
+				break
+			}
+		}
+
+		for _, strategy := range om.strategiesFor(partType) {
+			switch {
+			case len(parts) == 0:
+				errs = append(errs, fmt.Errorf("%s requires a %s but the anatomy has none", strategy.Weapon, partType))
+			case !operational:
+				errs = append(errs, fmt.Errorf("%s requires a %s but every %s is destroyed", strategy.Weapon, partType, partType))
+			}
+		}
+	}
+	return errs
+}
+
 // GetPreemptiveStrategies returns strategies that can be used for preemptive strikes
 func (om *OffenseManager) GetPreemptiveStrategies(part *anatomy.BodyPart) []AttackStrategy {
 	allStrategies := om.GetOffensiveStrategies(part)
@@ -159,4 +576,4 @@ func (om *OffenseManager) GetPreemptiveStrategies(part *anatomy.BodyPart) []Atta
 		}
 	}
 	return preemptive
-} 
\ No newline at end of file
+}
@@ -0,0 +1,113 @@
+package offense
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+	"t800/internal/monitoring"
+)
+
+func weaponOrder(strategies []AttackStrategy) []string {
+	weapons := make([]string, len(strategies))
+	for i, strategy := range strategies {
+		weapons[i] = strategy.Weapon
+	}
+	return weapons
+}
+
+func TestWeaponPriorityPutsEMPFirstForElectronicThreats(t *testing.T) {
+	om := NewOffenseManager()
+	// Without effectiveness weighting the missile's higher default
+	// priority puts it ahead of the EMP
+	om.SetEffectiveness(nil)
+	body := anatomy.NewRobotAnatomy().Body
+	electronic := &common.Threat{Type: "electronic"}
+	physical := &common.Threat{Type: "physical"}
+
+	if got := weaponOrder(om.GetOffensiveStrategiesFor(body, electronic)); len(got) != 2 || got[0] != MissileWeapon {
+		t.Fatalf("default order = %v, want the missile first", got)
+	}
+
+	om.SetWeaponPriority("electronic", []string{"emp_pulse"})
+	if got := weaponOrder(om.GetOffensiveStrategiesFor(body, electronic)); len(got) != 2 || got[0] != "emp_pulse" || got[1] != MissileWeapon {
+		t.Errorf("order against electronic = %v, want [emp_pulse missile]", got)
+	}
+	if got := weaponOrder(om.GetOffensiveStrategiesFor(body, physical)); len(got) != 2 || got[0] != MissileWeapon {
+		t.Errorf("order against physical = %v, want the override not to apply", got)
+	}
+
+	om.SetWeaponPriority("electronic", nil)
+	if got := weaponOrder(om.GetOffensiveStrategiesFor(body, electronic)); len(got) != 2 || got[0] != MissileWeapon {
+		t.Errorf("order after clearing the override = %v, want the missile first", got)
+	}
+}
+
+func TestValidateLoadoutRejectsHeadWeaponWithoutHead(t *testing.T) {
+	om := NewOffenseManager()
+	robot := anatomy.NewRobotAnatomy()
+	if errs := om.ValidateLoadout(robot); len(errs) != 0 {
+		t.Fatalf("default loadout on the default anatomy: %v", errs)
+	}
+
+	delete(robot.Parts, "head")
+	robot.Head = nil
+	errs := om.ValidateLoadout(robot)
+	if len(errs) != 1 {
+		t.Fatalf("headless anatomy reported %d errors %v, want 1", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "laser_beam") {
+		t.Errorf("error %q does not name the head-mounted laser", errs[0])
+	}
+}
+
+func TestDisabledPartHasNoStrategies(t *testing.T) {
+	om := NewOffenseManager()
+	robot := anatomy.NewRobotAnatomy()
+	arm := robot.Arms[0]
+	if len(om.GetOffensiveStrategies(arm)) == 0 {
+		t.Fatal("healthy arm has no strategies")
+	}
+
+	arm.Expose(100)
+	if got := om.GetOffensiveStrategies(arm); len(got) != 0 {
+		t.Errorf("destroyed arm offers %v, want nothing", weaponOrder(got))
+	}
+	if got := om.GetOffensiveStrategies(robot.Arms[1]); len(got) == 0 {
+		t.Error("the other arm lost its strategies")
+	}
+}
+
+func TestFireLogsStructuredWeaponFields(t *testing.T) {
+	logger, err := monitoring.NewLoggerWithConfig(monitoring.LoggerConfig{Level: "info", Format: monitoring.FormatJSON})
+	if err != nil {
+		t.Fatalf("NewLoggerWithConfig: %v", err)
+	}
+	var out bytes.Buffer
+	logger.SetOutput(&out)
+	om := NewOffenseManager()
+	om.SetLogger(logger)
+
+	strategy, _, _ := om.Strategy("plasma_cannon")
+	arm := anatomy.NewRobotAnatomy().Arms[0]
+	if err := om.Fire(arm, strategy, &common.Threat{ID: "t1", Severity: 5}); err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+
+	var entry struct {
+		Level   string            `json:"level"`
+		Message string            `json:"message"`
+		Fields  map[string]string `json:"fields"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("output %q is not a single JSON line: %v", out.String(), err)
+	}
+	want := map[string]string{"weapon": "plasma_cannon", "part": arm.Name, "target": "t1"}
+	if entry.Level != "INFO" || entry.Message != "Weapon fired" || !reflect.DeepEqual(entry.Fields, want) {
+		t.Errorf("logged %+v, want an info \"Weapon fired\" entry with fields %v", entry, want)
+	}
+}
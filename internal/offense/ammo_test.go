@@ -0,0 +1,40 @@
+package offense
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNinthMissileIsOutOfAmmo(t *testing.T) {
+	om := NewOffenseManager()
+	pool := AmmoPool(MissileWeapon, HighExplosive)
+
+	for i := 1; i <= defaultMissileMagazine; i++ {
+		if err := om.ConsumeAmmo(pool); err != nil {
+			t.Fatalf("missile %d: %v", i, err)
+		}
+	}
+	if err := om.ConsumeAmmo(pool); !errors.Is(err, ErrOutOfAmmo) {
+		t.Fatalf("missile %d: %v, want ErrOutOfAmmo", defaultMissileMagazine+1, err)
+	}
+
+	loaded := om.Reload()
+	if loaded[pool] != defaultMissileMagazine {
+		t.Errorf("Reload loaded %d missiles, want %d", loaded[pool], defaultMissileMagazine)
+	}
+	if err := om.ConsumeAmmo(pool); err != nil {
+		t.Errorf("missile after reloading: %v", err)
+	}
+}
+
+func TestUnlimitedWeaponsNeverRunDry(t *testing.T) {
+	om := NewOffenseManager()
+	if _, limited := om.Ammo("plasma_cannon"); limited {
+		t.Fatal("plasma cannon is ammunition-limited")
+	}
+	for i := 0; i < 100; i++ {
+		if err := om.ConsumeAmmo("plasma_cannon"); err != nil {
+			t.Fatalf("shot %d: %v", i+1, err)
+		}
+	}
+}
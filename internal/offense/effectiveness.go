@@ -0,0 +1,74 @@
+package offense
+
+import (
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+// EffectivenessMatrix maps a threat type to the multiplier each weapon's
+// effect is scaled by against it. Weapons or threat types missing from the
+// matrix are fully effective; a multiplier of zero makes a weapon useless.
+type EffectivenessMatrix map[string]map[string]float64
+
+// DefaultEffectiveness returns the default weapon effectiveness by threat type
+func DefaultEffectiveness() EffectivenessMatrix {
+	return EffectivenessMatrix{
+		"electronic": {
+			"plasma_cannon": 0.8,
+			"missile":       1.0,
+			"emp_pulse":     2.0,
+			"laser_beam":    1.2,
+		},
+		"physical": {
+			"plasma_cannon": 1.5,
+			"missile":       1.2,
+			"emp_pulse":     0.0,
+			"laser_beam":    0.8,
+		},
+	}
+}
+
+// SetEffectiveness replaces the effectiveness matrix; nil makes every weapon
+// fully effective against every threat type
+func (om *OffenseManager) SetEffectiveness(matrix EffectivenessMatrix) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.effectiveness = matrix
+}
+
+// Effectiveness returns the multiplier of a weapon against a threat type
+func (om *OffenseManager) Effectiveness(threatType, weapon string) float64 {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	if multiplier, exists := om.effectiveness[threatType][weapon]; exists {
+		return multiplier
+	}
+	return 1.0
+}
+
+// strategyScore ranks a strategy against a threat: its effectiveness divided
+// by its priority, where priority 1 is the most preferred
+func (om *OffenseManager) strategyScore(strategy AttackStrategy, threat *common.Threat) float64 {
+	effectiveness := 1.0
+	if threat != nil {
+		effectiveness = om.Effectiveness(threat.Type, strategy.Weapon)
+	}
+	return effectiveness / float64(max(strategy.Priority, 1))
+}
+
+// GetBestStrategy returns the part's strategy ranked highest against the
+// threat by priority and effectiveness for its type. It reports false if the
+// part has no weapon that is effective against the threat.
+func (om *OffenseManager) GetBestStrategy(part *anatomy.BodyPart, threat *common.Threat) (AttackStrategy, bool) {
+	var (
+		best      AttackStrategy
+		bestScore float64
+	)
+	for _, strategy := range om.GetOffensiveStrategies(part) {
+		if score := om.strategyScore(strategy, threat); score > bestScore {
+			best, bestScore = strategy, score
+		}
+	}
+	return best, bestScore > 0
+}
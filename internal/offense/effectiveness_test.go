@@ -0,0 +1,44 @@
+package offense
+
+import (
+	"testing"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+func TestGetBestStrategyFollowsThreatType(t *testing.T) {
+	om := NewOffenseManager()
+	robot := anatomy.NewRobotAnatomy()
+
+	for _, tc := range []struct {
+		name   string
+		part   *anatomy.BodyPart
+		threat string
+		want   string
+	}{
+		{"EMP against electronic", robot.Body, "electronic", "emp_pulse"},
+		{"missile against physical", robot.Body, "physical", MissileWeapon},
+		{"plasma against physical", robot.Arms[0], "physical", "plasma_cannon"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			best, ok := om.GetBestStrategy(tc.part, &common.Threat{Type: tc.threat})
+			if !ok || best.Weapon != tc.want {
+				t.Errorf("GetBestStrategy = %s, %v; want %s", best.Weapon, ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestUselessWeaponIsNeverBest(t *testing.T) {
+	om := NewOffenseManager()
+	om.SetEffectiveness(EffectivenessMatrix{"physical": {"emp_pulse": 0, MissileWeapon: 0}})
+	body := anatomy.NewRobotAnatomy().Body
+
+	if best, ok := om.GetBestStrategy(body, &common.Threat{Type: "physical"}); ok {
+		t.Errorf("GetBestStrategy = %s, want nothing when every weapon is useless", best.Weapon)
+	}
+	if got := om.Effectiveness("unknown", "emp_pulse"); got != 1 {
+		t.Errorf("effectiveness against an unlisted type = %v, want 1", got)
+	}
+}
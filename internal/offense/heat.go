@@ -0,0 +1,119 @@
+package offense
+
+import "t800/internal/anatomy"
+
+// HeatModel sets how weapons heat up and cool down. A weapon whose heat
+// reaches Threshold overheats and is locked out until it cools to ResetLevel.
+type HeatModel struct {
+	Threshold       float64
+	ResetLevel      float64
+	DissipationRate float64 // Heat shed per second
+}
+
+// DefaultHeatModel returns the default weapon heat model
+func DefaultHeatModel() HeatModel {
+	return HeatModel{
+		Threshold:       100.0,
+		ResetLevel:      40.0,
+		DissipationRate: 10.0,
+	}
+}
+
+// weaponHeat is the heat of a weapon mounted on a part
+type weaponHeat struct {
+	level      float64
+	overheated bool
+}
+
+// HeatState is the heat of a weapon mounted on a part
+type HeatState struct {
+	Level      float64 `json:"level"`
+	Overheated bool    `json:"overheated"`
+}
+
+// SetHeatModel replaces the heat model used for every weapon
+func (om *OffenseManager) SetHeatModel(model HeatModel) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	om.heatModel = model
+}
+
+// addHeat heats a part's weapon by its heat per shot; om.mu must be held
+func (om *OffenseManager) addHeat(part *anatomy.BodyPart, weapon string) {
+	strategy, _, exists := om.Strategy(weapon)
+	if !exists || strategy.HeatPerShot <= 0 {
+		return
+	}
+
+	key := cooldownKey(part, weapon)
+	heat, exists := om.heat[key]
+	if !exists {
+		heat = &weaponHeat{}
+		om.heat[key] = heat
+	}
+	heat.level += strategy.HeatPerShot
+	if heat.level >= om.heatModel.Threshold {
+		heat.overheated = true
+	}
+}
+
+// Heat returns the heat of a part's weapon and whether it is overheated
+func (om *OffenseManager) Heat(part *anatomy.BodyPart, weapon string) (float64, bool) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	heat, exists := om.heat[cooldownKey(part, weapon)]
+	if !exists {
+		return 0, false
+	}
+	return heat.level, heat.overheated
+}
+
+// HeatStatus returns the heat of every warm weapon, keyed by part and weapon
+func (om *OffenseManager) HeatStatus() map[string]HeatState {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	status := make(map[string]HeatState, len(om.heat))
+	for key, heat := range om.heat {
+		status[key] = HeatState{Level: heat.level, Overheated: heat.overheated}
+	}
+	return status
+}
+
+// SetHeatStatus replaces the heat of every weapon with a status previously
+// returned by HeatStatus
+func (om *OffenseManager) SetHeatStatus(status map[string]HeatState) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	om.heat = make(map[string]*weaponHeat, len(status))
+	for key, state := range status {
+		if state.Level > 0 {
+			om.heat[key] = &weaponHeat{level: state.Level, overheated: state.Overheated}
+		}
+	}
+}
+
+// Overheated reports whether a part's weapon is locked out by heat
+func (om *OffenseManager) Overheated(part *anatomy.BodyPart, weapon string) bool {
+	_, overheated := om.Heat(part, weapon)
+	return overheated
+}
+
+// Tick dissipates weapon heat over deltaTime seconds; overheated weapons
+// become available again once they cool to the reset level
+func (om *OffenseManager) Tick(deltaTime float64) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	for key, heat := range om.heat {
+		heat.level = max(0, heat.level-om.heatModel.DissipationRate*deltaTime)
+		if heat.level <= om.heatModel.ResetLevel {
+			heat.overheated = false
+		}
+		if heat.level == 0 {
+			delete(om.heat, key)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package offense
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"t800/internal/anatomy"
+)
+
+func TestWeaponOverheatsAndCools(t *testing.T) {
+	om := NewOffenseManager()
+	arm := anatomy.NewRobotAnatomy().Arms[0]
+	now := time.Unix(1000, 0)
+
+	// Plasma heats 35 per shot, so the third shot crosses the threshold of 100
+	for shot := 1; shot <= 3; shot++ {
+		if om.Overheated(arm, "plasma_cannon") {
+			t.Fatalf("overheated before shot %d", shot)
+		}
+		om.MarkFired(arm, "plasma_cannon", now)
+	}
+	if heat, overheated := om.Heat(arm, "plasma_cannon"); !overheated || heat != 105 {
+		t.Fatalf("Heat = %.1f, %v; want 105 and overheated", heat, overheated)
+	}
+	if slices.Contains(weaponOrder(om.GetOffensiveStrategies(arm)), "plasma_cannon") {
+		t.Error("overheated plasma cannon still offered")
+	}
+
+	// Cooling at 10 per second must reach the reset level of 40
+	om.Tick(6)
+	if !om.Overheated(arm, "plasma_cannon") {
+		t.Fatal("plasma cannon available again above the reset level")
+	}
+	om.Tick(0.5)
+	if om.Overheated(arm, "plasma_cannon") {
+		t.Fatal("plasma cannon still locked out at the reset level")
+	}
+	if !slices.Contains(weaponOrder(om.GetOffensiveStrategies(arm)), "plasma_cannon") {
+		t.Error("cooled plasma cannon not offered")
+	}
+}
+
+func TestHeatModelIsConfigurable(t *testing.T) {
+	om := NewOffenseManager()
+	om.SetHeatModel(HeatModel{Threshold: 30, ResetLevel: 0, DissipationRate: 100})
+	arm := anatomy.NewRobotAnatomy().Arms[0]
+
+	om.MarkFired(arm, "plasma_cannon", time.Unix(1000, 0))
+	if !om.Overheated(arm, "plasma_cannon") {
+		t.Fatal("a single shot did not overheat with a threshold of 30")
+	}
+	om.Tick(1)
+	if heat, overheated := om.Heat(arm, "plasma_cannon"); overheated || heat != 0 {
+		t.Errorf("Heat after cooling = %.1f, %v; want cold", heat, overheated)
+	}
+}
@@ -0,0 +1,101 @@
+package offense
+
+import (
+	"fmt"
+	"time"
+)
+
+// MissileWeapon is the weapon name of the body's missile launcher
+const MissileWeapon = "missile"
+
+// MissileType selects the payload a launched missile carries
+type MissileType string
+
+const (
+	// HighExplosive deals heavy damage to the target alone
+	HighExplosive MissileType = "high_explosive"
+	// Cluster scatters bomblets that hit every threat near the impact
+	Cluster MissileType = "cluster"
+	// Incendiary starts a fire at the impact that burns threats over time
+	Incendiary MissileType = "incendiary"
+)
+
+// Payload describes how a missile type delivers its damage
+type Payload struct {
+	DamageFraction      float64       // Fraction of the missile's damage dealt to each threat hit
+	BlastRadius         float64       // Radius about the impact within which other threats are also hit; zero hits only the target
+	BurnRadius          float64       // Radius of the fire left at the impact; zero leaves none
+	BurnDamagePerSecond float64       // Health lost per second inside the fire
+	BurnDuration        time.Duration // How long the fire burns
+	Magazine            int           // Missiles of the type the bay holds
+}
+
+// DefaultPayloads returns the payload of each missile type. High explosive
+// keeps the full magazine and damage of the original missile.
+func DefaultPayloads() map[MissileType]Payload {
+	return map[MissileType]Payload{
+		HighExplosive: {
+			DamageFraction: 1.0,
+			Magazine:       defaultMissileMagazine,
+		},
+		Cluster: {
+			DamageFraction: 0.6,
+			BlastRadius:    15.0,
+			Magazine:       defaultMissileMagazine / 2,
+		},
+		Incendiary: {
+			DamageFraction:      0.4,
+			BurnRadius:          10.0,
+			BurnDamagePerSecond: 5.0,
+			BurnDuration:        10 * time.Second,
+			Magazine:            defaultMissileMagazine / 2,
+		},
+	}
+}
+
+// MissileTypes lists the missile types in the order they are preferred
+// when a launch does not call for a particular one
+var MissileTypes = []MissileType{HighExplosive, Cluster, Incendiary}
+
+// AmmoPool returns the ammunition pool a shot draws from: missiles draw from
+// the pool of the type launched, every other weapon from its own
+func AmmoPool(weapon string, missile MissileType) string {
+	if weapon == MissileWeapon {
+		return MissileWeapon + ":" + string(missile)
+	}
+	return weapon
+}
+
+// ammoKey resolves a weapon or pool name to the pool it sets: the missile
+// launcher alone stands for its high-explosive magazine, the original
+// missile's
+func ammoKey(weapon string) string {
+	if weapon == MissileWeapon {
+		return AmmoPool(MissileWeapon, HighExplosive)
+	}
+	return weapon
+}
+
+// missileRounds returns the missiles left across every type. The caller must
+// hold om.mu.
+func (om *OffenseManager) missileRounds() int {
+	rounds := 0
+	for missile := range om.payloads {
+		rounds += om.ammo[AmmoPool(MissileWeapon, missile)]
+	}
+	return rounds
+}
+
+// CheckMissile returns an error unless the missile type is known
+func (om *OffenseManager) CheckMissile(missile MissileType) error {
+	if _, exists := om.payloads[missile]; !exists {
+		return fmt.Errorf("unknown missile type: %s", missile)
+	}
+	return nil
+}
+
+// Payload returns the payload of a missile type
+func (om *OffenseManager) Payload(missile MissileType) (Payload, bool) {
+	payload, exists := om.payloads[missile]
+	return payload, exists
+}
@@ -0,0 +1,22 @@
+package offense
+
+import (
+	"errors"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestCheckRangeAt80Meters(t *testing.T) {
+	om := NewOffenseManager()
+	target := common.Location{X: 80}
+
+	plasma, _, _ := om.Strategy("plasma_cannon")
+	if err := plasma.CheckRange(common.Location{}, target); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("plasma cannon at 80m: %v, want ErrOutOfRange", err)
+	}
+	missile, _, _ := om.Strategy(MissileWeapon)
+	if err := missile.CheckRange(common.Location{}, target); err != nil {
+		t.Errorf("missile at 80m: %v, want in range", err)
+	}
+}
@@ -0,0 +1,65 @@
+package offense
+
+import (
+	"slices"
+	"testing"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+// railgun is a custom arm weapon for registration tests
+var railgun = AttackStrategy{
+	Weapon:      "railgun",
+	Priority:    2,
+	Action:      func(*anatomy.BodyPart, *common.Threat) error { return nil },
+	Description: "Railgun attack",
+	PowerUsage:  50,
+	Range:       70,
+}
+
+func TestRegisterStrategyAddsWeaponForPartType(t *testing.T) {
+	om := NewOffenseManager()
+	robot := anatomy.NewRobotAnatomy()
+	if err := om.RegisterStrategy(anatomy.Arm, railgun); err != nil {
+		t.Fatalf("RegisterStrategy: %v", err)
+	}
+
+	strategies := om.GetOffensiveStrategies(robot.Arms[0])
+	if !slices.Contains(weaponOrder(strategies), "railgun") {
+		t.Fatalf("arm strategies %v lack the railgun", weaponOrder(strategies))
+	}
+	if !slices.IsSortedFunc(strategies, func(a, b AttackStrategy) int { return a.Priority - b.Priority }) {
+		t.Errorf("arm strategies %v are not in priority order", weaponOrder(strategies))
+	}
+	for _, part := range []*anatomy.BodyPart{robot.Body, robot.Head, robot.Legs[0]} {
+		if slices.Contains(weaponOrder(om.GetOffensiveStrategies(part)), "railgun") {
+			t.Errorf("%s offers the arm-mounted railgun", part.Name)
+		}
+	}
+	if _, partType, exists := om.Strategy("railgun"); !exists || partType != anatomy.Arm {
+		t.Errorf("Strategy(railgun) = %v, %v; want it mounted on arms", partType, exists)
+	}
+}
+
+func TestRegisterStrategyValidates(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		modify func(*AttackStrategy)
+	}{
+		{"no name", func(s *AttackStrategy) { s.Weapon = "" }},
+		{"no action", func(s *AttackStrategy) { s.Action = nil }},
+		{"no power", func(s *AttackStrategy) { s.PowerUsage = 0 }},
+		{"no range", func(s *AttackStrategy) { s.Range = 0 }},
+		{"minimum past range", func(s *AttackStrategy) { s.MinRange = 80 }},
+		{"duplicate", func(s *AttackStrategy) { s.Weapon = "plasma_cannon" }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			strategy := railgun
+			tc.modify(&strategy)
+			if err := NewOffenseManager().RegisterStrategy(anatomy.Arm, strategy); err == nil {
+				t.Error("RegisterStrategy accepted an invalid weapon")
+			}
+		})
+	}
+}
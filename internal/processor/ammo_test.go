@@ -0,0 +1,24 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/offense"
+)
+
+func TestMaintenanceReloadsMissiles(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	pool := offense.AmmoPool(offense.MissileWeapon, offense.HighExplosive)
+	full := p.offense.AmmoStatus()[pool]
+	if full == 0 {
+		t.Fatal("no high-explosive missiles loaded")
+	}
+	p.offense.SetAmmo(pool, 0)
+
+	if err := p.EnterMaintenance(); err != nil {
+		t.Fatalf("EnterMaintenance: %v", err)
+	}
+	if got := p.offense.AmmoStatus()[pool]; got != full {
+		t.Errorf("high-explosive missiles after maintenance = %d, want %d", got, full)
+	}
+}
@@ -0,0 +1,374 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+	"t800/internal/events"
+	"t800/internal/monitoring"
+	"t800/internal/offense"
+)
+
+// weaponAssignment pairs a firing part with the strategy it will use and,
+// for the missile launcher, the type of missile it launches
+type weaponAssignment struct {
+	part     *anatomy.BodyPart
+	strategy offense.AttackStrategy
+	missile  offense.MissileType
+}
+
+// volleyAssignments returns every weapon the robot can bring to bear on a
+// threat: arm weapons first, body-mounted weapons as backup. Weapons on
+// cooldown, out of ammunition or costing more than the power in reserve are
+// left out.
+func (p *Processor) volleyAssignments(threat *common.Threat) []weaponAssignment {
+	power, now := p.anatomy.Power.Level(), p.clock.Now()
+
+	var assignments []weaponAssignment
+	for _, arm := range p.anatomy.Arms {
+		for _, strategy := range p.offense.ReadyStrategies(arm, threat, power, now) {
+			assignments = append(assignments, weaponAssignment{part: arm, strategy: strategy})
+		}
+	}
+	for _, strategy := range p.offense.ReadyStrategies(p.anatomy.Body, threat, power, now) {
+		assignment := weaponAssignment{part: p.anatomy.Body, strategy: strategy}
+		if strategy.Weapon == offense.MissileWeapon {
+			assignment.missile = p.chooseMissile(threat)
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments
+}
+
+// readyWeapons returns the weapons some operational, recovered part can fire
+// at the threat now, each once, in the order the parts would use them
+func (p *Processor) readyWeapons(threat *common.Threat) []offense.AttackStrategy {
+	power, now := p.anatomy.Power.Level(), p.clock.Now()
+
+	var ready []offense.AttackStrategy
+	seen := make(map[string]bool)
+	for _, part := range p.anatomy.PartsByDefensePriority() {
+		if !p.partReady(part.Name, now) {
+			continue
+		}
+		for _, strategy := range p.offense.ReadyStrategies(part, threat, power, now) {
+			if !seen[strategy.Weapon] {
+				seen[strategy.Weapon] = true
+				ready = append(ready, strategy)
+			}
+		}
+	}
+	return ready
+}
+
+// minimalVolley returns the smallest set of weapons, most damaging first,
+// estimated to eliminate the threat, reserving the rest of the loadout. If
+// the whole loadout cannot eliminate the threat, every usable weapon is
+// assigned.
+func (p *Processor) minimalVolley(threat *common.Threat) []weaponAssignment {
+	distance := common.CalculateDistance(p.getLocation(), threat.Location)
+	usable := p.volleyAssignments(threat)
+	sort.SliceStable(usable, func(i, j int) bool {
+		return p.strikeDamage(usable[i].strategy, threat, distance) > p.strikeDamage(usable[j].strategy, threat, distance)
+	})
+
+	var expected float64
+	for i, assignment := range usable {
+		expected += p.strikeDamage(assignment.strategy, threat, distance)
+		if expected >= threat.Health {
+			return usable[:i+1]
+		}
+	}
+	return usable
+}
+
+// executeCoordinatedAttack fires the assigned weapons at the threat one
+// after another, in assignment order so volleys are reproducible: the
+// minimal volley when partial volleys are enabled, otherwise every weapon.
+// The volley stops as soon as the threat is eliminated, so the remaining
+// weapons hold fire and spend no ammunition. It returns the total damage dealt on impact; projectiles still
+// in flight are resolved when they arrive. In a dry run it returns the
+// damage the volley would deal, projectiles included.
+func (p *Processor) executeCoordinatedAttack(threat *common.Threat) float64 {
+	if p.holdFireOutsideROE(threat) || !p.beginAttack() {
+		return 0
+	}
+	defer p.attacks.Done()
+
+	var (
+		totalDamage float64
+		fired       []string
+	)
+	assignments := p.volleyAssignments(threat)
+	if p.config.PartialVolley {
+		assignments = p.minimalVolley(threat)
+	}
+
+	for _, assignment := range assignments {
+		damage, ok := p.fireWeapon(assignment.part, assignment.strategy, assignment.missile, threat)
+		if !ok {
+			continue
+		}
+		totalDamage += damage
+		fired = append(fired, assignment.strategy.Weapon)
+
+		if threat.Health <= 0 {
+			break
+		}
+	}
+	if p.DryRun() {
+		return totalDamage
+	}
+	p.noteRationaleWeapons(threat.ID, fired)
+
+	if threat.Health <= 0 {
+		p.eliminateThreat(threat)
+	}
+	return totalDamage
+}
+
+// executeAttack fires a single weapon at the current threat and returns the
+// damage dealt on impact. A missile launch carries the given type, or one
+// chosen for the threat if none is given.
+func (p *Processor) executeAttack(weapon string, missile offense.MissileType) float64 {
+	threat := p.GetActiveThreat()
+	if threat == nil || threat.Health <= 0 {
+		return 0
+	}
+
+	if p.holdFireOutsideROE(threat) {
+		return 0
+	}
+
+	weapon, allowed := p.permittedWeapon(threat, weapon)
+	if !allowed {
+		return 0
+	}
+
+	strategy, partType, exists := p.offense.Strategy(weapon)
+	if !exists {
+		p.logger.LogError(fmt.Errorf("unknown weapon: %s", weapon), "attack aborted")
+		return 0
+	}
+
+	if weapon == offense.MissileWeapon && missile == "" {
+		missile = p.chooseMissile(threat)
+	}
+
+	now := p.clock.Now()
+	for _, part := range p.anatomy.PartsOfType(partType) {
+		if part.IsDisabled() || !p.partReady(part.Name, now) {
+			continue
+		}
+		damage, fired := p.fireWeapon(part, strategy, missile, threat)
+		if fired && threat.Health <= 0 {
+			p.eliminateThreat(threat)
+		}
+		return damage
+	}
+	p.logger.Info(fmt.Sprintf("Cannot fire %s: every %s is disabled or recovering", weapon, partType))
+	return 0
+}
+
+// fireWeapon fires a single weapon from a part at a threat, occupying the
+// part for the weapon's recovery time. Weapons that cannot reach the threat,
+// cannot be brought to bear on it from the robot's heading or have no line of
+// sight to it are skipped without spending power or ammunition. Damage from weapons with no travel
+// time is applied at once and returned; otherwise it is applied on impact,
+// and only if the threat is still within range by then. A missile carries
+// the given type and draws on its ammunition; other weapons ignore it. In a
+// dry run the weapon is only previewed.
+func (p *Processor) fireWeapon(part *anatomy.BodyPart, strategy offense.AttackStrategy, missile offense.MissileType, threat *common.Threat) (float64, bool) {
+	if strategy.Weapon != offense.MissileWeapon {
+		missile = ""
+	}
+	now := p.clock.Now()
+	if part.IsDisabled() || !p.partReady(part.Name, now) || p.overBudget(threat.ID) || p.holdFireWhileRetreating() {
+		return 0, false
+	}
+	if err := strategy.CheckRange(p.getLocation(), threat.Location); err != nil {
+		p.logger.Info(fmt.Sprintf("Skipping %s on %s: %v", strategy.Weapon, part.Name, err))
+		return 0, false
+	}
+	if p.holdFireOutsideArc(strategy, threat) || p.holdFireWithoutLineOfSight(threat) {
+		return 0, false
+	}
+	if p.offense.Overheated(part, strategy.Weapon) {
+		p.logger.Info(fmt.Sprintf("%s on %s overheated", strategy.Weapon, part.Name))
+		return 0, false
+	}
+	if remaining := p.offense.CooldownRemaining(part, strategy, now); remaining > 0 {
+		p.logger.Info(fmt.Sprintf("%s on %s cooling down (%s remaining)", strategy.Weapon, part.Name, remaining))
+		return 0, false
+	}
+	if p.DryRun() {
+		return p.previewFire(part, strategy, missile, threat)
+	}
+	if !p.anatomy.Power.Draw(strategy.PowerUsage) {
+		p.logger.Info(fmt.Sprintf("Insufficient power for %s (needs %.1f, have %.1f)",
+			strategy.Weapon, strategy.PowerUsage, p.anatomy.Power.Level()))
+		return 0, false
+	}
+	if err := p.offense.ConsumeAmmo(offense.AmmoPool(strategy.Weapon, missile)); err != nil {
+		p.anatomy.Power.Recharge(strategy.PowerUsage)
+		p.logger.LogError(err, "offensive action failed")
+		return 0, false
+	}
+	if err := p.offense.Fire(part, strategy, threat); err != nil {
+		p.logger.LogError(err, "offensive action failed")
+		return 0, false
+	}
+	p.offense.MarkFired(part, strategy.Weapon, now)
+	p.noteEngagementSpend(threat, strategy, now)
+
+	if part.Type == anatomy.Arm {
+		// Arms are toed in so fire from both sides converges on the target
+		aim := p.anatomy.AimVector(part, threat.Location.Sub(p.getLocation()), p.Heading())
+		p.logger.Info(fmt.Sprintf("%s aimed at %s along (%.3f, %.3f, %.3f)", part.Name, threat.ID, aim.X, aim.Y, aim.Z))
+	}
+
+	p.setPartBusy(part.Name, now.Add(strategy.RecoveryTime))
+	p.noteFire(threat.ID)
+	p.logger.RecordCombat(now, monitoring.CombatWeaponFired, monitoring.CombatPayload{
+		ThreatID: threat.ID,
+		Part:     part.Name,
+		Weapon:   strategy.Weapon,
+	})
+	p.logger.LogDefensiveAction(strategy.Description, part.Name, true)
+
+	if strategy.TravelTime > 0 {
+		p.scheduleImpact(pendingImpact{
+			at:       now.Add(strategy.TravelTime),
+			threatID: threat.ID,
+			partName: part.Name,
+			strategy: strategy,
+			missile:  missile,
+		})
+		return 0, true
+	}
+	return p.landStrike(threat, part.Name, strategy, missile), true
+}
+
+// pendingImpact is a projectile in flight
+type pendingImpact struct {
+	at       time.Time
+	threatID string
+	partName string
+	strategy offense.AttackStrategy
+	missile  offense.MissileType // Payload of a missile, empty for other weapons
+}
+
+// scheduleImpact queues a projectile to land at its arrival time
+func (p *Processor) scheduleImpact(impact pendingImpact) {
+	p.impactsMu.Lock()
+	defer p.impactsMu.Unlock()
+	p.impacts = append(p.impacts, impact)
+}
+
+// resolveDueImpacts lands every projectile whose arrival time has passed,
+// in the order they were fired
+func (p *Processor) resolveDueImpacts() {
+	now := p.clock.Now()
+
+	p.impactsMu.Lock()
+	var due, pending []pendingImpact
+	for _, impact := range p.impacts {
+		if now.Before(impact.at) {
+			pending = append(pending, impact)
+		} else {
+			due = append(due, impact)
+		}
+	}
+	p.impacts = pending
+	p.impactsMu.Unlock()
+
+	for _, impact := range due {
+		p.resolveImpact(impact)
+	}
+}
+
+// resolveImpact applies the damage of a projectile when it arrives. A threat
+// that has moved out of the weapon's range during the flight avoids it.
+func (p *Processor) resolveImpact(impact pendingImpact) {
+	if p.ctx.Err() != nil {
+		return
+	}
+
+	stored, exists := p.threats.Get(impact.threatID)
+	if !exists {
+		return
+	}
+	if distance := common.CalculateDistance(p.getLocation(), stored.Location); distance > impact.strategy.Range {
+		p.logger.Info(fmt.Sprintf("%s from %s missed %s: target moved out of range (%.2f meters)",
+			impact.strategy.Weapon, impact.partName, impact.threatID, distance))
+		return
+	}
+
+	threat := &stored
+	p.landStrike(threat, impact.partName, impact.strategy, impact.missile)
+	if threat.Health <= 0 {
+		p.eliminateThreat(threat)
+	}
+}
+
+// applyDamage reduces a threat's health and returns the damage actually dealt
+func (p *Processor) applyDamage(threat *common.Threat, partName, weapon string, amount float64) float64 {
+	updated, damage, err := p.threats.Damage(threat.ID, amount)
+	if err != nil {
+		// Eliminated or pruned since the strike was aimed
+		return 0
+	}
+	*threat = updated
+	p.syncActiveThreat(*threat)
+
+	p.logger.Info(fmt.Sprintf("Attacked %s with %s (Damage: %.1f%%, Remaining Health: %.1f%%)",
+		threat.ID, weapon, damage, threat.Health))
+	p.logger.RecordCombat(p.clock.Now(), monitoring.CombatDamageDealt, monitoring.CombatPayload{
+		ThreatID: threat.ID,
+		Part:     partName,
+		Weapon:   weapon,
+		Value:    damage,
+		Health:   threat.Health,
+	})
+	p.events.Publish(events.Event{
+		Type:     events.WeaponFired,
+		ThreatID: threat.ID,
+		Part:     partName,
+		Weapon:   weapon,
+		Value:    damage,
+		Health:   threat.Health,
+	})
+	return damage
+}
+
+// partReady reports whether a part has recovered from its last shot
+func (p *Processor) partReady(partName string, now time.Time) bool {
+	p.partBusyMu.Lock()
+	defer p.partBusyMu.Unlock()
+	return !now.Before(p.partBusyUntil[partName])
+}
+
+// setPartBusy occupies a part until the given time
+func (p *Processor) setPartBusy(partName string, until time.Time) {
+	p.partBusyMu.Lock()
+	defer p.partBusyMu.Unlock()
+	p.partBusyUntil[partName] = until
+}
+
+// eliminateThreat removes a destroyed threat and stands down if it was the primary target
+func (p *Processor) eliminateThreat(threat *common.Threat) {
+	p.logger.Info(fmt.Sprintf("Threat %s has been eliminated", threat.ID))
+	p.eliminated.Add(1)
+	p.threats.Remove(threat.ID)
+	p.events.Publish(events.Event{Type: events.ThreatEliminated, ThreatID: threat.ID})
+	p.queueSalvage(threat.Location)
+	p.resetEngagement(threat.ID)
+	p.forgetEscalation(threat.ID)
+	if p.isActiveThreat(threat.ID) {
+		p.promoteNextTarget()
+	}
+	p.shareElimination(threat.ID)
+}
@@ -0,0 +1,152 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+func TestCoordinatedAttackStopsOnceThreatEliminated(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	threat := testThreat("t1", 5, common.Location{X: 30})
+	threat.Health = 1
+	p.AddThreat(threat)
+	p.escalate(threat.ID, EscalationFullEngagement)
+	p.status.active = true
+	p.config.PartialVolley = false
+
+	ammo := p.offense.AmmoStatus()
+	if damage := p.executeCoordinatedAttack(&threat); damage <= 0 {
+		t.Fatalf("volley dealt %v damage, want the threat eliminated", damage)
+	}
+	if _, exists := p.threats.Get(threat.ID); exists {
+		t.Fatal("threat still tracked after the volley")
+	}
+
+	strategy, _, _ := p.offense.Strategy("plasma_cannon")
+	fired := 0
+	for _, arm := range p.anatomy.Arms {
+		if p.offense.CooldownRemaining(arm, strategy, clock.Now()) > 0 {
+			fired++
+		}
+	}
+	if fired != 1 {
+		t.Errorf("%d arms fired, want only the first", fired)
+	}
+	for pool, rounds := range p.offense.AmmoStatus() {
+		if rounds != ammo[pool] {
+			t.Errorf("%s spent %d rounds after the threat was eliminated", pool, ammo[pool]-rounds)
+		}
+	}
+}
+
+func TestMinimalVolleyUsesSingleWeaponOnWeakThreat(t *testing.T) {
+	p, _ := newTestProcessor(t)
+
+	weak := testThreat("weak", 5, common.Location{X: 20})
+	weak.Health = 10
+	full := p.volleyAssignments(&weak)
+	if len(full) < 2 {
+		t.Fatalf("full volley has %d weapons, want several to choose from", len(full))
+	}
+	if volley := p.minimalVolley(&weak); len(volley) != 1 {
+		t.Errorf("minimal volley against a 10-health threat has %d weapons, want 1", len(volley))
+	}
+
+	tough := testThreat("tough", 5, common.Location{X: 20})
+	tough.Health = 10_000
+	if volley := p.minimalVolley(&tough); len(volley) != len(p.volleyAssignments(&tough)) {
+		t.Errorf("minimal volley against an unkillable threat has %d weapons, want the full volley", len(volley))
+	}
+}
+
+func TestThreatLeavingRangeDuringFlightAvoidsMissile(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		moveTo  common.Location
+		damaged bool
+	}{
+		{"holds position", common.Location{X: 70}, true},
+		{"leaves range", common.Location{X: 150}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, clock := newTestProcessor(t)
+			threat := testThreat("t1", 5, common.Location{X: 70})
+			p.AddThreat(threat)
+			p.setActiveThreat(&threat)
+			p.escalate(threat.ID, EscalationFullEngagement)
+
+			if dealt, err := p.LaunchMissile(offense.HighExplosive); err != nil || dealt != 0 {
+				t.Fatalf("LaunchMissile = %.2f, %v; want the missile in flight", dealt, err)
+			}
+
+			moved := threat
+			moved.Location = tc.moveTo
+			if err := p.threats.Update(moved); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+			clock.Advance(2 * time.Second)
+			p.resolveDueImpacts()
+
+			stored, _ := p.threats.Get(threat.ID)
+			if damaged := stored.Health < 100; damaged != tc.damaged {
+				t.Errorf("threat health = %.2f, damaged %v, want %v", stored.Health, damaged, tc.damaged)
+			}
+		})
+	}
+}
+
+func TestDisabledArmSitsOutCoordinatedAttack(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	threat := testThreat("t1", 5, common.Location{X: 30})
+	threat.Health = 10_000
+	p.AddThreat(threat)
+	p.escalate(threat.ID, EscalationFullEngagement)
+	p.status.active = true
+	p.config.PartialVolley = false
+
+	disabled, working := p.anatomy.Arms[0], p.anatomy.Arms[1]
+	disabled.Expose(100)
+	for _, assignment := range p.volleyAssignments(&threat) {
+		if assignment.part == disabled {
+			t.Fatalf("volley assigns %s to the destroyed %s", assignment.strategy.Weapon, disabled.Name)
+		}
+	}
+
+	if damage := p.executeCoordinatedAttack(&threat); damage <= 0 {
+		t.Fatal("volley dealt no damage")
+	}
+	strategy, _, _ := p.offense.Strategy("plasma_cannon")
+	if p.offense.CooldownRemaining(disabled, strategy, clock.Now()) > 0 {
+		t.Errorf("destroyed %s fired", disabled.Name)
+	}
+	if p.offense.CooldownRemaining(working, strategy, clock.Now()) == 0 {
+		t.Errorf("working %s did not fire", working.Name)
+	}
+}
+
+func TestOutOfRangeWeaponsAreSkipped(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	threat := testThreat("t1", 5, common.Location{X: 80})
+	threat.Health = 10_000
+	p.AddThreat(threat)
+	p.setActiveThreat(&threat)
+	p.escalate(threat.ID, EscalationFullEngagement)
+	p.status.active = true
+	p.config.PartialVolley = false
+
+	p.executeCoordinatedAttack(&threat)
+
+	plasma, _, _ := p.offense.Strategy("plasma_cannon")
+	for _, arm := range p.anatomy.Arms {
+		if p.offense.CooldownRemaining(arm, plasma, clock.Now()) > 0 {
+			t.Errorf("%s fired its plasma cannon at a threat 80m away", arm.Name)
+		}
+	}
+	missile, _, _ := p.offense.Strategy(offense.MissileWeapon)
+	if p.offense.CooldownRemaining(p.anatomy.Body, missile, clock.Now()) == 0 {
+		t.Error("missile did not fire at a threat 80m away")
+	}
+}
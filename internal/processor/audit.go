@@ -0,0 +1,86 @@
+package processor
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// maxAuditRecords bounds the audit log; the oldest records are dropped first
+const maxAuditRecords = 1000
+
+// DecisionSource identifies what produced a tactical decision
+type DecisionSource string
+
+const (
+	SourceAI        DecisionSource = "ai"
+	SourceHeuristic DecisionSource = "heuristic"
+	SourceOperator  DecisionSource = "operator"
+)
+
+// DecisionRecord captures a single tactical decision for after-action review
+type DecisionRecord struct {
+	Timestamp      time.Time          `json:"timestamp"`
+	ThreatID       string             `json:"threat_id"`
+	ThreatSeverity int                `json:"threat_severity"`
+	ThreatHealth   float64            `json:"threat_health"`
+	Distance       float64            `json:"distance"`
+	Health         map[string]float64 `json:"health"`
+	Action         string             `json:"action"`
+	Weapon         string             `json:"weapon,omitempty"`
+	Source         DecisionSource     `json:"source"`
+	Explanation    string             `json:"explanation,omitempty"`
+	Outcome        string             `json:"outcome"`
+}
+
+// DecisionAudit is a thread-safe log of every tactical decision made
+type DecisionAudit struct {
+	mu      sync.RWMutex
+	records []DecisionRecord
+}
+
+// NewDecisionAudit creates an empty decision audit log
+func NewDecisionAudit() *DecisionAudit {
+	return &DecisionAudit{
+		records: make([]DecisionRecord, 0),
+	}
+}
+
+// Record appends a decision to the audit log
+func (a *DecisionAudit) Record(record DecisionRecord) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	a.records = append(a.records, record)
+	if len(a.records) > maxAuditRecords {
+		a.records = a.records[len(a.records)-maxAuditRecords:]
+	}
+}
+
+// Records returns a copy of all recorded decisions in order
+func (a *DecisionAudit) Records() []DecisionRecord {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	records := make([]DecisionRecord, len(a.records))
+	copy(records, a.records)
+	return records
+}
+
+// Len returns the number of recorded decisions
+func (a *DecisionAudit) Len() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return len(a.records)
+}
+
+// ExportJSON writes all recorded decisions to w as a JSON array
+func (a *DecisionAudit) ExportJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(a.Records())
+}
@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestEngagementIsAudited(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	activate(p)
+	if err := p.ReportThreat(testThreat("t1", 8, common.Location{X: 30})); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+
+	steps := 0
+	for ; steps < 100; steps++ {
+		clock.Advance(500 * time.Millisecond)
+		p.resolveDueImpacts()
+		if _, tracked := p.threats.Get("t1"); !tracked {
+			break
+		}
+		if err := p.moveAndEngageWithAI(p.ctx); err != nil {
+			t.Fatalf("moveAndEngageWithAI: %v", err)
+		}
+	}
+	if _, tracked := p.threats.Get("t1"); tracked {
+		t.Fatalf("threat still standing after %d steps", steps)
+	}
+
+	records := p.DecisionAudit().Records()
+	if len(records) != steps+1 {
+		t.Fatalf("got %d audit records for the report and %d engagement steps, want %d", len(records), steps, steps+1)
+	}
+	if first := records[0]; first.Action != "engage" || first.Source != SourceOperator {
+		t.Errorf("first record = %s by %s, want engage by operator", first.Action, first.Source)
+	}
+	attacked := false
+	for _, record := range records {
+		if record.ThreatID != "t1" || record.Outcome == "" || record.Health == nil {
+			t.Errorf("incomplete audit record: %+v", record)
+		}
+		if record.Action == "attack" && record.Weapon != "" {
+			attacked = true
+		}
+	}
+	if !attacked {
+		t.Error("no audit record of an attack and the weapon used")
+	}
+}
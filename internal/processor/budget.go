@@ -0,0 +1,94 @@
+package processor
+
+import (
+	"fmt"
+	"time"
+
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+// EngagementBudget caps what the robot commits to a single threat. Once any
+// limit is exceeded the threat is de-prioritized and no longer engaged on
+// the robot's own initiative. Zero limits are unbounded.
+type EngagementBudget struct {
+	MaxPower float64       // Total weapon power spent on the threat
+	MaxAmmo  int           // Total rounds of limited ammunition spent on the threat
+	MaxTime  time.Duration // Time since first firing on the threat
+}
+
+// DefaultEngagementBudget returns the default per-threat engagement budget
+func DefaultEngagementBudget() EngagementBudget {
+	return EngagementBudget{
+		MaxPower: 600.0,
+		MaxAmmo:  4,
+		MaxTime:  30 * time.Second,
+	}
+}
+
+// engagementSpend tallies the resources committed to a threat
+type engagementSpend struct {
+	started time.Time
+	power   float64
+	ammo    int
+}
+
+// exceeds reports which limit of the budget the spend has gone past, if any
+func (s *engagementSpend) exceeds(budget EngagementBudget, now time.Time) string {
+	switch {
+	case budget.MaxPower > 0 && s.power >= budget.MaxPower:
+		return fmt.Sprintf("power %.1f of %.1f", s.power, budget.MaxPower)
+	case budget.MaxAmmo > 0 && s.ammo >= budget.MaxAmmo:
+		return fmt.Sprintf("ammo %d of %d", s.ammo, budget.MaxAmmo)
+	case budget.MaxTime > 0 && now.Sub(s.started) >= budget.MaxTime:
+		return fmt.Sprintf("time %s of %s", now.Sub(s.started).Round(time.Millisecond), budget.MaxTime)
+	default:
+		return ""
+	}
+}
+
+// overBudget reports whether a threat has exhausted its engagement budget
+func (p *Processor) overBudget(threatID string) bool {
+	p.engagementsMu.Lock()
+	defer p.engagementsMu.Unlock()
+
+	spend, exists := p.engagements[threatID]
+	return exists && spend.exceeds(p.config.EngagementBudget, p.clock.Now()) != ""
+}
+
+// noteEngagementSpend charges a shot to the threat's budget, de-prioritizing
+// the threat as soon as the budget is exhausted
+func (p *Processor) noteEngagementSpend(threat *common.Threat, strategy offense.AttackStrategy, now time.Time) {
+	p.engagementsMu.Lock()
+	spend, exists := p.engagements[threat.ID]
+	if !exists {
+		spend = &engagementSpend{started: now}
+		p.engagements[threat.ID] = spend
+	}
+	spend.power += strategy.PowerUsage
+	if _, limited := p.offense.Ammo(strategy.Weapon); limited {
+		spend.ammo++
+	}
+	exceeded := spend.exceeds(p.config.EngagementBudget, now)
+	p.engagementsMu.Unlock()
+
+	if exceeded != "" {
+		p.deprioritize(threat, exceeded)
+	}
+}
+
+// deprioritize stops pursuing a threat whose budget is exhausted and
+// reassesses which threat to engage
+func (p *Processor) deprioritize(threat *common.Threat, reason string) {
+	p.logger.Info(fmt.Sprintf("Engagement budget for %s exhausted (%s), de-prioritizing", threat.ID, reason))
+	if p.isActiveThreat(threat.ID) {
+		p.promoteNextTarget()
+	}
+}
+
+// resetEngagement clears the resources charged to a threat
+func (p *Processor) resetEngagement(threatID string) {
+	p.engagementsMu.Lock()
+	defer p.engagementsMu.Unlock()
+	delete(p.engagements, threatID)
+}
@@ -0,0 +1,39 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestThreatExhaustingPowerBudgetIsDeprioritized(t *testing.T) {
+	cfg := DefaultProcessorConfig()
+	cfg.EngagementBudget = EngagementBudget{MaxPower: 150}
+	p, _ := newTestProcessorWithConfig(t, cfg)
+
+	tank := testThreat("tank", 9, common.Location{X: 20})
+	tank.Health = 10_000
+	p.AddThreat(tank)
+	p.AddThreat(testThreat("other", 3, common.Location{X: 30}))
+	if primary := p.promoteNextTarget(); primary == nil || primary.ID != "tank" {
+		t.Fatalf("primary = %v, want tank", primary)
+	}
+	p.escalate("tank", EscalationFullEngagement)
+
+	// Two 75-power plasma shots use up the 150 power budget
+	for shot := 0; shot < 2; shot++ {
+		if dealt := p.executeAttack("plasma_cannon", ""); dealt <= 0 {
+			t.Fatalf("shot %d dealt no damage", shot+1)
+		}
+	}
+
+	if stored, alive := p.threats.Get("tank"); !alive || stored.Health <= 0 {
+		t.Fatal("tank died, want it to survive its budget")
+	}
+	if !p.overBudget("tank") {
+		t.Error("tank not over budget after spending 150 power")
+	}
+	if active := p.GetActiveThreat(); active == nil || active.ID != "other" {
+		t.Errorf("active threat = %v, want other after tank was de-prioritized", active)
+	}
+}
@@ -0,0 +1,177 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+	"t800/internal/defense"
+	"t800/internal/offense"
+)
+
+// maxCheckpoints bounds the undo history; the oldest checkpoints are dropped first
+const maxCheckpoints = 32
+
+// ProcessorSnapshot captures the processor and anatomy state at a point in time
+type ProcessorSnapshot struct {
+	Mode         common.OperationMode          `json:"mode"`
+	ResumeMode   common.OperationMode          `json:"resume_mode"` // Mode to return to when an emergency ends
+	Posture      Posture                       `json:"posture"`
+	Location     common.Location               `json:"location"`
+	Heading      float64                       `json:"heading"`
+	ActiveThreat *common.Threat                `json:"active_threat,omitempty"`
+	Threats      []common.Threat               `json:"threats"`
+	Escalation   map[string]EscalationLevel    `json:"escalation"`
+	Ammo         map[string]int                `json:"ammo"`
+	Heat         map[string]offense.HeatState  `json:"heat"`
+	Boosts       map[string]defense.BoostState `json:"boosts"`
+	Anatomy      anatomy.AnatomySnapshot       `json:"anatomy"`
+}
+
+// snapshot captures the current processor state
+func (p *Processor) snapshot() ProcessorSnapshot {
+	p.status.mu.RLock()
+	mode, resumeMode, posture := p.status.Mode, p.status.resumeMode, p.status.Posture
+	p.status.mu.RUnlock()
+
+	p.escalationMu.Lock()
+	escalation := make(map[string]EscalationLevel, len(p.escalation))
+	for id, level := range p.escalation {
+		escalation[id] = level
+	}
+	p.escalationMu.Unlock()
+
+	return ProcessorSnapshot{
+		Mode:         mode,
+		ResumeMode:   resumeMode,
+		Posture:      posture,
+		Location:     p.getLocation(),
+		Heading:      p.Heading(),
+		ActiveThreat: p.GetActiveThreat(),
+		Threats:      p.threats.List(),
+		Escalation:   escalation,
+		Ammo:         p.offense.AmmoStatus(),
+		Heat:         p.offense.HeatStatus(),
+		Boosts:       p.defense.BoostStatus(),
+		Anatomy:      p.anatomy.Snapshot(),
+	}
+}
+
+// restore applies a previously captured processor state. The mode is
+// switched directly, even out of an emergency, and reported like any other
+// mode change; the emergency's resume mode and the defensive boosts are
+// restored with it, so boosted protection still expires on time.
+func (p *Processor) restore(snapshot ProcessorSnapshot) error {
+	if err := p.anatomy.Restore(snapshot.Anatomy); err != nil {
+		return fmt.Errorf("failed to restore anatomy: %v", err)
+	}
+	p.defense.SetBoostStatus(p.anatomy, snapshot.Boosts)
+
+	p.status.mu.Lock()
+	previous := p.status.Mode
+	p.status.Mode = snapshot.Mode
+	p.status.resumeMode = snapshot.ResumeMode
+	p.status.Posture = snapshot.Posture
+	p.status.mu.Unlock()
+	p.modeChanged(previous, snapshot.Mode)
+
+	p.setLocation(snapshot.Location)
+	p.setHeading(snapshot.Heading)
+	p.setActiveThreat(snapshot.ActiveThreat)
+	p.threats.Reset(snapshot.Threats)
+
+	p.escalationMu.Lock()
+	p.escalation = make(map[string]EscalationLevel, len(snapshot.Escalation))
+	for id, level := range snapshot.Escalation {
+		p.escalation[id] = level
+	}
+	p.escalationMu.Unlock()
+
+	for weapon, rounds := range snapshot.Ammo {
+		p.offense.SetAmmo(weapon, rounds)
+	}
+	p.offense.SetHeatStatus(snapshot.Heat)
+	return nil
+}
+
+// SaveState writes the processor and anatomy state to w as JSON
+func (p *Processor) SaveState(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(p.snapshot()); err != nil {
+		return fmt.Errorf("failed to save state: %v", err)
+	}
+	return nil
+}
+
+// LoadState restores processor and anatomy state previously written by
+// SaveState. Parts destroyed when the state was saved stay disabled. The
+// command is checkpointed so the state it replaced can be restored with Undo.
+func (p *Processor) LoadState(r io.Reader) error {
+	var snapshot ProcessorSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return fmt.Errorf("failed to load state: %v", err)
+	}
+	return p.command(func() error {
+		if err := p.restore(snapshot); err != nil {
+			return err
+		}
+		p.logger.Info("Restored saved state")
+		return nil
+	})
+}
+
+// Checkpoint records the current state so it can be restored with Undo
+func (p *Processor) Checkpoint() {
+	snapshot := p.snapshot()
+
+	p.historyMu.Lock()
+	defer p.historyMu.Unlock()
+	p.history = append(p.history, snapshot)
+	if len(p.history) > maxCheckpoints {
+		p.history = p.history[len(p.history)-maxCheckpoints:]
+	}
+}
+
+// command runs a state-changing operator command, checkpointing first so it
+// can be undone. A command that fails leaves no checkpoint behind.
+func (p *Processor) command(run func() error) error {
+	p.commandMu.Lock()
+	defer p.commandMu.Unlock()
+
+	p.Checkpoint()
+	if err := run(); err != nil {
+		p.dropCheckpoint()
+		return err
+	}
+	return nil
+}
+
+// dropCheckpoint discards the most recent checkpoint without restoring it
+func (p *Processor) dropCheckpoint() {
+	p.historyMu.Lock()
+	defer p.historyMu.Unlock()
+	if len(p.history) > 0 {
+		p.history = p.history[:len(p.history)-1]
+	}
+}
+
+// Undo restores the most recent checkpoint and removes it from the history
+func (p *Processor) Undo() error {
+	p.historyMu.Lock()
+	if len(p.history) == 0 {
+		p.historyMu.Unlock()
+		return fmt.Errorf("no checkpoint to undo")
+	}
+	snapshot := p.history[len(p.history)-1]
+	p.history = p.history[:len(p.history)-1]
+	p.historyMu.Unlock()
+
+	if err := p.restore(snapshot); err != nil {
+		return err
+	}
+	p.logger.Info("Restored previous checkpoint")
+	return nil
+}
@@ -0,0 +1,170 @@
+package processor
+
+import (
+	"bytes"
+	"testing"
+
+	"t800/internal/common"
+	"t800/internal/events"
+)
+
+func TestUndoRestoresModeAndHealth(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	activate(p)
+	p.anatomy.Arms[0].Expose(30)
+	health := p.anatomy.GetHealthStatus()
+
+	if err := p.ReportThreat(testThreat("t1", 7, common.Location{X: 30})); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+	engaged := p.anatomy.GetHealthStatus()
+	if err := p.ReportDamage("t1", "body", 60); err != nil {
+		t.Fatalf("ReportDamage: %v", err)
+	}
+	if mode := p.getMode(); mode != common.Combat {
+		t.Fatalf("mode after report = %s, want combat", mode)
+	}
+
+	// Each command checkpoints itself, so undo steps back one command at a time
+	if err := p.Undo(); err != nil {
+		t.Fatalf("Undo damage: %v", err)
+	}
+	if mode := p.getMode(); mode != common.Combat {
+		t.Errorf("mode after undoing damage = %s, want combat", mode)
+	}
+	for part, want := range engaged {
+		if got := p.anatomy.GetHealthStatus()[part]; got != want {
+			t.Errorf("%s health after undoing damage = %v, want %v", part, got, want)
+		}
+	}
+
+	if err := p.Undo(); err != nil {
+		t.Fatalf("Undo report: %v", err)
+	}
+	if mode := p.getMode(); mode != common.Normal {
+		t.Errorf("mode after undo = %s, want normal", mode)
+	}
+	for part, want := range health {
+		if got := p.anatomy.GetHealthStatus()[part]; got != want {
+			t.Errorf("%s health after undo = %v, want %v", part, got, want)
+		}
+	}
+	if p.GetActiveThreat() != nil || p.threats.Len() != 0 {
+		t.Error("threat still tracked after undo")
+	}
+	if err := p.Undo(); err == nil {
+		t.Error("Undo with no checkpoint left succeeded")
+	}
+}
+
+func TestUndoCommandWithoutCheckpoint(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	activate(p)
+
+	p.SetPosture(PosturePassive)
+	if err := p.Undo(); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if posture := p.getPosture(); posture != PostureBalanced {
+		t.Errorf("posture after undo = %s, want %s", posture, PostureBalanced)
+	}
+}
+
+func TestFailedCommandLeavesNoCheckpoint(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	activate(p)
+
+	if err := p.ReportDamage("t1", "tail", 10); err == nil {
+		t.Fatal("ReportDamage on an unknown part succeeded")
+	}
+	if err := p.Undo(); err == nil {
+		t.Error("failed command left a checkpoint to undo")
+	}
+}
+
+func TestUndoLeavesEmergencyCleanly(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	head := p.anatomy.Head.Protection()
+	p.Checkpoint()
+
+	p.enterEmergency(10)
+	if len(p.defense.BoostStatus()) == 0 {
+		t.Fatal("emergency shielding granted no boosts")
+	}
+
+	stream, unsubscribe := p.Subscribe()
+	defer unsubscribe()
+	if err := p.Undo(); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+
+	if mode := p.getMode(); mode != common.Normal {
+		t.Errorf("mode after undo = %s, want normal", mode)
+	}
+	p.status.mu.RLock()
+	resumeMode := p.status.resumeMode
+	p.status.mu.RUnlock()
+	if resumeMode != common.Normal {
+		t.Errorf("resume mode after undo = %s, want normal", resumeMode)
+	}
+	if boosts := p.defense.BoostStatus(); len(boosts) != 0 {
+		t.Errorf("boosts after undo = %v, want none", boosts)
+	}
+	if got := p.anatomy.Head.Protection(); got != head {
+		t.Errorf("head protection after undo = %+v, want %+v", got, head)
+	}
+
+	announced := false
+	for len(stream) > 0 {
+		if event := <-stream; event.Type == events.ModeChanged && event.Mode == common.Normal {
+			announced = true
+		}
+	}
+	if !announced {
+		t.Error("undo did not announce the change back to normal mode")
+	}
+}
+
+func TestSaveStateRoundTripsIntoFreshProcessor(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	activate(p)
+	p.anatomy.Arms[0].Expose(30)
+	p.anatomy.Body.Expose(45)
+	p.anatomy.Legs[1].Expose(100)
+	if err := p.ReportThreat(testThreat("t1", 7, common.Location{X: 30})); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+
+	var saved bytes.Buffer
+	if err := p.SaveState(&saved); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	restored, _ := newTestProcessor(t)
+	if err := restored.LoadState(&saved); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	health := p.anatomy.GetHealthStatus()
+	got := restored.anatomy.GetHealthStatus()
+	if len(got) != len(health) {
+		t.Fatalf("restored %d parts, want %d", len(got), len(health))
+	}
+	for part, want := range health {
+		if got[part] != want {
+			t.Errorf("%s health after load = %v, want %v", part, got[part], want)
+		}
+	}
+	if !restored.anatomy.Legs[1].IsDisabled() {
+		t.Errorf("destroyed %s is not disabled after load", restored.anatomy.Legs[1].Name)
+	}
+	if mode := restored.getMode(); mode != common.Combat {
+		t.Errorf("mode after load = %s, want combat", mode)
+	}
+	if active := restored.GetActiveThreat(); active == nil || active.ID != "t1" {
+		t.Errorf("active threat after load = %v, want t1", active)
+	}
+
+	if err := restored.LoadState(bytes.NewBufferString("{")); err == nil {
+		t.Error("LoadState accepted malformed JSON")
+	}
+}
@@ -0,0 +1,43 @@
+package processor
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestDriveStopsAtContactWithThreat(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		radius float64
+	}{
+		{"threat radius", 1.0},
+		{"robot-sized threat", 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newTestProcessor(t, WithSpeed(common.MovementSpeed{Linear: 5, Angular: math.Pi}))
+			threat := testThreat("t1", 5, common.Location{X: 3})
+			threat.Radius = tc.radius
+			p.AddThreat(threat)
+
+			radius := p.anatomy.BoundingRadius()
+			contact := radius + tc.radius
+			if tc.radius == 0 {
+				contact = 2 * radius
+			}
+			for i := 0; i < 20; i++ {
+				location := p.drive(threat.Location)
+				if distance := common.CalculateDistance(location, threat.Location); distance < contact-1e-9 {
+					t.Fatalf("step %d: robot %.3fm from the threat, overlapping contact at %.3fm", i, distance, contact)
+				}
+			}
+			if distance := common.CalculateDistance(p.getLocation(), threat.Location); math.Abs(distance-contact) > 1e-9 {
+				t.Errorf("robot stopped %.3fm from the threat, want contact at %.3fm", distance, contact)
+			}
+			if v := p.Velocity(); v != (common.Location{}) {
+				t.Errorf("velocity at contact = %+v, want stopped", v)
+			}
+		})
+	}
+}
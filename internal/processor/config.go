@@ -0,0 +1,105 @@
+package processor
+
+import (
+	"math"
+	"time"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+// ProcessorConfig holds tunable behaviour for the processor
+type ProcessorConfig struct {
+	// RegenInCombat allows passive health regeneration while in Combat mode
+	RegenInCombat bool
+	// HitPolicy selects which part absorbs damage when a hit doesn't name one
+	HitPolicy anatomy.HitPolicy
+	// PredictedEngageConfidence is the minimum confidence required to engage a
+	// predicted threat; less certain predictions are only tracked
+	PredictedEngageConfidence float64
+	// LatencyBudget is the longest acceptable time from detecting a threat to
+	// first firing on it before a warning is logged; zero disables the check
+	LatencyBudget time.Duration
+	// CoverHealthThreshold is the critical-part health below which the robot
+	// breaks off to take cover behind an obstacle
+	CoverHealthThreshold float64
+	// PartialVolley fires only the weapons needed to eliminate a threat
+	// rather than the full loadout, conserving resources
+	PartialVolley bool
+	// DefenseBudget is the power available to defensive actions when a threat
+	// is reported; actions run in defense priority order, each drawing its
+	// strategy's power usage, while it lasts
+	DefenseBudget float64
+	// StandbyIdle is how long the processor may sit idle in Normal mode
+	// before entering standby; zero disables automatic standby
+	StandbyIdle time.Duration
+	// StandbyScanInterval is the reduced scan frequency used in standby
+	StandbyScanInterval time.Duration
+	// ModeHysteresis is how long threats must persist before the processor
+	// enters combat on its own, and how long they must be absent before it
+	// returns to normal; zero switches modes on every scan
+	ModeHysteresis time.Duration
+	// Targeting selects how the active threat is chosen among those scanned
+	Targeting TargetingDoctrine
+	// Imminence weighs severity against time to impact under the imminent doctrine
+	Imminence ImminenceWeights
+	// Recovery controls how parts are repaired in maintenance mode
+	Recovery anatomy.RecoveryPolicy
+	// Nanobots controls active repair of critical parts outside maintenance
+	Nanobots anatomy.NanobotPolicy
+	// Salvage controls recovering resources from the wrecks of eliminated threats
+	Salvage SalvageConfig
+	// WeaponDamage is the full damage dealt per hit by each weapon; weapons
+	// missing from the table deal a small default amount
+	WeaponDamage map[string]float64
+	// FlankSpread is the angular spread in radians of live threats around the
+	// robot at or beyond which it considers itself flanked
+	FlankSpread float64
+	// EngagementBudget caps the resources committed to any single threat
+	EngagementBudget EngagementBudget
+	// ROE holds the rules of engagement the processor must respect
+	ROE ROE
+	// ThreatApproachSpeed is the speed in meters per second at which threats
+	// without a velocity of their own close on the robot; zero leaves them static
+	ThreatApproachSpeed float64
+	// ThreatTimeout is how long a threat may go without being reported or
+	// re-detected before it is dropped; zero keeps threats until eliminated
+	ThreatTimeout time.Duration
+	// SafeZone is where the robot falls back to when retreating; nil retreats
+	// directly away from the nearest threat
+	SafeZone *common.Location
+	// SafeDistance is how far from every threat the robot must be before a
+	// retreat may end
+	SafeDistance float64
+	// RetreatRecoveryHealth is the critical-part health at which a retreating
+	// robot re-engages
+	RetreatRecoveryHealth float64
+}
+
+// DefaultProcessorConfig returns the default processor configuration
+func DefaultProcessorConfig() ProcessorConfig {
+	return ProcessorConfig{
+		RegenInCombat: false,
+		HitPolicy:     anatomy.HitRandomBySurfaceArea,
+
+		PredictedEngageConfidence: 0.85,
+		LatencyBudget:             2 * time.Second,
+		CoverHealthThreshold:      50.0,
+		PartialVolley:             true,
+		DefenseBudget:             200.0,
+		StandbyIdle:               2 * time.Minute,
+		StandbyScanInterval:       2 * time.Second,
+		ModeHysteresis:            time.Second,
+		Targeting:                 TargetImminent,
+		Imminence:                 DefaultImminenceWeights(),
+		Recovery:                  anatomy.DefaultRecoveryPolicy(),
+		Nanobots:                  anatomy.DefaultNanobotPolicy(),
+		Salvage:                   DefaultSalvageConfig(),
+		WeaponDamage:              DefaultWeaponDamage(),
+		FlankSpread:               2 * math.Pi / 3,
+		EngagementBudget:          DefaultEngagementBudget(),
+		ThreatTimeout:             30 * time.Second,
+		SafeDistance:              100.0,
+		RetreatRecoveryHealth:     60.0,
+	}
+}
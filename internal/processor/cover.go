@@ -0,0 +1,152 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+
+	"t800/internal/common"
+)
+
+// coverMargin is the clearance in meters kept between the robot and its cover
+const coverMargin = 1.0
+
+// SetObstacles replaces the obstacles the processor considers for line of sight and cover
+func (p *Processor) SetObstacles(obstacles []common.Obstacle) {
+	p.obstacles = append([]common.Obstacle(nil), obstacles...)
+}
+
+// findCover searches for the nearest position behind an obstacle that breaks
+// line of sight to the threat
+func (p *Processor) findCover(threat *common.Threat) (common.Location, bool) {
+	location := p.getLocation()
+	var (
+		best     common.Location
+		bestDist = math.Inf(1)
+		found    bool
+	)
+	for _, obstacle := range p.obstacles {
+		center := obstacle.Center()
+		dx, dy := center.X-threat.Location.X, center.Y-threat.Location.Y
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			continue
+		}
+
+		// Step past the far side of the obstacle, directly away from the threat
+		halfDiagonal := math.Hypot(obstacle.Max.X-obstacle.Min.X, obstacle.Max.Y-obstacle.Min.Y) / 2
+		reach := halfDiagonal + coverMargin
+		candidate := common.Location{
+			X: center.X + dx/length*reach,
+			Y: center.Y + dy/length*reach,
+			Z: location.Z,
+		}
+		if common.HasLineOfSight(candidate, threat.Location, p.obstacles) {
+			continue
+		}
+
+		if dist := common.CalculateDistance(location, candidate); dist < bestDist {
+			best, bestDist, found = candidate, dist, true
+		}
+	}
+	return best, found
+}
+
+// needsCover reports whether a critical part has fallen below the cover threshold
+func (p *Processor) needsCover() bool {
+	for _, part := range p.anatomy.GetCriticalParts() {
+		if part.GetHealth() < p.config.CoverHealthThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// seekCover moves toward cover from the threat when badly damaged. It
+// reports whether the robot is taking cover this tick.
+func (p *Processor) seekCover(threat *common.Threat) bool {
+	if !p.needsCover() {
+		return false
+	}
+	cover, found := p.findCover(threat)
+	if !found {
+		return false
+	}
+	if common.CalculateDistance(p.getLocation(), cover) > coverMargin/2 {
+		p.moveTowardsTarget(cover)
+	}
+	p.recordDecision(threat, "take_cover", "", SourceHeuristic,
+		fmt.Sprintf("cover at (%.2f, %.2f)", cover.X, cover.Y), "breaking line of sight")
+	return true
+}
+
+// Sidesteps tried, in meters either side of the line to the threat, when
+// looking for a clear shot
+const (
+	sidestepInterval = 2.0
+	maxSidestep      = 20.0
+)
+
+// hasLineOfSight reports whether the robot can see the threat past every obstacle
+func (p *Processor) hasLineOfSight(threat *common.Threat) bool {
+	return common.HasLineOfSight(p.getLocation(), threat.Location, p.obstacles)
+}
+
+// findFiringPosition searches for the nearest sidestep across the line to the
+// threat from which it is in line of sight
+func (p *Processor) findFiringPosition(threat *common.Threat) (common.Location, bool) {
+	location := p.getLocation()
+	dx, dy := threat.Location.X-location.X, threat.Location.Y-location.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return common.Location{}, false
+	}
+
+	// Unit vector perpendicular to the line to the threat
+	px, py := -dy/length, dx/length
+	for offset := sidestepInterval; offset <= maxSidestep; offset += sidestepInterval {
+		for _, side := range []float64{1, -1} {
+			candidate := common.Location{
+				X: location.X + px*offset*side,
+				Y: location.Y + py*offset*side,
+				Z: location.Z,
+			}
+			if p.insideObstacle(candidate) {
+				continue
+			}
+			if common.HasLineOfSight(candidate, threat.Location, p.obstacles) {
+				return candidate, true
+			}
+		}
+	}
+	return common.Location{}, false
+}
+
+// insideObstacle reports whether a location lies within any obstacle
+func (p *Processor) insideObstacle(loc common.Location) bool {
+	for _, obstacle := range p.obstacles {
+		if obstacle.Contains(loc) {
+			return true
+		}
+	}
+	return false
+}
+
+// repositionForLineOfSight moves toward a position with a clear shot at the
+// threat, or closes on it when no sidestep clears the obstacles
+func (p *Processor) repositionForLineOfSight(threat *common.Threat) {
+	if position, found := p.findFiringPosition(threat); found {
+		p.moveTowardsTarget(position)
+		return
+	}
+	p.moveTowardsTarget(threat.Location)
+}
+
+// holdFireWithoutLineOfSight logs and reports whether the robot must hold
+// fire because an obstacle blocks its view of the threat
+func (p *Processor) holdFireWithoutLineOfSight(threat *common.Threat) bool {
+	if p.hasLineOfSight(threat) {
+		return false
+	}
+	p.logger.Info(fmt.Sprintf("Holding fire: no line of sight to %s", threat.ID))
+	return true
+}
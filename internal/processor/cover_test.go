@@ -0,0 +1,44 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestFindCoverChoosesSpotBehindNearestObstacle(t *testing.T) {
+	near := common.Obstacle{Min: common.Location{X: 10, Y: -1}, Max: common.Location{X: 12, Y: 1, Z: 3}}
+	far := common.Obstacle{Min: common.Location{X: -40, Y: -1}, Max: common.Location{X: -38, Y: 1, Z: 3}}
+	p, _ := newTestProcessor(t, WithObstacles([]common.Obstacle{far, near}))
+	threat := testThreat("t1", 8, common.Location{X: 30})
+
+	cover, found := p.findCover(&threat)
+	if !found {
+		t.Fatal("no cover found behind either obstacle")
+	}
+	if common.HasLineOfSight(cover, threat.Location, []common.Obstacle{near, far}) {
+		t.Errorf("cover at %+v is in line of sight of the threat", cover)
+	}
+	if cover.X >= near.Min.X || cover.X < 0 {
+		t.Errorf("cover at %+v, want the near side of the nearest obstacle", cover)
+	}
+}
+
+func TestSeekCoverOnlyWhenBadlyDamaged(t *testing.T) {
+	obstacle := common.Obstacle{Min: common.Location{X: 10, Y: -1}, Max: common.Location{X: 12, Y: 1, Z: 3}}
+	p, _ := newTestProcessor(t, WithObstacles([]common.Obstacle{obstacle}))
+	threat := testThreat("t1", 8, common.Location{X: 30})
+
+	if p.seekCover(&threat) {
+		t.Fatal("healthy robot took cover")
+	}
+
+	p.anatomy.Head.Expose(60)
+	start := p.getLocation()
+	if !p.seekCover(&threat) {
+		t.Fatal("damaged robot did not take cover")
+	}
+	if moved := p.getLocation(); moved.X <= start.X {
+		t.Errorf("robot moved from %+v to %+v, want toward cover", start, moved)
+	}
+}
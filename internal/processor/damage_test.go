@@ -0,0 +1,42 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestThreatDiesAfterExpectedVolleys(t *testing.T) {
+	cfg := DefaultProcessorConfig()
+	cfg.WeaponDamage = map[string]float64{"plasma_cannon": 20}
+	p, clock := newTestProcessorWithConfig(t, cfg)
+	p.offense.SetEffectiveness(nil)
+
+	threat := testThreat("t1", 5, common.Location{X: 20})
+	p.AddThreat(threat)
+	p.setActiveThreat(&threat)
+	p.escalate(threat.ID, EscalationFullEngagement)
+
+	// 20 damage a shot against 100 health takes five shots
+	for shot := 1; shot <= 5; shot++ {
+		if dealt := p.executeAttack("plasma_cannon", ""); dealt != 20 {
+			t.Fatalf("shot %d dealt %.2f damage, want 20", shot, dealt)
+		}
+		stored, alive := p.threats.Get(threat.ID)
+		if shot < 5 {
+			if !alive || stored.Health != 100-20*float64(shot) {
+				t.Fatalf("after shot %d threat = %+v, alive %v; want %.0f health", shot, stored, alive, 100-20*float64(shot))
+			}
+		} else if alive {
+			t.Fatalf("threat survived the fifth shot with %.2f health", stored.Health)
+		}
+
+		// Let the cannons cool down and recover before the next shot
+		clock.Advance(5 * time.Second)
+		p.offense.Tick(5)
+	}
+	if eliminated := p.Metrics().ThreatsEliminated; eliminated != 1 {
+		t.Errorf("threats eliminated = %d, want 1", eliminated)
+	}
+}
@@ -0,0 +1,122 @@
+package processor
+
+import (
+	"context"
+	"time"
+
+	"t800/internal/ai"
+	"t800/internal/common"
+)
+
+// combatDecisionTTL is how long an AI combat decision keeps driving the
+// movement loop before it is considered stale
+const combatDecisionTTL = 4 * scanInterval
+
+// engagingActions are the AI combat actions that take on a threat rather
+// than holding or falling back from it
+var engagingActions = map[string]bool{"attack": true, "move": true}
+
+// cachedDecision is the AI's latest combat decision for a threat
+type cachedDecision struct {
+	threatID string
+	decision *ai.CombatDecision
+	at       time.Time // When the AI made the decision
+}
+
+// refreshCombatDecisions consults the AI for the active threat on every scan
+// interval, off the movement loop, so its round-trips never stall movement.
+// A failed consultation discards the previous decision so the heuristics
+// take over rather than acting on stale advice.
+func (p *Processor) refreshCombatDecisions() {
+	if !p.AIEnabled() {
+		return
+	}
+
+	ticker := p.clock.NewTicker(scanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C():
+			threat := p.GetActiveThreat()
+			if threat == nil {
+				continue
+			}
+
+			decision, err := p.decisionMaker.MakeCombatDecision(
+				p.ctx,
+				p.getLocation(),
+				threat,
+				p.getHealthStatus(),
+				p.availableWeapons(threat),
+			)
+			if err != nil {
+				p.logger.LogError(err, "AI decision error")
+				p.combatDecisionMu.Lock()
+				p.combatDecision = cachedDecision{}
+				p.combatDecisionMu.Unlock()
+				continue
+			}
+			p.cacheCombatDecision(threat.ID, decision)
+		}
+	}
+}
+
+// engagementDecisions consults the AI on every candidate threat at once,
+// with results in the order of threats. An entry is nil where the AI could
+// not decide, and the result is nil if the AI is disabled.
+func (p *Processor) engagementDecisions(ctx context.Context, threats []*common.Threat) []*ai.CombatDecision {
+	if !p.AIEnabled() || len(threats) == 0 {
+		return nil
+	}
+
+	location, health := p.getLocation(), p.getHealthStatus()
+	situations := make([]ai.Situation, len(threats))
+	for i, threat := range threats {
+		situations[i] = ai.Situation{
+			CurrentLocation:  location,
+			Threat:           threat,
+			HealthStatus:     health,
+			AvailableWeapons: p.availableWeapons(threat),
+		}
+	}
+
+	decisions, err := p.decisionMaker.MakeCombatDecisions(ctx, situations)
+	if err != nil {
+		p.logger.LogError(err, "AI decisions incomplete, using heuristic")
+	}
+	return decisions
+}
+
+// cacheCombatDecision records the AI's latest combat decision for a threat
+// for the movement loop to act on
+func (p *Processor) cacheCombatDecision(threatID string, decision *ai.CombatDecision) {
+	p.combatDecisionMu.Lock()
+	defer p.combatDecisionMu.Unlock()
+	p.combatDecision = cachedDecision{threatID: threatID, decision: decision, at: p.clock.Now()}
+}
+
+// cachedCombatDecision returns the AI's latest decision for a threat, or nil
+// if the AI is disabled, has not yet decided on it or decided longer ago
+// than combatDecisionTTL
+func (p *Processor) cachedCombatDecision(threatID string) *ai.CombatDecision {
+	p.combatDecisionMu.Lock()
+	defer p.combatDecisionMu.Unlock()
+
+	if p.combatDecision.threatID != threatID || p.clock.Now().Sub(p.combatDecision.at) > combatDecisionTTL {
+		return nil
+	}
+	return p.combatDecision.decision
+}
+
+// availableWeapons lists the weapons ready to fire at the threat
+func (p *Processor) availableWeapons(threat *common.Threat) []string {
+	strategies := p.readyWeapons(threat)
+	weapons := make([]string, 0, len(strategies))
+	for _, strategy := range strategies {
+		weapons = append(weapons, strategy.Weapon)
+	}
+	return weapons
+}
@@ -0,0 +1,70 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"t800/internal/ai"
+	"t800/internal/common"
+	"t800/internal/monitoring"
+)
+
+// newTestAI returns a decision maker backed by a stand-in Ollama server that
+// answers each prompt with the text reply returns for it
+func newTestAI(t *testing.T, reply func(prompt string) string) *ai.DecisionMaker {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Prompt string `json:"prompt"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &request)
+		json.NewEncoder(w).Encode(map[string]string{"response": reply(request.Prompt)})
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("OLLAMA_BASE_URL", server.URL)
+
+	decisionMaker, err := ai.NewDecisionMakerWithOptions(monitoring.NewLogger(), ai.Options{MaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("NewDecisionMakerWithOptions: %v", err)
+	}
+	return decisionMaker
+}
+
+func TestEngagementConsultsAIOnEveryCandidate(t *testing.T) {
+	decisionMaker := newTestAI(t, func(prompt string) string {
+		if strings.Contains(prompt, "t2") {
+			return `{"action": "move", "target": "t2", "priority": 6, "confidence": 0.8, "explanation": "close in on t2"}`
+		}
+		return `{"action": "defend", "target": "t1", "priority": 4, "confidence": 0.7, "explanation": "hold against t1"}`
+	})
+
+	cfg := DefaultProcessorConfig()
+	cfg.ModeHysteresis = 0
+	p, _ := newTestProcessorWithConfig(t, cfg, WithDecisionMaker(decisionMaker))
+	activate(p)
+
+	// Both threats are beyond the heuristics' reach, so only the AI engages
+	near := testThreat("t1", 3, common.Location{X: 60})
+	far := testThreat("t2", 3, common.Location{X: 80})
+	p.threats.Add(near)
+	p.threats.Add(far)
+	if err := p.processThreatsWithAI(context.Background(), []*common.Threat{&near, &far}); err != nil {
+		t.Fatalf("processThreatsWithAI: %v", err)
+	}
+
+	if active := p.GetActiveThreat(); active == nil || active.ID != far.ID {
+		t.Fatalf("active threat = %v, want %s engaged on the AI's advice", active, far.ID)
+	}
+	if rationale := p.LastDecisionRationale(); rationale.Source != SourceAI {
+		t.Errorf("decision source = %s, want %s", rationale.Source, SourceAI)
+	}
+	if cached := p.cachedCombatDecision(far.ID); cached == nil || cached.Action != "move" {
+		t.Errorf("cached decision = %+v, want the AI's move for the movement loop", cached)
+	}
+}
@@ -0,0 +1,40 @@
+package processor
+
+import (
+	"fmt"
+
+	"t800/internal/common"
+)
+
+// applyDefenses runs the defensive strategies for each critical part in
+// defense priority order, and each part's strategies in priority order,
+// drawing each action's power usage from the power core. Actions that would
+// exceed the per-threat DefenseBudget or that the core cannot power are
+// skipped, so when power is short the higher priority actions get it first.
+// It returns the power spent.
+func (p *Processor) applyDefenses(threat *common.Threat) float64 {
+	budget := p.config.DefenseBudget
+	spent := 0.0
+
+	for _, part := range p.anatomy.PartsByDefensePriority() {
+		if !part.IsCritical {
+			continue
+		}
+		for _, strategy := range p.defense.GetDefensiveStrategies(part) {
+			cost := strategy.PowerUsage
+			if spent+cost > budget || !p.anatomy.Power.Draw(cost) {
+				p.logger.Info(fmt.Sprintf("Skipping %s on %s: needs %.1f power, %.1f of budget spent",
+					strategy.Description, part.Name, cost, spent))
+				continue
+			}
+			spent += cost
+
+			if err := p.defense.Apply(part, strategy, threat, p.clock.Now()); err != nil {
+				p.logger.LogError(err, "defensive action failed")
+				continue
+			}
+			p.logger.LogDefensiveAction(strategy.Description, part.Name, true)
+		}
+	}
+	return spent
+}
@@ -0,0 +1,79 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/ai"
+	"t800/internal/common"
+)
+
+// boostedParts runs the defenses against a threat with a budget that covers
+// a single boost, with the legs made critical so they compete for it
+func boostedParts(t *testing.T, configure func(p *Processor)) map[string]bool {
+	t.Helper()
+	cfg := DefaultProcessorConfig()
+	cfg.DefenseBudget = 30
+	p, _ := newTestProcessorWithConfig(t, cfg)
+	for _, leg := range p.anatomy.Legs {
+		leg.IsCritical = true
+	}
+	configure(p)
+
+	threat := testThreat("t1", 8, common.Location{X: 20})
+	p.applyDefenses(&threat)
+
+	boosted := make(map[string]bool)
+	for name := range p.defense.BoostStatus() {
+		boosted[name] = true
+	}
+	return boosted
+}
+
+func TestLimitedDefenseBudgetBoostsHeadBeforeLegs(t *testing.T) {
+	boosted := boostedParts(t, func(*Processor) {})
+	if !boosted["head"] {
+		t.Error("head not boosted")
+	}
+	if boosted["leg_left"] || boosted["leg_right"] {
+		t.Errorf("boosted %v, want the legs left without", boosted)
+	}
+}
+
+func TestDefensePriorityOverrideReordersBoosts(t *testing.T) {
+	boosted := boostedParts(t, func(p *Processor) {
+		if err := p.anatomy.SetDefensePriority("leg_left", 10); err != nil {
+			t.Fatalf("SetDefensePriority: %v", err)
+		}
+	})
+	if !boosted["leg_left"] || boosted["head"] {
+		t.Errorf("boosted %v, want the prioritised leg instead of the head", boosted)
+	}
+}
+
+func TestDefendDecisionRaisesDefensesOnce(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	activate(p)
+	defend := func() {
+		p.combatDecision = cachedDecision{
+			threatID: "t1",
+			decision: &ai.CombatDecision{Action: "defend", Target: "t1", Explanation: "hold position"},
+			at:       clock.Now(),
+		}
+		engageActiveThreat(t, p)
+	}
+
+	power := p.anatomy.Power.Level()
+	defend()
+	if len(p.defense.BoostStatus()) == 0 {
+		t.Fatal("defend decision boosted no parts")
+	}
+	raised := p.anatomy.Power.Level()
+	if raised >= power {
+		t.Fatalf("power %.1f after defending, want some drawn from %.1f", raised, power)
+	}
+
+	defend()
+	if level := p.anatomy.Power.Level(); level != raised {
+		t.Errorf("power %.1f after defending again, want %.1f with the boosts still up", level, raised)
+	}
+}
@@ -0,0 +1,41 @@
+package processor
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"t800/internal/common"
+)
+
+// seededRun steps a headless processor seeded with seed through a few scans
+// on its manual clock and returns the threats it tracks and where it ends up
+func seededRun(t *testing.T, seed int64) ([]common.Threat, common.Location) {
+	t.Helper()
+	p, clock := newTestProcessor(t, WithSeed(seed))
+	if err := p.StartHeadless(); err != nil {
+		t.Fatalf("StartHeadless: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		clock.Advance(scanInterval)
+		p.Step(scanInterval)
+	}
+
+	threats := p.threats.List()
+	sort.Slice(threats, func(i, j int) bool { return threats[i].ID < threats[j].ID })
+	return threats, p.getLocation()
+}
+
+func TestSeededScanLoopIsDeterministic(t *testing.T) {
+	first, firstLocation := seededRun(t, 7)
+	second, secondLocation := seededRun(t, 7)
+	if len(first) == 0 {
+		t.Fatal("no threats detected; the run exercises nothing")
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("runs with the same seed tracked\n%+v\nand\n%+v", first, second)
+	}
+	if firstLocation != secondLocation {
+		t.Errorf("runs with the same seed ended at %+v and %+v", firstLocation, secondLocation)
+	}
+}
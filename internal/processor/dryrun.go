@@ -0,0 +1,49 @@
+package processor
+
+import (
+	"fmt"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+// SetDryRun switches attacks to a preview: the engagement logic runs and
+// each weapon is checked for range, power, ammunition and cooldown as
+// usual, but nothing fires. The damage each shot would deal is logged and
+// reported while power, ammunition, heat and the threat are left untouched.
+func (p *Processor) SetDryRun(enabled bool) {
+	p.dryRun.Store(enabled)
+}
+
+// DryRun reports whether attacks are only previewed
+func (p *Processor) DryRun() bool {
+	return p.dryRun.Load()
+}
+
+// previewFire reports the damage a weapon that passed the range, arc, line
+// of sight, heat and cooldown checks would deal, if the robot has the power
+// and ammunition to fire it, without firing
+func (p *Processor) previewFire(part *anatomy.BodyPart, strategy offense.AttackStrategy, missile offense.MissileType, threat *common.Threat) (float64, bool) {
+	if p.anatomy.Power.Level() < strategy.PowerUsage {
+		p.logger.Info(fmt.Sprintf("Dry run: insufficient power for %s (needs %.1f, have %.1f)",
+			strategy.Weapon, strategy.PowerUsage, p.anatomy.Power.Level()))
+		return 0, false
+	}
+	if rounds, limited := p.offense.Ammo(offense.AmmoPool(strategy.Weapon, missile)); limited && rounds <= 0 {
+		p.logger.Info(fmt.Sprintf("Dry run: %s out of ammunition", strategy.Weapon))
+		return 0, false
+	}
+	if err := strategy.Action(part, threat); err != nil {
+		p.logger.LogError(err, "dry run offensive action failed")
+		return 0, false
+	}
+
+	distance := common.CalculateDistance(p.getLocation(), threat.Location)
+	damage := p.strikeDamage(strategy, threat, distance)
+	if payload, isMissile := p.offense.Payload(missile); isMissile {
+		damage *= payload.DamageFraction
+	}
+	p.logger.Info(fmt.Sprintf("Dry run: %s from %s would deal %.2f damage to %s", strategy.Weapon, part.Name, damage, threat.ID))
+	return damage, true
+}
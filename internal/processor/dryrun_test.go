@@ -0,0 +1,60 @@
+package processor
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestDryRunAttackReportsDamageWithoutSideEffects(t *testing.T) {
+	p, clock := newTestProcessor(t, WithDryRun())
+	var out bytes.Buffer
+	p.logger.SetOutput(&out)
+
+	threat := testThreat("t1", 5, common.Location{X: 30})
+	threat.Health = 10_000
+	p.AddThreat(threat)
+	p.escalate(threat.ID, EscalationFullEngagement)
+	p.status.active = true
+	p.config.PartialVolley = false
+
+	ammo, heat, power := p.offense.AmmoStatus(), p.offense.HeatStatus(), p.anatomy.Power.Level()
+	predicted := p.executeCoordinatedAttack(&threat)
+	if predicted <= 0 {
+		t.Fatalf("dry run predicted %.2f damage, want some", predicted)
+	}
+	if !strings.Contains(out.String(), "Dry run: plasma_cannon") {
+		t.Errorf("dry run did not log the predicted shots:\n%s", out.String())
+	}
+
+	if got := p.offense.AmmoStatus(); !reflect.DeepEqual(got, ammo) {
+		t.Errorf("ammo after dry run = %v, want %v", got, ammo)
+	}
+	if got := p.offense.HeatStatus(); !reflect.DeepEqual(got, heat) {
+		t.Errorf("heat after dry run = %v, want %v", got, heat)
+	}
+	if got := p.anatomy.Power.Level(); got != power {
+		t.Errorf("power after dry run = %.2f, want %.2f", got, power)
+	}
+	if stored, _ := p.threats.Get(threat.ID); stored.Health != 10_000 {
+		t.Errorf("threat health after dry run = %.2f, want untouched", stored.Health)
+	}
+	plasma, _, _ := p.offense.Strategy("plasma_cannon")
+	for _, arm := range p.anatomy.Arms {
+		if p.offense.CooldownRemaining(arm, plasma, clock.Now()) > 0 {
+			t.Errorf("%s is cooling down after a dry run", arm.Name)
+		}
+	}
+
+	p.SetDryRun(false)
+	live := threat
+	if dealt := p.executeCoordinatedAttack(&live); dealt <= 0 {
+		t.Fatal("live volley dealt no damage")
+	}
+	if got := p.anatomy.Power.Level(); got >= power {
+		t.Errorf("power after a live volley = %.2f, want below %.2f", got, power)
+	}
+}
@@ -0,0 +1,95 @@
+package processor
+
+import (
+	"fmt"
+
+	"t800/internal/common"
+	"t800/internal/defense"
+	"t800/internal/events"
+	"t800/internal/monitoring"
+)
+
+const (
+	// emergencyThreshold is the health percentage of a critical part below
+	// which the processor enters emergency mode
+	emergencyThreshold = 20.0
+	// emergencyRecoveryMargin is how far above the threshold every critical
+	// part must recover before emergency mode ends, so it does not flap
+	emergencyRecoveryMargin = 10.0
+)
+
+// lowestCriticalHealth returns the health of the most damaged critical part
+func (p *Processor) lowestCriticalHealth() float64 {
+	lowest := 100.0
+	for _, part := range p.anatomy.GetCriticalParts() {
+		lowest = min(lowest, part.GetHealth())
+	}
+	return lowest
+}
+
+// checkEmergency enters emergency mode when a critical part fails and
+// returns to the prior mode once every critical part has recovered
+func (p *Processor) checkEmergency() {
+	lowest := p.lowestCriticalHealth()
+	switch emergency := p.getMode() == common.Emergency; {
+	case !emergency && lowest < emergencyThreshold:
+		p.enterEmergency(lowest)
+	case emergency && lowest >= emergencyThreshold+emergencyRecoveryMargin:
+		p.exitEmergency()
+	}
+}
+
+// enterEmergency switches to emergency mode, remembering the mode to resume,
+// and shields every critical part
+func (p *Processor) enterEmergency(lowest float64) {
+	p.status.mu.Lock()
+	previous := p.status.Mode
+	p.status.resumeMode = previous
+	p.status.Mode = common.Emergency
+	p.status.mu.Unlock()
+
+	p.logger.Info(fmt.Sprintf("Critical part at %.2f%% health, entering emergency mode", lowest))
+	p.modeChanged(previous, common.Emergency)
+	p.shieldCriticalParts()
+}
+
+// shieldCriticalParts applies emergency shielding to every critical part in
+// defense priority order while power lasts
+func (p *Processor) shieldCriticalParts() {
+	strategy := defense.EmergencyShielding()
+	for _, part := range p.anatomy.PartsByDefensePriority() {
+		if !part.IsCritical {
+			continue
+		}
+		if !p.anatomy.Power.Draw(strategy.PowerUsage) {
+			p.logger.Info(fmt.Sprintf("Insufficient power to shield %s", part.Name))
+			return
+		}
+		if err := p.defense.Apply(part, strategy, nil, p.clock.Now()); err != nil {
+			p.logger.LogError(err, "defensive action failed")
+			continue
+		}
+		p.logger.LogDefensiveAction(strategy.Description, part.Name, true)
+	}
+}
+
+// exitEmergency returns to the mode that emergency interrupted, or the last
+// mode requested during the emergency
+func (p *Processor) exitEmergency() {
+	p.status.mu.Lock()
+	mode := p.status.resumeMode
+	p.status.Mode = mode
+	p.status.mu.Unlock()
+
+	p.logger.Info(fmt.Sprintf("Critical parts recovered, leaving emergency mode for %s", mode))
+	p.modeChanged(common.Emergency, mode)
+}
+
+// modeChanged notes a mode transition
+func (p *Processor) modeChanged(previous, mode common.OperationMode) {
+	if previous != mode {
+		p.noteActivity()
+		p.events.Publish(events.Event{Type: events.ModeChanged, Mode: mode})
+		p.logger.RecordCombat(p.clock.Now(), monitoring.CombatModeChange, monitoring.CombatPayload{Mode: mode.String()})
+	}
+}
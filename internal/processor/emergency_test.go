@@ -0,0 +1,42 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestEmergencyFlipsAndRecoversWithHysteresis(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	p.setMode(common.Combat)
+
+	p.anatomy.Head.Expose(85)
+	p.checkEmergency()
+	if mode := p.getMode(); mode != common.Emergency {
+		t.Fatalf("mode with the head at 15%% = %v, want emergency", mode)
+	}
+
+	// Back above the threshold but within the recovery margin
+	p.anatomy.Head.Heal(10)
+	p.checkEmergency()
+	if mode := p.getMode(); mode != common.Emergency {
+		t.Fatalf("mode with the head at 25%% = %v, want emergency to hold", mode)
+	}
+
+	p.anatomy.Head.Heal(10)
+	p.checkEmergency()
+	if mode := p.getMode(); mode != common.Combat {
+		t.Errorf("mode with the head at 35%% = %v, want combat resumed", mode)
+	}
+}
+
+func TestEmergencyShieldsCriticalParts(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	shield := p.anatomy.Body.Protection().ShieldStrength
+
+	p.anatomy.Head.Expose(90)
+	p.checkEmergency()
+	if got := p.anatomy.Body.Protection().ShieldStrength; got <= shield {
+		t.Errorf("body shield in emergency = %.1f, want boosted above %.1f", got, shield)
+	}
+}
@@ -0,0 +1,104 @@
+package processor
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// roundsPerEngagement is the typical ammunition spent per limited weapon in one engagement
+	roundsPerEngagement = 2
+	// damagePerEngagement is the typical health lost by a critical part in one engagement
+	damagePerEngagement = 20.0
+)
+
+// Resources that can limit combat endurance
+const (
+	ResourcePower  = "power"
+	ResourceAmmo   = "ammo"
+	ResourceHealth = "health"
+)
+
+// EnduranceReport is a high-level estimate of how much more fighting the robot can sustain
+type EnduranceReport struct {
+	EngagementsRemaining int
+	LimitingResource     string
+	TimeToRecovery       time.Duration
+	PowerEngagements     float64
+	AmmoEngagements      float64
+	HealthEngagements    float64
+}
+
+// CombatEndurance estimates how many more engagements the robot can sustain
+// given its power, ammunition and health, and which of them runs out first
+func (p *Processor) CombatEndurance() EnduranceReport {
+	report := EnduranceReport{
+		PowerEngagements:  p.powerEngagements(),
+		AmmoEngagements:   p.ammoEngagements(),
+		HealthEngagements: p.healthEngagements(),
+	}
+
+	report.LimitingResource = ResourcePower
+	lowest := report.PowerEngagements
+	if report.AmmoEngagements < lowest {
+		report.LimitingResource = ResourceAmmo
+		lowest = report.AmmoEngagements
+	}
+	if report.HealthEngagements < lowest {
+		report.LimitingResource = ResourceHealth
+		lowest = report.HealthEngagements
+	}
+	report.EngagementsRemaining = int(math.Floor(lowest))
+	report.TimeToRecovery = p.timeToRecovery()
+	return report
+}
+
+// powerEngagements estimates engagements left assuming one full volley each
+func (p *Processor) powerEngagements() float64 {
+	var volleyCost float64
+	for _, strategy := range p.offense.AllStrategies() {
+		volleyCost += strategy.PowerUsage
+	}
+	if volleyCost == 0 {
+		return math.Inf(1)
+	}
+	return p.anatomy.Power.Level() / volleyCost
+}
+
+// ammoEngagements estimates engagements left before any limited weapon, with
+// missiles of every type counted together, runs dry
+func (p *Processor) ammoEngagements() float64 {
+	engagements := math.Inf(1)
+	for _, strategy := range p.offense.AllStrategies() {
+		if rounds, limited := p.offense.Ammo(strategy.Weapon); limited {
+			engagements = min(engagements, float64(rounds)/roundsPerEngagement)
+		}
+	}
+	return engagements
+}
+
+// healthEngagements estimates engagements left before the weakest critical part fails
+func (p *Processor) healthEngagements() float64 {
+	engagements := math.Inf(1)
+	for _, part := range p.anatomy.GetCriticalParts() {
+		engagements = min(engagements, part.GetHealth()/damagePerEngagement)
+	}
+	return engagements
+}
+
+// timeToRecovery returns how long until power and health are fully restored.
+// Ammunition does not recover on its own and is not included.
+func (p *Processor) timeToRecovery() time.Duration {
+	seconds := p.anatomy.Power.TimeToFull()
+	for _, part := range p.anatomy.Parts {
+		if t := part.TimeToFullHealth(); t < 0 || seconds < 0 {
+			seconds = -1
+		} else {
+			seconds = max(seconds, t)
+		}
+	}
+	if seconds < 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
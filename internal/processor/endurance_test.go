@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"t800/internal/anatomy"
+	"t800/internal/offense"
+)
+
+func TestEnduranceLimitedByDepletedAmmo(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	if report := p.CombatEndurance(); report.LimitingResource == ResourceAmmo {
+		t.Fatalf("fresh robot limited by ammo: %+v", report)
+	}
+
+	for _, missile := range offense.MissileTypes {
+		p.offense.SetAmmo(offense.AmmoPool(offense.MissileWeapon, missile), 0)
+	}
+	report := p.CombatEndurance()
+	if report.LimitingResource != ResourceAmmo {
+		t.Errorf("limiting resource = %s, want %s", report.LimitingResource, ResourceAmmo)
+	}
+	if report.EngagementsRemaining != 0 {
+		t.Errorf("engagements remaining = %d, want 0", report.EngagementsRemaining)
+	}
+}
+
+func TestTimeToRecoveryWaitsForSlowestPart(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	if recovery := p.CombatEndurance().TimeToRecovery; recovery != 0 {
+		t.Fatalf("time to recovery = %v for an undamaged robot, want 0", recovery)
+	}
+
+	p.anatomy.Arms[0].Expose(50)
+	p.anatomy.Head.Expose(50)
+	want := 0.0
+	for _, part := range []*anatomy.BodyPart{p.anatomy.Arms[0], p.anatomy.Head} {
+		want = max(want, 50/(part.RegenRate()*100))
+	}
+	recovery := p.CombatEndurance().TimeToRecovery
+	if math.Abs(recovery.Seconds()-want) > 1e-6 {
+		t.Errorf("time to recovery = %v, want %.1fs for the slowest part", recovery, want)
+	}
+
+	p.anatomy.Legs[0].Expose(100)
+	if recovery := p.CombatEndurance().TimeToRecovery; recovery != time.Duration(math.MaxInt64) {
+		t.Errorf("time to recovery with a destroyed leg = %v, want never", recovery)
+	}
+}
@@ -0,0 +1,33 @@
+package processor
+
+// falloffFraction is the fraction of a weapon's maximum range at which its
+// effectiveness starts to drop off
+const falloffFraction = 0.75
+
+// EnvelopeRing describes the concentric range rings of a single weapon
+type EnvelopeRing struct {
+	Weapon       string
+	Min          float64
+	Optimal      float64
+	FalloffStart float64
+	Max          float64
+}
+
+// EngagementEnvelope returns the range rings of every weapon in the current
+// loadout, suitable for drawing weapon coverage around the robot
+func (p *Processor) EngagementEnvelope() []EnvelopeRing {
+	strategies := p.offense.AllStrategies()
+	rings := make([]EnvelopeRing, 0, len(strategies))
+	for _, strategy := range strategies {
+		falloff := strategy.Range * falloffFraction
+		optimal := min(max(p.engagementDistance, strategy.MinRange), falloff)
+		rings = append(rings, EnvelopeRing{
+			Weapon:       strategy.Weapon,
+			Min:          strategy.MinRange,
+			Optimal:      optimal,
+			FalloffStart: falloff,
+			Max:          strategy.Range,
+		})
+	}
+	return rings
+}
@@ -0,0 +1,34 @@
+package processor
+
+import "testing"
+
+func TestEnvelopeMatchesWeaponRanges(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	want := map[string]struct{ min, max float64 }{
+		"plasma_cannon": {0, 50},
+		"missile":       {10, 100},
+		"emp_pulse":     {0, 30},
+		"laser_beam":    {0, 40},
+	}
+
+	rings := p.EngagementEnvelope()
+	if len(rings) != len(want) {
+		t.Fatalf("got %d rings, want %d", len(rings), len(want))
+	}
+	for _, ring := range rings {
+		expected, exists := want[ring.Weapon]
+		if !exists {
+			t.Errorf("unexpected ring for %s", ring.Weapon)
+			continue
+		}
+		if ring.Min != expected.min || ring.Max != expected.max {
+			t.Errorf("%s ring spans %v-%v, want %v-%v", ring.Weapon, ring.Min, ring.Max, expected.min, expected.max)
+		}
+		if ring.FalloffStart != ring.Max*falloffFraction {
+			t.Errorf("%s falloff starts at %v, want %v", ring.Weapon, ring.FalloffStart, ring.Max*falloffFraction)
+		}
+		if ring.Optimal < ring.Min || ring.Optimal > ring.FalloffStart {
+			t.Errorf("%s optimal range %v outside %v-%v", ring.Weapon, ring.Optimal, ring.Min, ring.FalloffStart)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package processor
+
+import (
+	"fmt"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+	"t800/internal/events"
+	"t800/internal/monitoring"
+)
+
+// EscalationLevel is a rung on the retaliation ladder for a single threat
+type EscalationLevel int
+
+const (
+	EscalationObserve EscalationLevel = iota
+	EscalationWarn
+	EscalationDefensiveFire
+	EscalationFullEngagement
+)
+
+// String returns a human readable name for the escalation level
+func (l EscalationLevel) String() string {
+	switch l {
+	case EscalationObserve:
+		return "observe"
+	case EscalationWarn:
+		return "warn"
+	case EscalationDefensiveFire:
+		return "defensive_fire"
+	case EscalationFullEngagement:
+		return "full_engagement"
+	default:
+		return fmt.Sprintf("escalation(%d)", int(l))
+	}
+}
+
+// escalationLevel returns the current rung for a threat; unknown threats are observed
+func (p *Processor) escalationLevel(threatID string) EscalationLevel {
+	p.escalationMu.Lock()
+	defer p.escalationMu.Unlock()
+	return p.escalation[threatID]
+}
+
+// escalate raises a threat to at least the given rung; the ladder never steps down
+func (p *Processor) escalate(threatID string, level EscalationLevel) {
+	p.escalationMu.Lock()
+	current := p.escalation[threatID]
+	if level > current {
+		p.escalation[threatID] = level
+	}
+	p.escalationMu.Unlock()
+
+	if level > current {
+		p.logger.Info(fmt.Sprintf("Escalating response to %s: %s -> %s", threatID, current, level))
+	}
+}
+
+// forgetEscalation drops a threat's rung once the threat is gone
+func (p *Processor) forgetEscalation(threatID string) {
+	p.escalationMu.Lock()
+	defer p.escalationMu.Unlock()
+	delete(p.escalation, threatID)
+}
+
+// ReportDamage applies a hit from a threat to the named part and escalates
+// the response to that threat. If partName is empty the part is chosen by
+// the configured hit policy. Part of the impact carries through to the parts
+// connected to the one hit. Being fired upon always justifies at least
+// defensive fire; repeated hits advance the ladder to full engagement. The
+// command is checkpointed so it can be undone.
+func (p *Processor) ReportDamage(threatID string, partName string, impact float64) error {
+	return p.command(func() error { return p.reportDamage(threatID, partName, impact) })
+}
+
+// reportDamage applies a reported hit without checkpointing
+func (p *Processor) reportDamage(threatID string, partName string, impact float64) error {
+	var part *anatomy.BodyPart
+	if partName == "" {
+		var threatDir common.Location
+		if threat, ok := p.threats.Get(threatID); ok {
+			threatDir = threat.Location.Sub(p.getLocation())
+		}
+		part = p.anatomy.SelectHitPart(p.config.HitPolicy, threatDir.Rotate(-p.Heading()))
+	} else {
+		var err error
+		if part, err = p.anatomy.GetPart(partName); err != nil {
+			return err
+		}
+	}
+
+	damage, hit, err := p.anatomy.ApplyDamageWithPropagation(part.Name, impact)
+	if err != nil {
+		return err
+	}
+	if hit.Critical {
+		p.logger.Info(fmt.Sprintf("Critical hit from %s on %s %s (x%.2f)", threatID, part.Name, hit.WeakPoint, hit.Multiplier))
+	}
+	if damage[part.Name] == 0 && impact > 0 {
+		p.logger.Info(fmt.Sprintf("Hit from %s absorbed by %s protection (Impact: %.2f)", threatID, part.Name, impact))
+	} else {
+		p.notePartDamage(threatID, part, damage[part.Name])
+	}
+	for _, connected := range p.anatomy.ConnectedParts(part.Name) {
+		if damage[connected.Name] > 0 {
+			p.notePartDamage(threatID, connected, damage[connected.Name])
+		}
+	}
+
+	next := p.escalationLevel(threatID) + 1
+	if next < EscalationDefensiveFire {
+		next = EscalationDefensiveFire
+	}
+	p.escalate(threatID, min(next, EscalationFullEngagement))
+	return nil
+}
+
+// notePartDamage logs and publishes health lost by a part to a threat
+func (p *Processor) notePartDamage(threatID string, part *anatomy.BodyPart, damage float64) {
+	p.logger.Info(fmt.Sprintf("%s took %.2f damage from %s (Health: %.2f%%)", part.Name, damage, threatID, part.GetHealth()))
+	p.events.Publish(events.Event{
+		Type:     events.PartDamaged,
+		ThreatID: threatID,
+		Part:     part.Name,
+		Value:    damage,
+		Health:   part.GetHealth(),
+	})
+	if part.IsDisabled() {
+		p.logger.Warn(fmt.Sprintf("%s disabled by %s", part.Name, threatID))
+		p.logger.RecordCombat(p.clock.Now(), monitoring.CombatPartDisabled, monitoring.CombatPayload{
+			ThreatID: threatID,
+			Part:     part.Name,
+			Value:    damage,
+		})
+	}
+}
+
+// proportionateWeapon returns the least powerful weapon ready to fire at the
+// threat, used when the escalation ladder only permits defensive fire, or ""
+// if none is ready
+func (p *Processor) proportionateWeapon(threat *common.Threat) string {
+	var weapon string
+	lowest := -1.0
+	for _, strategy := range p.readyWeapons(threat) {
+		if lowest < 0 || strategy.PowerUsage < lowest {
+			lowest = strategy.PowerUsage
+			weapon = strategy.Weapon
+		}
+	}
+	return weapon
+}
+
+// permittedWeapon applies the escalation ladder to a requested weapon,
+// returning the weapon that may be fired and whether firing is allowed
+func (p *Processor) permittedWeapon(threat *common.Threat, weapon string) (string, bool) {
+	switch level := p.escalationLevel(threat.ID); {
+	case level < EscalationDefensiveFire:
+		p.logger.Info(fmt.Sprintf("Holding fire on %s (escalation: %s)", threat.ID, level))
+		return "", false
+	case level == EscalationDefensiveFire:
+		weapon := p.proportionateWeapon(threat)
+		return weapon, weapon != ""
+	default:
+		return weapon, true
+	}
+}
@@ -0,0 +1,50 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestDamageEscalatesToDefensiveFire(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	p.AddThreat(testThreat("t1", 5, common.Location{X: 30}))
+
+	if level := p.escalationLevel("t1"); level != EscalationObserve {
+		t.Fatalf("initial escalation = %s, want observe", level)
+	}
+	if err := p.ReportDamage("t1", "body", 40); err != nil {
+		t.Fatalf("ReportDamage: %v", err)
+	}
+	if level := p.escalationLevel("t1"); level != EscalationDefensiveFire {
+		t.Errorf("escalation after a hit = %s, want defensive_fire", level)
+	}
+	if err := p.ReportDamage("t1", "body", 40); err != nil {
+		t.Fatalf("ReportDamage: %v", err)
+	}
+	if level := p.escalationLevel("t1"); level != EscalationFullEngagement {
+		t.Errorf("escalation after a second hit = %s, want full_engagement", level)
+	}
+}
+
+func TestEscalationForgottenWhenThreatGone(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	for _, id := range []string{"removed", "eliminated"} {
+		p.AddThreat(testThreat(id, 5, common.Location{X: 30}))
+		p.escalate(id, EscalationFullEngagement)
+	}
+
+	p.RemoveThreat("removed")
+	eliminated, _ := p.threats.Get("eliminated")
+	p.eliminateThreat(&eliminated)
+
+	p.escalationMu.Lock()
+	remaining := len(p.escalation)
+	p.escalationMu.Unlock()
+	if remaining != 0 {
+		t.Errorf("%d escalation entries left after the threats were gone, want 0", remaining)
+	}
+	if level := p.escalationLevel("removed"); level != EscalationObserve {
+		t.Errorf("removed threat escalation = %s, want observe", level)
+	}
+}
@@ -0,0 +1,62 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"t800/internal/common"
+	"t800/internal/events"
+)
+
+func TestReportedThreatPublishesModeChanged(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	activate(p)
+
+	received := make(chan events.Event, 64)
+	unsubscribe := p.SubscribeFunc(func(event events.Event) {
+		received <- event
+	}, events.WithBuffer(64))
+	defer unsubscribe()
+
+	if err := p.ReportThreat(testThreat("t1", 7, common.Location{X: 30})); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-received:
+			if event.Type == events.ModeChanged {
+				if event.Mode != common.Combat {
+					t.Errorf("ModeChanged to %v, want combat", event.Mode)
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("no ModeChanged event after reporting a threat")
+		}
+	}
+}
+
+func TestStuckHandlerDoesNotBlockProcessor(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	activate(p)
+
+	release := make(chan struct{})
+	defer close(release)
+	unsubscribe := p.SubscribeFunc(func(events.Event) { <-release }, events.WithBuffer(1))
+	defer unsubscribe()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.ReportThreat(testThreat("t1", 7, common.Location{X: 30}))
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("ReportThreat: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ReportThreat blocked behind a stuck event handler")
+	}
+}
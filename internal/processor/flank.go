@@ -0,0 +1,82 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"t800/internal/common"
+)
+
+// threatBearings returns the bearing in radians, counterclockwise from the X
+// axis, from the robot to every live threat, sorted ascending in [0, 2π)
+func (p *Processor) threatBearings() []float64 {
+	location := p.getLocation()
+	var bearings []float64
+	for _, threat := range p.threats.List() {
+		if threat.Health <= 0 {
+			continue
+		}
+		dx, dy := threat.Location.X-location.X, threat.Location.Y-location.Y
+		if dx == 0 && dy == 0 {
+			continue
+		}
+		bearing := math.Atan2(dy, dx)
+		if bearing < 0 {
+			bearing += 2 * math.Pi
+		}
+		bearings = append(bearings, bearing)
+	}
+	sort.Float64s(bearings)
+	return bearings
+}
+
+// largestGap returns the widest empty arc between consecutive bearings and
+// the bearing at its middle
+func largestGap(bearings []float64) (width, middle float64) {
+	for i, bearing := range bearings {
+		next := bearings[(i+1)%len(bearings)]
+		gap := next - bearing
+		if gap <= 0 {
+			gap += 2 * math.Pi
+		}
+		if gap > width {
+			width, middle = gap, math.Mod(bearing+gap/2, 2*math.Pi)
+		}
+	}
+	return width, middle
+}
+
+// isFlanked reports whether two or more live threats surround the robot
+// across an arc at least as wide as the configured flank spread
+func (p *Processor) isFlanked() bool {
+	bearings := p.threatBearings()
+	if len(bearings) < 2 {
+		return false
+	}
+	gap, _ := largestGap(bearings)
+	return 2*math.Pi-gap >= p.config.FlankSpread
+}
+
+// respondToFlanking backs toward cover from the primary threat if any is
+// available, and otherwise moves into the widest gap between the threats so
+// that they fall within a narrower arc in front of the robot
+func (p *Processor) respondToFlanking() {
+	if threat := p.GetActiveThreat(); threat != nil {
+		if cover, found := p.findCover(threat); found {
+			p.logger.Info("Flanked, falling back to cover")
+			p.moveTowardsTarget(cover)
+			return
+		}
+	}
+
+	_, heading := largestGap(p.threatBearings())
+	location, speed := p.getLocation(), p.effectiveSpeed()
+	target := common.Location{
+		X: location.X + math.Cos(heading)*speed,
+		Y: location.Y + math.Sin(heading)*speed,
+		Z: location.Z,
+	}
+	p.logger.Info(fmt.Sprintf("Flanked, repositioning along bearing %.2f radians", heading))
+	p.moveTowardsTarget(target)
+}
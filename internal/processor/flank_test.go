@@ -0,0 +1,40 @@
+package processor
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestFlankedOnlyByThreatsOnOppositeSides(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		second  common.Location
+		flanked bool
+	}{
+		{"opposite", common.Location{X: -20}, true},
+		{"adjacent", common.Location{X: 20, Y: 5}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newTestProcessor(t)
+			p.AddThreat(testThreat("t1", 5, common.Location{X: 20}))
+			p.AddThreat(testThreat("t2", 5, tc.second))
+			if flanked := p.isFlanked(); flanked != tc.flanked {
+				t.Errorf("isFlanked = %v, want %v", flanked, tc.flanked)
+			}
+		})
+	}
+}
+
+func TestFlankedRobotMovesIntoGap(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	p.AddThreat(testThreat("t1", 5, common.Location{X: 20}))
+	p.AddThreat(testThreat("t2", 5, common.Location{X: -20}))
+
+	p.respondToFlanking()
+	moved := p.getLocation()
+	if moved.Y == 0 || math.Abs(moved.X) > 1e-9 {
+		t.Errorf("robot moved to %+v, want sideways out from between the threats", moved)
+	}
+}
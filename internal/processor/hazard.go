@@ -0,0 +1,108 @@
+package processor
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+// Hazard is a spherical region, such as a fire or radiation field, that
+// damages the robot continuously while it is inside
+type Hazard struct {
+	Name            string
+	Center          common.Location
+	Radius          float64
+	DamagePerSecond float64            // Health lost per second by each affected part
+	PartTypes       []anatomy.PartType // Parts affected; empty affects every part
+
+	ThreatDamagePerSecond float64   // Health lost per second by threats inside; zero spares them
+	Expires               time.Time // When the hazard dies out; zero lasts forever
+}
+
+// Contains reports whether a location lies within the hazard
+func (h Hazard) Contains(loc common.Location) bool {
+	return common.CalculateDistance(h.Center, loc) <= h.Radius
+}
+
+// affects reports whether the hazard damages parts of the given type
+func (h Hazard) affects(partType anatomy.PartType) bool {
+	return len(h.PartTypes) == 0 || slices.Contains(h.PartTypes, partType)
+}
+
+// expired reports whether the hazard has died out by the given time
+func (h Hazard) expired(now time.Time) bool {
+	return !h.Expires.IsZero() && !now.Before(h.Expires)
+}
+
+// SetHazards replaces the hazards that damage the robot while it is inside them
+func (p *Processor) SetHazards(hazards []Hazard) {
+	p.hazardsMu.Lock()
+	defer p.hazardsMu.Unlock()
+	p.hazards = append([]Hazard(nil), hazards...)
+}
+
+// addHazard adds a hazard to those already present
+func (p *Processor) addHazard(hazard Hazard) {
+	p.hazardsMu.Lock()
+	defer p.hazardsMu.Unlock()
+	p.hazards = append(p.hazards, hazard)
+}
+
+// hazardTick drops hazards that have died out, then damages the parts
+// exposed to every hazard the robot is inside and the threats inside those
+// that burn threats, for the time since the last tick measured on the
+// processor's clock. With a clock that has not moved, such as a headless run
+// that does not advance it, the tick's nominal elapsed time is used instead.
+func (p *Processor) hazardTick(elapsed time.Duration) {
+	now := p.clock.Now()
+	p.hazardsMu.Lock()
+	if !p.lastHazardTick.IsZero() && now.After(p.lastHazardTick) {
+		elapsed = now.Sub(p.lastHazardTick)
+	}
+	p.lastHazardTick = now
+	active := p.hazards[:0:0]
+	for _, hazard := range p.hazards {
+		if !hazard.expired(now) {
+			active = append(active, hazard)
+		}
+	}
+	p.hazards = active
+	p.hazardsMu.Unlock()
+
+	location := p.getLocation()
+	for _, hazard := range active {
+		if hazard.ThreatDamagePerSecond > 0 {
+			p.burnThreats(hazard, hazard.ThreatDamagePerSecond*elapsed.Seconds())
+		}
+		if !hazard.Contains(location) {
+			continue
+		}
+		damage := hazard.DamagePerSecond * elapsed.Seconds()
+		for _, part := range p.anatomy.PartsByDefensePriority() {
+			if !hazard.affects(part.Type) {
+				continue
+			}
+			if lost := part.Expose(damage); lost > 0 {
+				p.logger.Info(fmt.Sprintf("Hazard %s damaged %s by %.2f (Health: %.2f%%)", hazard.Name, part.Name, lost, part.GetHealth()))
+			}
+		}
+	}
+}
+
+// burnThreats damages every live threat inside a hazard, eliminating those
+// it destroys
+func (p *Processor) burnThreats(hazard Hazard, damage float64) {
+	for _, stored := range p.threats.List() {
+		if stored.Health <= 0 || !hazard.Contains(stored.Location) {
+			continue
+		}
+		threat := stored
+		p.applyDamage(&threat, "", hazard.Name, damage)
+		if threat.Health <= 0 {
+			p.eliminateThreat(&threat)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package processor
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+func TestHazardDamagesRobotInsideAtConfiguredRate(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	p.SetHazards([]Hazard{
+		{Name: "fire", Center: common.Location{X: 2}, Radius: 5, DamagePerSecond: 4, PartTypes: []anatomy.PartType{anatomy.Leg}},
+		{Name: "radiation", Center: common.Location{X: 50}, Radius: 5, DamagePerSecond: 50},
+	})
+
+	p.hazardTick(time.Second)
+	clock.Advance(2500 * time.Millisecond)
+	p.hazardTick(time.Second)
+
+	for _, leg := range p.anatomy.Legs {
+		if health := leg.GetHealth(); math.Abs(health-86) > 1e-9 {
+			t.Errorf("%s health after 3.5s in the fire = %.2f, want 86", leg.Name, health)
+		}
+	}
+	for _, part := range []*anatomy.BodyPart{p.anatomy.Head, p.anatomy.Body, p.anatomy.Arms[0]} {
+		if health := part.GetHealth(); health != 100 {
+			t.Errorf("unaffected %s health = %.2f, want 100", part.Name, health)
+		}
+	}
+
+	// Out of the fire the robot takes no more damage
+	p.setLocation(common.Location{X: 20})
+	clock.Advance(time.Second)
+	p.hazardTick(time.Second)
+	if health := p.anatomy.Legs[0].GetHealth(); math.Abs(health-86) > 1e-9 {
+		t.Errorf("leg health outside every hazard = %.2f, want 86", health)
+	}
+}
+
+func TestHazardBurnsThreatsUntilItDiesOut(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	inside := testThreat("inside", 5, common.Location{X: 40})
+	outside := testThreat("outside", 5, common.Location{X: 80})
+	p.AddThreat(inside)
+	p.AddThreat(outside)
+	p.SetHazards([]Hazard{{
+		Name:                  "napalm",
+		Center:                common.Location{X: 40},
+		Radius:                3,
+		ThreatDamagePerSecond: 60,
+		Expires:               clock.Now().Add(1500 * time.Millisecond),
+	}})
+
+	p.hazardTick(time.Second)
+	if stored, _ := p.threats.Get("inside"); stored.Health != 40 {
+		t.Errorf("threat in the hazard has %.2f health, want 40", stored.Health)
+	}
+	if stored, _ := p.threats.Get("outside"); stored.Health != 100 {
+		t.Errorf("threat outside the hazard has %.2f health, want 100", stored.Health)
+	}
+
+	clock.Advance(time.Second)
+	p.hazardTick(time.Second)
+	if _, exists := p.threats.Get("inside"); exists {
+		t.Error("threat burned to nothing is still tracked")
+	}
+
+	clock.Advance(time.Second)
+	p.hazardTick(time.Second)
+	p.hazardsMu.Lock()
+	remaining := len(p.hazards)
+	p.hazardsMu.Unlock()
+	if remaining != 0 {
+		t.Errorf("%d hazards remain after dying out", remaining)
+	}
+}
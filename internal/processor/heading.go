@@ -0,0 +1,64 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+// Heading returns the robot's facing in radians, counterclockwise from the X axis
+func (p *Processor) Heading() float64 {
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
+	return p.heading
+}
+
+// setHeading sets the robot's facing in radians
+func (p *Processor) setHeading(heading float64) {
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+	p.heading = common.NormalizeAngle(heading)
+}
+
+// turnTowards rotates the robot toward a target for one 100ms movement
+// update at its angular speed and reports whether it now faces the target
+func (p *Processor) turnTowards(target common.Location) bool {
+	deltaTime := 0.1 // 100ms movement update
+	maxTurn := p.getSpeed().Angular * deltaTime
+
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+
+	if target.X == p.location.X && target.Y == p.location.Y {
+		return true
+	}
+	turn := common.TurnAngle(p.heading, common.Bearing(p.location, target))
+	facing := math.Abs(turn) <= maxTurn
+	if !facing {
+		turn = math.Copysign(maxTurn, turn)
+	}
+	p.heading = common.NormalizeAngle(p.heading + turn)
+	return facing
+}
+
+// withinFiringArc reports whether a threat lies within a weapon's firing
+// arc about the robot's heading; weapons without an arc fire in any direction
+func (p *Processor) withinFiringArc(strategy offense.AttackStrategy, threat *common.Threat) bool {
+	if strategy.FiringArc <= 0 {
+		return true
+	}
+	offset := common.TurnAngle(p.Heading(), common.Bearing(p.getLocation(), threat.Location))
+	return math.Abs(offset) <= strategy.FiringArc/2
+}
+
+// holdFireOutsideArc logs and reports whether a weapon must hold fire
+// because the threat is outside its firing arc
+func (p *Processor) holdFireOutsideArc(strategy offense.AttackStrategy, threat *common.Threat) bool {
+	if p.withinFiringArc(strategy, threat) {
+		return false
+	}
+	p.logger.Info(fmt.Sprintf("Holding %s: %s is outside its firing arc", strategy.Weapon, threat.ID))
+	return true
+}
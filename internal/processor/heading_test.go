@@ -0,0 +1,48 @@
+package processor
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestTurningToFaceTargetBehindTakesTwentyTicks(t *testing.T) {
+	p, _ := newTestProcessor(t, WithSpeed(common.MovementSpeed{Linear: 5, Angular: math.Pi / 2}))
+	behind := common.Location{X: -10}
+
+	// Half a turn at 90°/s in 100ms ticks takes 2s
+	ticks := 0
+	for !p.turnTowards(behind) {
+		ticks++
+		if ticks > 100 {
+			t.Fatal("robot never faced the target")
+		}
+	}
+	ticks++
+	if ticks != 20 {
+		t.Errorf("facing the target took %d ticks, want 20", ticks)
+	}
+	if turn := common.TurnAngle(p.Heading(), math.Pi); math.Abs(turn) > 1e-9 {
+		t.Errorf("heading = %.4f, want π", p.Heading())
+	}
+}
+
+func TestWeaponsWithArcWaitUntilFacing(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	threat := testThreat("t1", 5, common.Location{X: -30})
+	plasma, _, _ := p.offense.Strategy("plasma_cannon")
+	missile, _, _ := p.offense.Strategy("missile")
+
+	if p.withinFiringArc(plasma, &threat) {
+		t.Error("plasma cannon can fire at a threat behind the robot")
+	}
+	if !p.withinFiringArc(missile, &threat) {
+		t.Error("missile without a firing arc is gated on heading")
+	}
+
+	p.setHeading(math.Pi - plasma.FiringArc/2 + 0.01)
+	if !p.withinFiringArc(plasma, &threat) {
+		t.Error("plasma cannon cannot fire once the threat is inside its arc")
+	}
+}
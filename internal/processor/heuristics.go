@@ -0,0 +1,128 @@
+package processor
+
+import (
+	"fmt"
+
+	"t800/internal/ai"
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+const (
+	// engageSeverity is the severity at which threats are engaged regardless of distance
+	engageSeverity = 5
+	// retreatHealth is the critical-part health below which the heuristic retreats
+	retreatHealth = 20.0
+	// defaultWeaponDamage applies to weapons missing from the damage table
+	defaultWeaponDamage = 10.0
+	// maxRangeDamageFraction is the share of full damage a weapon still deals
+	// at its maximum range
+	maxRangeDamageFraction = 0.5
+)
+
+// DefaultWeaponDamage returns the default damage dealt per hit by each weapon
+func DefaultWeaponDamage() map[string]float64 {
+	return map[string]float64{
+		"plasma_cannon": 25.0,
+		"missile":       40.0,
+		"emp_pulse":     15.0,
+		"laser_beam":    20.0,
+	}
+}
+
+// weaponDamage returns the full damage dealt per hit by a weapon
+func (p *Processor) weaponDamage(weapon string) float64 {
+	if damage, exists := p.config.WeaponDamage[weapon]; exists {
+		return damage
+	}
+	return defaultWeaponDamage
+}
+
+// strikeDamage returns the damage a weapon deals to a threat at distance:
+// full damage out to the falloff ring, tapering linearly to half at maximum
+// range, and none outside the weapon's range band, scaled by the weapon's
+// effectiveness against the threat's type
+func (p *Processor) strikeDamage(strategy offense.AttackStrategy, threat *common.Threat, distance float64) float64 {
+	if distance < strategy.MinRange || distance > strategy.Range {
+		return 0
+	}
+
+	damage := p.weaponDamage(strategy.Weapon) * p.offense.Effectiveness(threat.Type, strategy.Weapon)
+	falloff := strategy.Range * falloffFraction
+	if distance <= falloff || strategy.Range <= falloff {
+		return damage
+	}
+	progress := (distance - falloff) / (strategy.Range - falloff)
+	return damage * (1 - progress*(1-maxRangeDamageFraction))
+}
+
+// shouldEngageProactively decides whether to engage a threat without the AI:
+// severe threats are always engaged, others once they come within engagement distance
+func (p *Processor) shouldEngageProactively(threat *common.Threat) bool {
+	if threat.Severity >= engageSeverity {
+		return true
+	}
+	return common.CalculateDistance(p.getLocation(), threat.Location) <= p.engagementDistance
+}
+
+// heuristicCombatDecision chooses a combat action without the AI: retreat
+// when a critical part is failing, close the distance when no weapon that is
+// ready to fire can reach, and otherwise attack with the most damaging ready
+// weapon in range
+func (p *Processor) heuristicCombatDecision(threat *common.Threat) *ai.CombatDecision {
+	for _, part := range p.anatomy.GetCriticalParts() {
+		if part.GetHealth() < retreatHealth {
+			return &ai.CombatDecision{
+				Action:      "retreat",
+				Target:      threat.ID,
+				Explanation: fmt.Sprintf("%s health critical", part.Name),
+			}
+		}
+	}
+
+	distance := common.CalculateDistance(p.getLocation(), threat.Location)
+	if weapon := p.bestWeaponInRange(threat, distance); weapon != "" {
+		return &ai.CombatDecision{
+			Action:      "attack",
+			Target:      threat.ID,
+			Weapon:      weapon,
+			Explanation: fmt.Sprintf("%s in range at %.2f meters", weapon, distance),
+		}
+	}
+
+	return &ai.CombatDecision{
+		Action:      "move",
+		Target:      threat.ID,
+		Explanation: fmt.Sprintf("no ready weapon in range at %.2f meters", distance),
+	}
+}
+
+// bestWeaponInRange returns the weapon to use against a threat at the given
+// distance, among those ready to fire: the first in-range weapon of any
+// operator priority override for the threat's type, otherwise the weapon in
+// range most damaging against the threat's type. It returns "" if no ready
+// weapon reaches.
+func (p *Processor) bestWeaponInRange(threat *common.Threat, distance float64) string {
+	ready := p.readyWeapons(threat)
+	inRange := make(map[string]bool)
+	for _, strategy := range ready {
+		inRange[strategy.Weapon] = distance >= strategy.MinRange && distance <= strategy.Range
+	}
+	for _, weapon := range p.offense.WeaponPriority(threat.Type) {
+		if inRange[weapon] {
+			return weapon
+		}
+	}
+
+	var (
+		best       string
+		bestDamage float64
+	)
+	for _, strategy := range ready {
+		damage := p.strikeDamage(strategy, threat, distance)
+		if damage > bestDamage {
+			best, bestDamage = strategy.Weapon, damage
+		}
+	}
+	return best
+}
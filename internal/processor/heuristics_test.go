@@ -0,0 +1,81 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+func TestHeuristicSkipsWeaponsOutOfAmmo(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	threat := testThreat("t1", 5, common.Location{X: 70})
+
+	if decision := p.heuristicCombatDecision(&threat); decision.Action != "attack" || decision.Weapon != "missile" {
+		t.Fatalf("decision with missiles = %+v, want attack with missile", decision)
+	}
+
+	for _, missile := range offense.MissileTypes {
+		p.offense.SetAmmo(offense.AmmoPool(offense.MissileWeapon, missile), 0)
+	}
+	if decision := p.heuristicCombatDecision(&threat); decision.Action != "move" {
+		t.Errorf("decision without missiles = %+v, want move", decision)
+	}
+}
+
+func TestHeuristicSkipsWeaponsCoolingDown(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	threat := testThreat("t1", 5, common.Location{X: 70})
+
+	p.offense.MarkFired(p.anatomy.Body, "missile", clock.Now())
+	if decision := p.heuristicCombatDecision(&threat); decision.Action != "move" {
+		t.Errorf("decision while the missile cools down = %+v, want move", decision)
+	}
+}
+
+func TestProcessorWithoutAIEngagesViaHeuristics(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	if p.AIEnabled() {
+		t.Fatal("NewProcessor enabled the AI")
+	}
+	activate(p)
+
+	threat := testThreat("t1", 8, common.Location{X: 20})
+	if err := p.ReportThreat(threat); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+	p.escalate(threat.ID, EscalationFullEngagement)
+	if err := p.moveAndEngageWithAI(context.Background()); err != nil {
+		t.Fatalf("moveAndEngageWithAI: %v", err)
+	}
+
+	records := p.DecisionAudit().Records()
+	if len(records) == 0 {
+		t.Fatal("no decisions recorded")
+	}
+	if last := records[len(records)-1]; last.Action != "attack" || last.Source != SourceHeuristic {
+		t.Errorf("last decision = %s from %s, want a heuristic attack", last.Action, last.Source)
+	}
+}
+
+func TestEngagementFallsBackWhenAIReturnsGarbage(t *testing.T) {
+	decisionMaker := newTestAI(t, func(prompt string) string { return "I think you should engage" })
+
+	cfg := DefaultProcessorConfig()
+	cfg.ModeHysteresis = 0
+	p, _ := newTestProcessorWithConfig(t, cfg, WithDecisionMaker(decisionMaker))
+	activate(p)
+
+	threat := testThreat("t1", 8, common.Location{X: 20})
+	p.threats.Add(threat)
+	if err := p.processThreatsWithAI(context.Background(), []*common.Threat{&threat}); err != nil {
+		t.Fatalf("processThreatsWithAI: %v", err)
+	}
+	if active := p.GetActiveThreat(); active == nil || active.ID != threat.ID {
+		t.Fatalf("active threat = %v, want %s engaged by the heuristic", active, threat.ID)
+	}
+	if rationale := p.LastDecisionRationale(); rationale.Source != SourceHeuristic {
+		t.Errorf("decision source = %s, want %s", rationale.Source, SourceHeuristic)
+	}
+}
@@ -0,0 +1,20 @@
+package processor
+
+import "time"
+
+// presenceTracker debounces threat presence between scans so that threats
+// flickering in and out of view don't flap the operation mode
+type presenceTracker struct {
+	present bool
+	since   time.Time
+}
+
+// settled records whether threats are present at now and reports whether
+// that state has held for at least the hysteresis period
+func (t *presenceTracker) settled(present bool, now time.Time, hysteresis time.Duration) bool {
+	if t.since.IsZero() || present != t.present {
+		t.present = present
+		t.since = now
+	}
+	return now.Sub(t.since) >= hysteresis
+}
@@ -0,0 +1,50 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestModeHysteresisIgnoresOneTickThreats(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	threat := testThreat("t1", 8, common.Location{X: 20})
+	scan := func(threats ...*common.Threat) {
+		t.Helper()
+		if err := p.processThreatsWithAI(context.Background(), threats); err != nil {
+			t.Fatalf("processThreatsWithAI: %v", err)
+		}
+		clock.Advance(scanInterval)
+	}
+
+	// A threat seen on a single scan never brings the robot into combat
+	scan(&threat)
+	for i := 0; i < 20; i++ {
+		scan()
+		if mode := p.getMode(); mode != common.Normal {
+			t.Fatalf("mode after a one-scan threat = %v, want normal", mode)
+		}
+	}
+
+	// A sustained threat does, once it has persisted for the hysteresis
+	for elapsed := time.Duration(0); elapsed <= p.config.ModeHysteresis; elapsed += scanInterval {
+		scan(&threat)
+	}
+	if mode := p.getMode(); mode != common.Combat {
+		t.Fatalf("mode after a sustained threat = %v, want combat", mode)
+	}
+
+	// Combat holds through a brief gap and ends once threats stay away
+	scan()
+	if mode := p.getMode(); mode != common.Combat {
+		t.Errorf("mode after one empty scan = %v, want combat", mode)
+	}
+	for elapsed := time.Duration(0); elapsed <= p.config.ModeHysteresis; elapsed += scanInterval {
+		scan()
+	}
+	if mode := p.getMode(); mode != common.Normal {
+		t.Errorf("mode after threats stayed away = %v, want normal", mode)
+	}
+}
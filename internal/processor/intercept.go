@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"t800/internal/common"
+)
+
+// effectiveSpeed returns the robot's linear speed scaled by the health of
+// its legs, so a damaged robot moves more slowly
+func (p *Processor) effectiveSpeed() float64 {
+	return p.getSpeed().Linear * p.anatomy.LocomotionFactor()
+}
+
+// CanIntercept solves the pursuit problem against a threat moving at constant
+// velocity, returning the earliest point and time at which the robot, moving
+// at its damage-adjusted speed, can reach it. It reports false when the
+// threat can never be caught.
+func (p *Processor) CanIntercept(threat *common.Threat) (common.Location, time.Duration, bool) {
+	offset := threat.Location.Sub(p.getLocation())
+	velocity := threat.Velocity
+	speed := p.effectiveSpeed()
+
+	// Find the smallest t >= 0 with |offset + velocity*t| = speed*t
+	a := velocity.X*velocity.X + velocity.Y*velocity.Y + velocity.Z*velocity.Z - speed*speed
+	b := 2 * (offset.X*velocity.X + offset.Y*velocity.Y + offset.Z*velocity.Z)
+	c := offset.X*offset.X + offset.Y*offset.Y + offset.Z*offset.Z
+
+	var t float64
+	switch {
+	case c == 0:
+		t = 0
+	case math.Abs(a) < 1e-9:
+		if b >= 0 {
+			return common.Location{}, 0, false
+		}
+		t = -c / b
+	default:
+		discriminant := b*b - 4*a*c
+		if discriminant < 0 {
+			return common.Location{}, 0, false
+		}
+		root := math.Sqrt(discriminant)
+		t1, t2 := (-b-root)/(2*a), (-b+root)/(2*a)
+		t = math.Inf(1)
+		for _, candidate := range []float64{t1, t2} {
+			if candidate >= 0 && candidate < t {
+				t = candidate
+			}
+		}
+		if math.IsInf(t, 1) {
+			return common.Location{}, 0, false
+		}
+	}
+
+	intercept := threat.Location.Add(velocity.Scale(t))
+	return intercept, time.Duration(t * float64(time.Second)), true
+}
+
+// abandonPursuit gives up on a threat the robot cannot catch
+func (p *Processor) abandonPursuit(threat *common.Threat) {
+	p.logger.Info(fmt.Sprintf("Abandoning pursuit of %s: target cannot be intercepted", threat.ID))
+	if p.isActiveThreat(threat.ID) {
+		p.setActiveThreat(nil)
+		p.setMode(common.Normal)
+	}
+}
@@ -0,0 +1,36 @@
+package processor
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestCanInterceptOnlySlowerFleeingThreats(t *testing.T) {
+	p, _ := newTestProcessor(t, WithSpeed(common.MovementSpeed{Linear: 5, Angular: math.Pi}))
+
+	fast := testThreat("fast", 5, common.Location{X: 50})
+	fast.Velocity = common.Location{X: 10}
+	if point, _, ok := p.CanIntercept(&fast); ok {
+		t.Errorf("intercepted a threat fleeing at twice the robot's speed at %+v", point)
+	}
+
+	slow := testThreat("slow", 5, common.Location{X: 50})
+	slow.Velocity = common.Location{X: 2}
+	point, eta, ok := p.CanIntercept(&slow)
+	if !ok {
+		t.Fatal("cannot intercept a threat fleeing slower than the robot")
+	}
+	// The robot closes at 3 m/s from 50 meters
+	wantETA := 50.0 / 3
+	if math.Abs(eta.Seconds()-wantETA) > 1e-6 {
+		t.Errorf("intercept time = %v, want %.3fs", eta, wantETA)
+	}
+	if want := (common.Location{X: 50 + 2*wantETA}); common.CalculateDistance(point, want) > 1e-6 {
+		t.Errorf("intercept point = %+v, want %+v", point, want)
+	}
+	if reach := 5 * eta.Seconds(); math.Abs(common.CalculateDistance(p.getLocation(), point)-reach) > 1e-6 {
+		t.Errorf("intercept point is not %.2f meters away, reachable in %v", reach, eta)
+	}
+}
@@ -0,0 +1,27 @@
+package processor
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestRobotRampsUpToSpeed(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	speed := p.getSpeed()
+	target := common.Location{X: 100}
+
+	var previous float64
+	for tick := 1; tick <= 30; tick++ {
+		p.moveTowardsTarget(target)
+		current := common.CalculateDistance(common.Location{}, p.Velocity())
+		if current-previous > speed.MaxAccel*0.1+1e-9 {
+			t.Fatalf("tick %d: speed rose from %.3f to %.3f, faster than %.1f m/s²", tick, previous, current, speed.MaxAccel)
+		}
+		previous = current
+	}
+	if math.Abs(previous-speed.Linear) > 1e-9 {
+		t.Errorf("speed after 3s = %.3f, want top speed %.1f", previous, speed.Linear)
+	}
+}
@@ -0,0 +1,139 @@
+package processor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// latencyRetention is how long an unengaged detection is remembered
+const latencyRetention = time.Minute
+
+// latencyBucketBounds are the upper bounds of the latency histogram buckets
+var latencyBucketBounds = []time.Duration{
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+}
+
+// LatencyBucket counts engagements whose latency fell at or below UpperBound.
+// The final bucket has a zero UpperBound and counts everything slower.
+type LatencyBucket struct {
+	UpperBound time.Duration
+	Count      int
+}
+
+// LatencyStats summarises scan-to-engagement latency: the time from a
+// threat first being detected to the first weapon firing at it
+type LatencyStats struct {
+	Count     int
+	Min       time.Duration
+	Max       time.Duration
+	Mean      time.Duration
+	Last      time.Duration
+	Histogram []LatencyBucket
+}
+
+// latencyTracker records detection and first-fire times per threat
+type latencyTracker struct {
+	mu         sync.Mutex
+	detectedAt map[string]time.Time
+	total      time.Duration
+	stats      LatencyStats
+}
+
+// newLatencyTracker creates an empty latency tracker
+func newLatencyTracker() *latencyTracker {
+	histogram := make([]LatencyBucket, len(latencyBucketBounds)+1)
+	for i, bound := range latencyBucketBounds {
+		histogram[i].UpperBound = bound
+	}
+	return &latencyTracker{
+		detectedAt: make(map[string]time.Time),
+		stats:      LatencyStats{Histogram: histogram},
+	}
+}
+
+// recordDetection notes when a threat was first seen; later sightings are ignored
+func (lt *latencyTracker) recordDetection(threatID string, now time.Time) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	for id, detected := range lt.detectedAt {
+		if now.Sub(detected) > latencyRetention {
+			delete(lt.detectedAt, id)
+		}
+	}
+	if _, seen := lt.detectedAt[threatID]; !seen {
+		lt.detectedAt[threatID] = now
+	}
+}
+
+// recordFire measures the latency of the first shot at a threat. It returns
+// the latency and whether this was the first shot at a detected threat.
+func (lt *latencyTracker) recordFire(threatID string, now time.Time) (time.Duration, bool) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	detected, pending := lt.detectedAt[threatID]
+	if !pending {
+		return 0, false
+	}
+	delete(lt.detectedAt, threatID)
+
+	latency := now.Sub(detected)
+	lt.total += latency
+	lt.stats.Count++
+	lt.stats.Last = latency
+	lt.stats.Mean = lt.total / time.Duration(lt.stats.Count)
+	if lt.stats.Count == 1 || latency < lt.stats.Min {
+		lt.stats.Min = latency
+	}
+	if latency > lt.stats.Max {
+		lt.stats.Max = latency
+	}
+
+	bucket := len(latencyBucketBounds)
+	for i, bound := range latencyBucketBounds {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+	lt.stats.Histogram[bucket].Count++
+	return latency, true
+}
+
+// snapshot returns a copy of the current statistics
+func (lt *latencyTracker) snapshot() LatencyStats {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	stats := lt.stats
+	stats.Histogram = make([]LatencyBucket, len(lt.stats.Histogram))
+	copy(stats.Histogram, lt.stats.Histogram)
+	return stats
+}
+
+// EngagementLatency returns scan-to-engagement latency statistics
+func (p *Processor) EngagementLatency() LatencyStats {
+	return p.latency.snapshot()
+}
+
+// noteDetection records the first detection of a threat
+func (p *Processor) noteDetection(threatID string) {
+	p.latency.recordDetection(threatID, p.clock.Now())
+}
+
+// noteFire records a weapon firing at a threat and warns when the first
+// shot came later than the configured latency budget
+func (p *Processor) noteFire(threatID string) {
+	latency, first := p.latency.recordFire(threatID, p.clock.Now())
+	if first && p.config.LatencyBudget > 0 && latency > p.config.LatencyBudget {
+		p.logger.Warn(fmt.Sprintf("Engagement latency for %s was %v, exceeding budget of %v",
+			threatID, latency, p.config.LatencyBudget))
+	}
+}
@@ -0,0 +1,52 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestEngagementLatencyMeasuresDetectionToFirstShot(t *testing.T) {
+	cfg := DefaultProcessorConfig()
+	cfg.ModeHysteresis = 0
+	p, clock := newTestProcessorWithConfig(t, cfg)
+	activate(p)
+
+	// The scanner detects the threat and the processor engages it
+	threat := testThreat("t1", 8, common.Location{X: 20})
+	p.threats.Add(threat)
+	if err := p.processThreatsWithAI(context.Background(), []*common.Threat{&threat}); err != nil {
+		t.Fatalf("processThreatsWithAI: %v", err)
+	}
+	if active := p.GetActiveThreat(); active == nil || active.ID != threat.ID {
+		t.Fatalf("active threat = %v, want %s engaged", active, threat.ID)
+	}
+
+	// The first shot goes out on a later movement tick
+	clock.Advance(300 * time.Millisecond)
+	if err := p.moveAndEngageWithAI(context.Background()); err != nil {
+		t.Fatalf("moveAndEngageWithAI: %v", err)
+	}
+
+	stats := p.EngagementLatency()
+	if stats.Count != 1 || stats.Last != 300*time.Millisecond {
+		t.Fatalf("latency stats = %+v, want one engagement after 300ms", stats)
+	}
+	for _, bucket := range stats.Histogram {
+		want := 0
+		if bucket.UpperBound == 500*time.Millisecond {
+			want = 1
+		}
+		if bucket.Count != want {
+			t.Errorf("bucket up to %v counted %d, want %d", bucket.UpperBound, bucket.Count, want)
+		}
+	}
+
+	// Only the first shot at a threat counts
+	p.noteFire(threat.ID)
+	if stats := p.EngagementLatency(); stats.Count != 1 {
+		t.Errorf("latency count after a second shot = %d, want 1", stats.Count)
+	}
+}
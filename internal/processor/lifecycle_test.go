@@ -0,0 +1,107 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestStartStopCyclesLeaveNoGoroutines(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	baseline := runtime.NumGoroutine()
+
+	for i := 0; i < 100; i++ {
+		if err := p.Start(); err != nil {
+			t.Fatalf("cycle %d: Start: %v", i, err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := p.Stop(ctx)
+		cancel()
+		if err != nil {
+			t.Fatalf("cycle %d: Stop: %v", i, err)
+		}
+		if count := p.GoroutineCount(); count != 0 {
+			t.Fatalf("cycle %d: %d goroutines running after Stop", i, count)
+		}
+	}
+
+	// Allow the runtime a moment to reap exited goroutines
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if leaked := runtime.NumGoroutine() - baseline; leaked > 0 {
+		t.Errorf("%d goroutines leaked over 100 Start/Stop cycles", leaked)
+	}
+}
+
+func TestStopDrainsLoopsAfterThreatReported(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if p.GoroutineCount() == 0 {
+		t.Fatal("no background loops running after Start")
+	}
+	if err := p.ReportThreat(testThreat("t1", 7, common.Location{X: 30})); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := p.Stop(ctx); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if count := p.GoroutineCount(); count != 0 {
+		t.Errorf("%d goroutines running after Stop", count)
+	}
+	if p.IsActive() {
+		t.Error("system still active after Stop")
+	}
+}
+
+func TestStopReportsUndrainedWorkAtDeadline(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		begin func(t *testing.T, p *Processor, release <-chan struct{})
+	}{
+		{"stuck loop", func(t *testing.T, p *Processor, release <-chan struct{}) {
+			p.spawn(func() { <-release })
+		}},
+		{"in-flight attack", func(t *testing.T, p *Processor, release <-chan struct{}) {
+			if !p.beginAttack() {
+				t.Fatal("beginAttack refused while active")
+			}
+			go func() {
+				<-release
+				p.attacks.Done()
+			}()
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newTestProcessor(t)
+			activate(p)
+			release := make(chan struct{})
+			tc.begin(t, p, release)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			err := p.Stop(ctx)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Stop with undrained work = %v, want the deadline exceeded", err)
+			}
+			if p.beginAttack() {
+				t.Error("attack began after Stop")
+			}
+
+			close(release)
+			if err := p.Stop(context.Background()); err != nil {
+				t.Errorf("Stop once drained: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package processor
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestDamagedLegSlowsMovement(t *testing.T) {
+	speed := common.MovementSpeed{Linear: 5, Angular: math.Pi / 2}
+	target := common.Location{X: 100}
+
+	healthy, _ := newTestProcessor(t, WithSpeed(speed))
+	healthy.moveTowardsTarget(target)
+	full := common.CalculateDistance(common.Location{}, healthy.getLocation())
+	if math.Abs(full-0.5) > 1e-9 {
+		t.Fatalf("healthy robot moved %.3fm in one step, want 0.5", full)
+	}
+
+	damaged, _ := newTestProcessor(t, WithSpeed(speed))
+	damaged.anatomy.Legs[0].Expose(100)
+	damaged.moveTowardsTarget(target)
+	if got := common.CalculateDistance(common.Location{}, damaged.getLocation()); math.Abs(got-full/2) > 1e-9 {
+		t.Errorf("robot with a destroyed leg moved %.3fm, want %.3f", got, full/2)
+	}
+}
@@ -0,0 +1,33 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestBlockedLineOfSightRepositionsInsteadOfFiring(t *testing.T) {
+	wall := common.Obstacle{Min: common.Location{X: 9, Y: -1, Z: -1}, Max: common.Location{X: 11, Y: 1, Z: 3}}
+	p, clock := newTestProcessor(t, WithObstacles([]common.Obstacle{wall}))
+	activate(p)
+	start := p.getLocation()
+
+	engageActiveThreat(t, p)
+
+	if rationale := p.LastDecisionRationale(); rationale.Action != "reposition" {
+		t.Errorf("decision = %q, want reposition", rationale.Action)
+	}
+	if p.getLocation() == start {
+		t.Error("robot did not move to find a clear shot")
+	}
+	plasma, _, _ := p.offense.Strategy("plasma_cannon")
+	for _, arm := range p.anatomy.Arms {
+		if p.offense.CooldownRemaining(arm, plasma, clock.Now()) > 0 {
+			t.Errorf("%s fired through the wall", arm.Name)
+		}
+	}
+	threat, _ := p.threats.Get("t1")
+	if position, found := p.findFiringPosition(&threat); !found || !common.HasLineOfSight(position, threat.Location, p.obstacles) {
+		t.Errorf("findFiringPosition = %+v, %v; want a position with a clear shot", position, found)
+	}
+}
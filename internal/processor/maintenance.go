@@ -0,0 +1,91 @@
+package processor
+
+import (
+	"fmt"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+// EnterMaintenance switches an idle processor into maintenance, during which
+// offensive engagement halts, ammunition-limited weapons are reloaded and
+// damaged parts are repaired each health tick according to the recovery policy. Maintenance ends by itself once repairs
+// are complete or a threat is detected. A processor in combat cannot enter
+// maintenance. The command is checkpointed so it can be undone.
+func (p *Processor) EnterMaintenance() error {
+	return p.command(p.enterMaintenance)
+}
+
+// enterMaintenance switches into maintenance without checkpointing
+func (p *Processor) enterMaintenance() error {
+	switch mode := p.getMode(); mode {
+	case common.Maintenance:
+		return nil
+	case common.Normal, common.Standby:
+	default:
+		return fmt.Errorf("cannot enter maintenance in %s mode", mode)
+	}
+
+	p.logger.Info("Entering maintenance")
+	p.setActiveThreat(nil)
+	p.setMode(common.Maintenance)
+	for weapon, rounds := range p.offense.Reload() {
+		p.logger.Info(fmt.Sprintf("Reloaded %d rounds of %s", rounds, weapon))
+	}
+	return nil
+}
+
+// ExitMaintenance returns the processor from maintenance to normal
+// operation. The command is checkpointed so it can be undone.
+func (p *Processor) ExitMaintenance() {
+	p.command(func() error {
+		p.exitMaintenance()
+		return nil
+	})
+}
+
+// exitMaintenance leaves maintenance without checkpointing
+func (p *Processor) exitMaintenance() {
+	if p.getMode() != common.Maintenance {
+		return
+	}
+	p.logger.Info("Exiting maintenance")
+	p.setMode(common.Normal)
+}
+
+// SetRecoveryPolicy replaces the policy used for maintenance repairs
+func (p *Processor) SetRecoveryPolicy(policy anatomy.RecoveryPolicy) {
+	p.repair.SetPolicy(policy)
+}
+
+// SetNanobotPolicy replaces the policy used for active repairs outside
+// maintenance, e.g. to enable or disable the nanobots
+func (p *Processor) SetNanobotPolicy(policy anatomy.NanobotPolicy) {
+	p.repair.SetNanobotPolicy(policy)
+}
+
+// nanobotTick spends one tick of nanobot power on failing critical parts
+func (p *Processor) nanobotTick() {
+	repaired := p.repair.NanobotTick()
+
+	status := p.anatomy.GetHealthStatus()
+	for part, amount := range repaired {
+		p.logger.Info(fmt.Sprintf("Nanobots repaired %s by %.2f (Health: %.2f%%)", part, amount, status[part]))
+	}
+}
+
+// repairTick spends one tick of the repair budget, logs what was repaired
+// and leaves maintenance once every part is repaired
+func (p *Processor) repairTick() {
+	repaired := p.repair.RepairTick()
+
+	status := p.anatomy.GetHealthStatus()
+	for part, amount := range repaired {
+		p.logger.Info(fmt.Sprintf("Repaired %s by %.2f (Health: %.2f%%)", part, amount, status[part]))
+	}
+
+	if p.repair.Repaired() {
+		p.logger.Info("Repairs complete")
+		p.exitMaintenance()
+	}
+}
@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestMaintenanceRepairsCriticalPartsFirst(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	p.anatomy.Head.Expose(30)
+	p.anatomy.Arms[0].Expose(30)
+
+	if err := p.EnterMaintenance(); err != nil {
+		t.Fatalf("EnterMaintenance: %v", err)
+	}
+	if mode := p.getMode(); mode != common.Maintenance {
+		t.Fatalf("mode = %v, want maintenance", mode)
+	}
+
+	for i := 0; i < 3; i++ {
+		p.repairTick()
+	}
+	if health := p.anatomy.Head.GetHealth(); health != 100 {
+		t.Errorf("head health after 3 repair ticks = %.1f, want 100", health)
+	}
+	if health := p.anatomy.Arms[0].GetHealth(); health != 70 {
+		t.Errorf("arm health after 3 repair ticks = %.1f, want 70 until the head is done", health)
+	}
+
+	for i := 0; i < 10 && p.getMode() == common.Maintenance; i++ {
+		p.repairTick()
+	}
+	if mode := p.getMode(); mode != common.Normal {
+		t.Fatalf("mode after repairs = %v, want normal", mode)
+	}
+	threshold := p.repair.Policy().ExitThreshold
+	for name, health := range p.anatomy.GetHealthStatus() {
+		if health < threshold {
+			t.Errorf("%s left maintenance at %.1f, below %.1f", name, health, threshold)
+		}
+	}
+}
+
+func TestDetectionAbandonsMaintenance(t *testing.T) {
+	p, _ := newTestProcessor(t, WithSeed(4))
+	p.anatomy.Arms[0].Expose(50)
+	if err := p.EnterMaintenance(); err != nil {
+		t.Fatalf("EnterMaintenance: %v", err)
+	}
+
+	p.scanTick()
+	if mode := p.getMode(); mode == common.Maintenance {
+		t.Error("still in maintenance after a detection")
+	}
+}
+
+func TestCannotEnterMaintenanceInCombat(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	p.setMode(common.Combat)
+	if err := p.EnterMaintenance(); err == nil {
+		t.Error("EnterMaintenance succeeded in combat")
+	}
+	if mode := p.getMode(); mode != common.Combat {
+		t.Errorf("mode = %v, want combat unchanged", mode)
+	}
+}
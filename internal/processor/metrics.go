@@ -0,0 +1,85 @@
+package processor
+
+import (
+	"sync"
+	"time"
+
+	"t800/internal/common"
+)
+
+// ProcessorMetrics is a point-in-time snapshot of the processor's state
+type ProcessorMetrics struct {
+	Timestamp          time.Time
+	Mode               common.OperationMode
+	Posture            Posture
+	Location           common.Location
+	ThreatCount        int
+	ActiveThreatID     string
+	ActiveThreatHealth float64
+	PowerLevel         float64
+	Health             map[string]float64
+	Ammo               map[string]int
+	Decisions          int
+	ThreatsEliminated  int
+	Latency            LatencyStats
+}
+
+// Metrics returns a snapshot of the processor's current metrics
+func (p *Processor) Metrics() ProcessorMetrics {
+	status := p.GetStatus()
+	metrics := ProcessorMetrics{
+		Timestamp:   p.clock.Now(),
+		Mode:        status.Mode,
+		Posture:     status.Posture,
+		Location:    p.getLocation(),
+		ThreatCount: p.threats.Len(),
+		PowerLevel:  p.anatomy.Power.Level(),
+		Health:      p.anatomy.GetHealthStatus(),
+		Ammo:        p.offense.AmmoStatus(),
+		Decisions:   p.audit.Len(),
+		Latency:     p.EngagementLatency(),
+	}
+	if threat := p.GetActiveThreat(); threat != nil {
+		metrics.ActiveThreatID = threat.ID
+		metrics.ActiveThreatHealth = threat.Health
+	}
+	metrics.ThreatsEliminated = int(p.eliminated.Load())
+	return metrics
+}
+
+// MetricsStream emits a metrics snapshot every interval until the returned
+// cancel function is called or the processor shuts down, after which the
+// channel is closed. Snapshots are dropped if the consumer falls behind.
+func (p *Processor) MetricsStream(interval time.Duration) (<-chan ProcessorMetrics, func()) {
+	stream := make(chan ProcessorMetrics, 1)
+	done := make(chan struct{})
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() { close(done) })
+	}
+
+	// Start the ticker now so the cadence runs from the call, not from
+	// whenever the goroutine gets scheduled
+	ticker := p.clock.NewTicker(interval)
+	p.spawn(func() {
+		defer close(stream)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-p.ctx.Done():
+				return
+			case <-ticker.C():
+				select {
+				case stream <- p.Metrics():
+				default:
+				}
+			}
+		}
+	})
+
+	return stream, cancel
+}
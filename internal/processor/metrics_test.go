@@ -0,0 +1,41 @@
+package processor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetricsStreamEmitsAtIntervalUntilCancelled(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	stream, cancel := p.MetricsStream(time.Second)
+
+	for i := 1; i <= 3; i++ {
+		clock.Advance(500 * time.Millisecond)
+		select {
+		case metrics := <-stream:
+			t.Fatalf("snapshot at %v arrived before the interval elapsed", metrics.Timestamp)
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		clock.Advance(500 * time.Millisecond)
+		select {
+		case metrics := <-stream:
+			if want := testStart.Add(time.Duration(i) * time.Second); !metrics.Timestamp.Equal(want) {
+				t.Errorf("snapshot %d taken at %v, want %v", i, metrics.Timestamp, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no snapshot after %d intervals", i)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-stream:
+		if ok {
+			t.Error("snapshot emitted after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream not closed after cancel")
+	}
+	cancel()
+}
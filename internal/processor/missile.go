@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"fmt"
+
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+// LaunchMissile fires a missile of the given type at the current threat and
+// returns the damage dealt on impact. The command is checkpointed so it can
+// be undone.
+func (p *Processor) LaunchMissile(missile offense.MissileType) (float64, error) {
+	var damage float64
+	err := p.command(func() error {
+		if err := p.offense.CheckMissile(missile); err != nil {
+			return err
+		}
+		p.logger.Info(fmt.Sprintf("Launching %s missile", missile))
+		damage = p.executeAttack(offense.MissileWeapon, missile)
+		return nil
+	})
+	return damage, err
+}
+
+// chooseMissile picks the type of missile to launch at a threat: a cluster
+// when other live threats are within its blast radius, otherwise the first
+// type still loaded in order of preference
+func (p *Processor) chooseMissile(threat *common.Threat) offense.MissileType {
+	loaded := func(missile offense.MissileType) bool {
+		rounds, _ := p.offense.Ammo(offense.AmmoPool(offense.MissileWeapon, missile))
+		return rounds > 0
+	}
+
+	if payload, _ := p.offense.Payload(offense.Cluster); loaded(offense.Cluster) {
+		for _, other := range p.threats.List() {
+			if other.ID != threat.ID && other.Health > 0 &&
+				common.CalculateDistance(threat.Location, other.Location) <= payload.BlastRadius {
+				return offense.Cluster
+			}
+		}
+	}
+	for _, missile := range offense.MissileTypes {
+		if loaded(missile) {
+			return missile
+		}
+	}
+	return offense.HighExplosive
+}
+
+// landStrike applies the damage of a shot that reached its target and
+// returns the damage dealt. Missiles deal their payload's share of the
+// weapon's damage and then branch on type: high explosive hits the target
+// alone, a cluster also hits every threat within its blast radius and
+// incendiary leaves a fire burning at the impact point.
+func (p *Processor) landStrike(threat *common.Threat, partName string, strategy offense.AttackStrategy, missile offense.MissileType) float64 {
+	distance := common.CalculateDistance(p.getLocation(), threat.Location)
+	damage := p.strikeDamage(strategy, threat, distance)
+
+	payload, isMissile := p.offense.Payload(missile)
+	if !isMissile {
+		return p.applyDamage(threat, partName, strategy.Weapon, damage)
+	}
+
+	dealt := p.applyDamage(threat, partName, strategy.Weapon, damage*payload.DamageFraction)
+	switch missile {
+	case offense.Cluster:
+		dealt += p.clusterBlast(threat, partName, strategy, payload, distance)
+	case offense.Incendiary:
+		p.ignite(threat.Location, payload)
+	}
+	return dealt
+}
+
+// clusterBlast hits every other live threat within the payload's blast
+// radius of the target, eliminating those it destroys, and returns the
+// damage dealt. Bomblets carry the missile's damage at the range of the
+// impact, scaled by each threat's own weapon effectiveness.
+func (p *Processor) clusterBlast(target *common.Threat, partName string, strategy offense.AttackStrategy, payload offense.Payload, distance float64) float64 {
+	dealt := 0.0
+	for _, stored := range p.threats.List() {
+		if stored.ID == target.ID || stored.Health <= 0 ||
+			common.CalculateDistance(target.Location, stored.Location) > payload.BlastRadius {
+			continue
+		}
+		threat := stored
+		dealt += p.applyDamage(&threat, partName, strategy.Weapon, p.strikeDamage(strategy, &threat, distance)*payload.DamageFraction)
+		if threat.Health <= 0 {
+			p.eliminateThreat(&threat)
+		}
+	}
+	return dealt
+}
+
+// ignite starts a fire at an impact point that burns threats, and the robot
+// should it enter, until the payload's burn duration has passed
+func (p *Processor) ignite(at common.Location, payload offense.Payload) {
+	if payload.BurnRadius <= 0 || payload.BurnDuration <= 0 {
+		return
+	}
+	p.addHazard(Hazard{
+		Name:                  "incendiary fire",
+		Center:                at,
+		Radius:                payload.BurnRadius,
+		DamagePerSecond:       payload.BurnDamagePerSecond,
+		ThreatDamagePerSecond: payload.BurnDamagePerSecond,
+		Expires:               p.clock.Now().Add(payload.BurnDuration),
+	})
+	p.logger.Info(fmt.Sprintf("Incendiary fire started at (%.2f, %.2f, %.2f)", at.X, at.Y, at.Z))
+}
@@ -0,0 +1,71 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+func TestClusterMissileHitsNearbyThreats(t *testing.T) {
+	for _, tc := range []struct {
+		missile    offense.MissileType
+		hitsNearby bool
+	}{
+		{offense.HighExplosive, false},
+		{offense.Cluster, true},
+	} {
+		t.Run(string(tc.missile), func(t *testing.T) {
+			p, _ := newTestProcessor(t)
+			target := testThreat("target", 5, common.Location{X: 70})
+			nearby := testThreat("nearby", 5, common.Location{X: 75})
+			distant := testThreat("distant", 5, common.Location{X: 70, Y: 40})
+			for _, threat := range []common.Threat{target, nearby, distant} {
+				p.AddThreat(threat)
+			}
+
+			strategy, _, _ := p.offense.Strategy(offense.MissileWeapon)
+			p.landStrike(&target, p.anatomy.Body.Name, strategy, tc.missile)
+
+			if target.Health >= 100 {
+				t.Errorf("target health = %v, want damage", target.Health)
+			}
+			stored, _ := p.threats.Get("nearby")
+			if hit := stored.Health < 100; hit != tc.hitsNearby {
+				t.Errorf("nearby threat hit = %v, want %v", hit, tc.hitsNearby)
+			}
+			if stored, _ := p.threats.Get("distant"); stored.Health != 100 {
+				t.Errorf("distant threat health = %v, want 100", stored.Health)
+			}
+		})
+	}
+}
+
+func TestLaunchMissileDrawsOnItsType(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	threat := testThreat("t1", 5, common.Location{X: 70})
+	p.AddThreat(threat)
+	p.setActiveThreat(&threat)
+	p.escalate(threat.ID, EscalationFullEngagement)
+
+	before := p.offense.AmmoStatus()
+	if _, err := p.LaunchMissile(offense.Incendiary); err != nil {
+		t.Fatalf("LaunchMissile: %v", err)
+	}
+	after := p.offense.AmmoStatus()
+
+	for _, missile := range offense.MissileTypes {
+		pool := offense.AmmoPool(offense.MissileWeapon, missile)
+		want := before[pool]
+		if missile == offense.Incendiary {
+			want--
+		}
+		if after[pool] != want {
+			t.Errorf("%s rounds = %d, want %d", pool, after[pool], want)
+		}
+	}
+
+	if _, err := p.LaunchMissile("nuclear"); err == nil {
+		t.Error("LaunchMissile accepted an unknown missile type")
+	}
+}
@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"t800/internal/ai"
+	"t800/internal/common"
+	"t800/internal/monitoring"
+	"t800/internal/scanner"
+)
+
+// Option customizes a processor at construction
+type Option func(*Processor)
+
+// WithEngagementDistance sets the distance within which threats are engaged
+func WithEngagementDistance(distance float64) Option {
+	return func(p *Processor) {
+		p.engagementDistance = distance
+	}
+}
+
+// WithScanner replaces the scanner; the processor tracks threats in the
+// scanner's store
+func WithScanner(s *scanner.Scanner) Option {
+	return func(p *Processor) {
+		p.scanner = s
+		p.threats = s.Store()
+	}
+}
+
+// WithObstacles sets the obstacles considered for line of sight and cover
+func WithObstacles(obstacles []common.Obstacle) Option {
+	return func(p *Processor) {
+		p.SetObstacles(obstacles)
+	}
+}
+
+// WithSafeZone sets the location the robot falls back to when retreating
+func WithSafeZone(zone common.Location) Option {
+	return func(p *Processor) {
+		p.config.SafeZone = &zone
+	}
+}
+
+// WithHazards sets the hazards that damage the robot while it is inside them
+func WithHazards(hazards []Hazard) Option {
+	return func(p *Processor) {
+		p.SetHazards(hazards)
+	}
+}
+
+// WithDryRun previews attacks without firing; see SetDryRun
+func WithDryRun() Option {
+	return func(p *Processor) {
+		p.SetDryRun(true)
+	}
+}
+
+// WithClock replaces the clock the processor and its scanner read the time
+// and tick from
+func WithClock(clock common.Clock) Option {
+	return func(p *Processor) {
+		p.SetClock(clock)
+	}
+}
+
+// WithSeed seeds every random source for reproducible runs
+func WithSeed(seed int64) Option {
+	return func(p *Processor) {
+		p.SetSeed(seed)
+	}
+}
+
+// WithLogger replaces the processor's logger
+func WithLogger(logger *monitoring.Logger) Option {
+	return func(p *Processor) {
+		p.logger = logger
+		p.offense.SetLogger(logger)
+	}
+}
+
+// WithInitialLocation sets where the robot starts
+func WithInitialLocation(location common.Location) Option {
+	return func(p *Processor) {
+		p.setLocation(location)
+	}
+}
+
+// WithSpeed sets the robot's movement speed
+func WithSpeed(speed common.MovementSpeed) Option {
+	return func(p *Processor) {
+		p.stateMu.Lock()
+		defer p.stateMu.Unlock()
+		p.speed = speed
+	}
+}
+
+// WithDecisionMaker enables the AI with the given decision maker; nil
+// disables it
+func WithDecisionMaker(decisionMaker *ai.DecisionMaker) Option {
+	return func(p *Processor) {
+		p.decisionMaker = decisionMaker
+	}
+}
+
+// applyOptions applies construction options in order
+func (p *Processor) applyOptions(opts []Option) *Processor {
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
@@ -0,0 +1,47 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"t800/internal/common"
+	"t800/internal/scanner"
+)
+
+func TestScanLoopUsesInjectedScanner(t *testing.T) {
+	stub := scanner.NewScanner()
+	stub.SetSeed(4)
+	calls := 0
+	stub.SetIDGenerator(func() string {
+		calls++
+		return fmt.Sprintf("stub-%d", calls)
+	})
+
+	start := common.Location{X: 5, Y: -5}
+	p, clock := newTestProcessor(t, WithScanner(stub), WithInitialLocation(start), WithEngagementDistance(35))
+	p.SetClock(clock)
+	if p.threats != stub.Store() {
+		t.Fatal("processor does not track threats in the injected scanner's store")
+	}
+	if got := p.getLocation(); got != start {
+		t.Errorf("initial location = %+v, want %+v", got, start)
+	}
+	if p.engagementDistance != 35 {
+		t.Errorf("engagement distance = %v, want 35", p.engagementDistance)
+	}
+
+	p.scanTick()
+	if calls == 0 {
+		t.Fatal("scan loop never used the injected scanner")
+	}
+	threats := p.threats.List()
+	if len(threats) == 0 {
+		t.Fatal("no threats recorded from the injected scanner")
+	}
+	for _, threat := range threats {
+		if !strings.HasPrefix(threat.ID, "stub-") {
+			t.Errorf("threat %s was not detected by the injected scanner", threat.ID)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package processor
+
+import (
+	"fmt"
+	"time"
+)
+
+// Posture describes how eagerly the processor engages detected threats
+type Posture int
+
+const (
+	// PostureBalanced consults the decision maker before engaging
+	PostureBalanced Posture = iota
+	// PosturePassive tracks detected threats without engaging them
+	PosturePassive
+	// PostureAggressive engages every detected threat immediately
+	PostureAggressive
+)
+
+// String returns a human readable name for the posture
+func (p Posture) String() string {
+	switch p {
+	case PostureBalanced:
+		return "balanced"
+	case PosturePassive:
+		return "passive"
+	case PostureAggressive:
+		return "aggressive"
+	default:
+		return fmt.Sprintf("posture(%d)", int(p))
+	}
+}
+
+// ScheduleWindow maps a daily time window to a posture. Start and End are
+// offsets from midnight; a window whose End is before its Start wraps past
+// midnight (e.g. 20:00 to 06:00).
+type ScheduleWindow struct {
+	Start   time.Duration
+	End     time.Duration
+	Posture Posture
+}
+
+// Schedule is an ordered list of posture windows; the first match wins
+type Schedule []ScheduleWindow
+
+// contains reports whether the time of day falls inside the window
+func (w ScheduleWindow) contains(timeOfDay time.Duration) bool {
+	if w.Start <= w.End {
+		return timeOfDay >= w.Start && timeOfDay < w.End
+	}
+	return timeOfDay >= w.Start || timeOfDay < w.End
+}
+
+// PostureAt returns the posture scheduled for the given time, if any
+func (s Schedule) PostureAt(t time.Time) (Posture, bool) {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	timeOfDay := t.Sub(midnight)
+	for _, window := range s {
+		if window.contains(timeOfDay) {
+			return window.Posture, true
+		}
+	}
+	return PostureBalanced, false
+}
+
+// SetPosture switches the processor's engagement posture. The command is
+// checkpointed so it can be undone.
+func (p *Processor) SetPosture(posture Posture) {
+	p.command(func() error {
+		p.setPosture(posture)
+		return nil
+	})
+}
+
+// setPosture switches the engagement posture without checkpointing
+func (p *Processor) setPosture(posture Posture) {
+	p.status.mu.Lock()
+	previous := p.status.Posture
+	p.status.Posture = posture
+	p.status.mu.Unlock()
+
+	if previous != posture {
+		p.logger.Info(fmt.Sprintf("Posture changed: %s -> %s", previous, posture))
+	}
+}
+
+// getPosture returns the current engagement posture
+func (p *Processor) getPosture() Posture {
+	p.status.mu.RLock()
+	defer p.status.mu.RUnlock()
+	return p.status.Posture
+}
+
+// SetSchedule installs a posture schedule that the processor follows automatically
+func (p *Processor) SetSchedule(s Schedule) {
+	p.status.mu.Lock()
+	p.schedule = s
+	p.status.mu.Unlock()
+	p.applySchedule()
+}
+
+// applySchedule switches to the posture scheduled for the current time
+func (p *Processor) applySchedule() {
+	p.status.mu.RLock()
+	schedule := p.schedule
+	p.status.mu.RUnlock()
+
+	if posture, ok := schedule.PostureAt(p.clock.Now()); ok {
+		p.setPosture(posture)
+	}
+}
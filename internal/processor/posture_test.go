@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestNightWindowSwitchesPostureToAggressive(t *testing.T) {
+	clock := common.NewManualClock(time.Date(2024, 6, 1, 21, 59, 0, 0, time.UTC))
+	p, _ := newTestProcessor(t, WithClock(clock))
+	p.SetSchedule(Schedule{
+		{Start: 22 * time.Hour, End: 6 * time.Hour, Posture: PostureAggressive},
+		{Start: 6 * time.Hour, End: 22 * time.Hour, Posture: PostureBalanced},
+	})
+	if posture := p.getPosture(); posture != PostureBalanced {
+		t.Fatalf("posture before night = %v, want balanced", posture)
+	}
+
+	clock.Advance(time.Minute)
+	p.applySchedule()
+	if posture := p.getPosture(); posture != PostureAggressive {
+		t.Errorf("posture at night = %v, want aggressive", posture)
+	}
+
+	clock.Advance(8 * time.Hour)
+	p.applySchedule()
+	if posture := p.getPosture(); posture != PostureBalanced {
+		t.Errorf("posture in the morning = %v, want balanced", posture)
+	}
+}
+
+func TestScheduleWindowWrapsMidnight(t *testing.T) {
+	schedule := Schedule{{Start: 22 * time.Hour, End: 6 * time.Hour, Posture: PosturePassive}}
+	for _, tc := range []struct {
+		hour int
+		want bool
+	}{
+		{21, false},
+		{22, true},
+		{0, true},
+		{5, true},
+		{6, false},
+	} {
+		_, ok := schedule.PostureAt(time.Date(2024, 6, 1, tc.hour, 0, 0, 0, time.UTC))
+		if ok != tc.want {
+			t.Errorf("window covers %02d:00 = %v, want %v", tc.hour, ok, tc.want)
+		}
+	}
+}
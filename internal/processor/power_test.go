@@ -0,0 +1,68 @@
+package processor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestDrainedReservoirSkipsRestOfVolley(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	activate(p)
+	p.config.PartialVolley = false
+	threat := testThreat("t1", 5, common.Location{X: 20})
+	threat.Health = 10_000
+	p.AddThreat(threat)
+	p.escalate(threat.ID, EscalationFullEngagement)
+
+	assignments := p.volleyAssignments(&threat)
+	if len(assignments) < 2 {
+		t.Fatalf("volley has %d weapons, want several", len(assignments))
+	}
+	// Enough for any one weapon but never two
+	p.anatomy.Power.SetLevel(100)
+	p.executeCoordinatedAttack(&threat)
+
+	fired := 0
+	for _, assignment := range assignments {
+		if p.offense.CooldownRemaining(assignment.part, assignment.strategy, clock.Now()) > 0 {
+			fired++
+		}
+	}
+	if fired != 1 {
+		t.Errorf("%d weapons fired on 100 power, want 1 with the rest skipped", fired)
+	}
+	if level := p.anatomy.Power.Level(); level >= 60 {
+		t.Errorf("power left = %.1f, want the one shot to have drawn it down", level)
+	}
+}
+
+func TestLowPowerSkipsLowerPriorityDefensiveAction(t *testing.T) {
+	cfg := DefaultProcessorConfig()
+	cfg.DefenseBudget = 1000
+	p, _ := newTestProcessorWithConfig(t, cfg)
+	p.anatomy.Power.SetLevel(35)
+	var out bytes.Buffer
+	p.logger.SetOutput(&out)
+
+	threat := testThreat("t1", 8, common.Location{X: 20})
+	if spent := p.applyDefenses(&threat); spent != 30 {
+		t.Errorf("defenses spent %.1f power, want 30 for the head's emergency shields alone", spent)
+	}
+	if level := p.anatomy.Power.Level(); level != 5 {
+		t.Errorf("power left = %.1f, want 5", level)
+	}
+
+	boosted := p.defense.BoostStatus()
+	if _, ok := boosted["head"]; !ok {
+		t.Error("head's first-priority shields skipped")
+	}
+	if _, ok := boosted["body"]; ok {
+		t.Error("body boosted without the power to pay for it")
+	}
+	if !strings.Contains(out.String(), "Skipping Rapid evasive movement to protect head") {
+		t.Errorf("head's lower-priority evasive manoeuvre was not skipped:\n%s", out.String())
+	}
+}
@@ -0,0 +1,35 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestProcessorOnlyEngagesConfidentPredictions(t *testing.T) {
+	cfg := DefaultProcessorConfig()
+	cfg.ModeHysteresis = 0
+	p, _ := newTestProcessorWithConfig(t, cfg)
+
+	speculative := testThreat("speculative", 8, common.Location{X: 20})
+	speculative.Type, speculative.Confidence = "predicted", 0.6
+	if err := p.processThreatsWithAI(context.Background(), []*common.Threat{&speculative}); err != nil {
+		t.Fatalf("processThreatsWithAI: %v", err)
+	}
+	if active := p.GetActiveThreat(); active != nil {
+		t.Fatalf("engaged %s at confidence 0.6, want it only tracked", active.ID)
+	}
+	if records := p.DecisionAudit().Records(); len(records) != 1 || records[0].Action != "track" {
+		t.Fatalf("decisions = %+v, want the speculative prediction tracked", records)
+	}
+
+	confident := testThreat("confident", 8, common.Location{X: 20})
+	confident.Type, confident.Confidence = "predicted", 0.9
+	if err := p.processThreatsWithAI(context.Background(), []*common.Threat{&confident}); err != nil {
+		t.Fatalf("processThreatsWithAI: %v", err)
+	}
+	if active := p.GetActiveThreat(); active == nil || active.ID != "confident" {
+		t.Errorf("active threat = %v, want confident prediction engaged", active)
+	}
+}
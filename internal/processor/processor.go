@@ -3,13 +3,17 @@ package processor
 import (
 	"context"
 	"fmt"
+	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"t800/internal/anatomy"
 	"t800/internal/ai"
+	"t800/internal/anatomy"
 	"t800/internal/common"
+	"t800/internal/config"
 	"t800/internal/defense"
+	"t800/internal/events"
 	"t800/internal/monitoring"
 	"t800/internal/offense"
 	"t800/internal/scanner"
@@ -17,21 +21,66 @@ import (
 
 // Processor represents the main T800 defensive system
 type Processor struct {
-	logger          *monitoring.Logger
-	anatomy         *anatomy.RobotAnatomy
-	defense         *defense.StrategyManager
-	offense         *offense.OffenseManager
-	scanner         *scanner.Scanner
-	status          *Status
-	location        common.Location
-	speed           common.MovementSpeed
-	ctx             context.Context
-	cancel          context.CancelFunc
-	activeThreat    *common.Threat
+	logger             *monitoring.Logger
+	anatomy            *anatomy.RobotAnatomy
+	defense            *defense.StrategyManager
+	offense            *offense.OffenseManager
+	scanner            *scanner.Scanner
+	status             *Status
+	location           common.Location
+	speed              common.MovementSpeed
+	velocity           common.Location
+	heading            float64 // Facing in radians, counterclockwise from the X axis
+	parent             context.Context
+	ctx                context.Context
+	cancel             context.CancelFunc
+	lifecycleMu        sync.Mutex
+	wg                 sync.WaitGroup
+	attacks            sync.WaitGroup
+	goroutines         atomic.Int32
+	activeThreat       *common.Threat
+	stateMu            sync.RWMutex // Guards activeThreat, location and speed
+	threats            *common.ThreatStore
 	engagementDistance float64
-	decisionMaker   *ai.DecisionMaker
-	mode            common.OperationMode
-	availableWeapons []string
+	decisionMaker      *ai.DecisionMaker
+	config             ProcessorConfig
+	audit              *DecisionAudit
+	escalation         map[string]EscalationLevel
+	escalationMu       sync.Mutex
+	clock              common.Clock
+	schedule           Schedule
+	history            []ProcessorSnapshot
+	historyMu          sync.Mutex
+	commandMu          sync.Mutex // Serializes checkpointed commands
+	latency            *latencyTracker
+	events             *events.Bus
+	obstacles          []common.Obstacle
+	hazards            []Hazard
+	hazardsMu          sync.Mutex
+	lastHazardTick     time.Time
+	partBusyUntil      map[string]time.Time
+	partBusyMu         sync.Mutex
+	lastActivity       time.Time
+	activityMu         sync.Mutex
+	presence           presenceTracker
+	repair             *anatomy.RepairSystem
+	combatDecision     cachedDecision
+	combatDecisionMu   sync.Mutex
+	rationale          Rationale
+	rationaleMu        sync.RWMutex
+	salvageSites       []common.Location
+	salvageMu          sync.Mutex
+	engagements        map[string]*engagementSpend
+	engagementsMu      sync.Mutex
+	impacts            []pendingImpact
+	impactsMu          sync.Mutex
+	eliminated         atomic.Int64
+	squad              *Squad
+	squadID            string
+	squadMu            sync.RWMutex
+	dryRun             atomic.Bool
+	lastSeen           map[string]time.Time
+	seenMu             sync.Mutex
 }
 
 // Status maintains the processor's current state
@@ -39,70 +88,320 @@ type Status struct {
 	mu       sync.RWMutex
 	active   bool
 	Mode     common.OperationMode
+	Posture  Posture
 	lastScan time.Time
+
+	// resumeMode is the mode to return to when an emergency ends
+	resumeMode common.OperationMode
+}
+
+// NewProcessor creates a new T800 processor customized by the given options
+func NewProcessor(ctx context.Context, opts ...Option) (*Processor, error) {
+	return NewProcessorWithConfig(ctx, DefaultProcessorConfig(), opts...)
+}
+
+// NewProcessorWithConfig creates a new T800 processor with the given
+// configuration. Unless an option supplies a decision maker, the AI is
+// disabled and heuristics are used.
+func NewProcessorWithConfig(ctx context.Context, cfg ProcessorConfig, opts ...Option) (*Processor, error) {
+	return newProcessor(ctx, cfg, monitoring.NewLogger(), nil).applyOptions(opts), nil
 }
 
-// NewProcessor creates a new T800 processor
-func NewProcessor(ctx context.Context) (*Processor, error) {
-	ctx, cancel := context.WithCancel(ctx)
+// NewProcessorFromConfig creates a new T800 processor tuned by a loaded
+// configuration; the AI is consulted only if the configuration enables it
+func NewProcessorFromConfig(ctx context.Context, cfg *config.Config, opts ...Option) (*Processor, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %v", err)
+	}
+
+	logger, err := monitoring.NewLoggerWithConfig(monitoring.LoggerConfig{
+		Level:        cfg.Logging.Level,
+		Format:       monitoring.Format(cfg.Logging.Format),
+		Output:       cfg.Logging.Output,
+		MaxSizeBytes: int64(cfg.Logging.MaxSizeMB * 1024 * 1024),
+		MaxBackups:   cfg.Logging.MaxBackups,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %v", err)
+	}
+	var decisionMaker *ai.DecisionMaker
+	if cfg.AI.Enabled {
+		if decisionMaker, err = ai.NewDecisionMakerFromConfig(logger, cfg.AI); err != nil {
+			return nil, fmt.Errorf("failed to create decision maker: %v", err)
+		}
+	}
+
+	p := newProcessor(ctx, DefaultProcessorConfig(), logger, decisionMaker)
+	p.engagementDistance = cfg.Processor.EngagementDistance
+	p.speed = common.MovementSpeed{
+		Linear:   cfg.Processor.LinearSpeed,
+		Angular:  cfg.Processor.AngularSpeed,
+		MaxAccel: cfg.Processor.MaxAccel,
+	}
+
+	s, err := scanner.NewScannerFromConfig(p.threats, cfg.Scanner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scanner: %v", err)
+	}
+	p.scanner = s
+	if p.offense, err = offense.NewOffenseManagerFromConfig(cfg.Weapons); err != nil {
+		return nil, fmt.Errorf("failed to create offense manager: %v", err)
+	}
+	p.offense.SetLogger(logger)
+
+	if err := p.anatomy.SetRegenRate(cfg.Anatomy.RegenRate); err != nil {
+		return nil, err
+	}
+	if err := p.anatomy.SetCritChance(cfg.Anatomy.CritChance); err != nil {
+		return nil, err
+	}
+	for partType, rate := range cfg.Anatomy.RegenRates {
+		if err := p.anatomy.SetPartTypeRegenRate(anatomy.PartType(partType), rate); err != nil {
+			return nil, err
+		}
+	}
+	p.anatomy.Power = anatomy.NewPowerCore(cfg.Anatomy.PowerCapacity, cfg.Anatomy.PowerRechargeRate)
+	shieldDelay := time.Duration(cfg.Anatomy.ShieldRechargeDelay * float64(time.Second))
+	if err := p.anatomy.SetShieldRecharge(cfg.Anatomy.ShieldRechargeRate, shieldDelay); err != nil {
+		return nil, err
+	}
+
+	return p.applyOptions(opts), nil
+}
+
+// NewProcessorWithAI creates a new T800 processor that consults the AI
+// decision maker for engagement and combat decisions
+func NewProcessorWithAI(ctx context.Context, opts ...Option) (*Processor, error) {
 	logger := monitoring.NewLogger()
-	
+
 	decisionMaker, err := ai.NewDecisionMaker(logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create decision maker: %v", err)
 	}
+	if err := decisionMaker.Healthy(ctx); err != nil {
+		logger.LogError(err, "AI unreachable, falling back to heuristics until it responds")
+	}
+
+	return newProcessor(ctx, DefaultProcessorConfig(), logger, decisionMaker).applyOptions(opts), nil
+}
 
+// newProcessor wires up a processor; a nil decision maker disables the AI
+func newProcessor(ctx context.Context, cfg ProcessorConfig, logger *monitoring.Logger, decisionMaker *ai.DecisionMaker) *Processor {
+	threats := common.NewThreatStore()
+	robot := anatomy.NewRobotAnatomy()
+	parent := ctx
+	ctx, cancel := context.WithCancel(parent)
+	repair := anatomy.NewRepairSystem(robot, cfg.Recovery)
+	repair.SetNanobotPolicy(cfg.Nanobots)
+	om := offense.NewOffenseManager()
+	om.SetLogger(logger)
 	return &Processor{
-		logger:          logger,
-		anatomy:         anatomy.NewRobotAnatomy(),
-		defense:         defense.NewStrategyManager(),
-		offense:         offense.NewOffenseManager(),
-		scanner:         scanner.NewScanner(),
-		status:          &Status{Mode: common.Normal},
-		location:        common.Location{X: 0, Y: 0, Z: 0},
-		speed:           common.DefaultSpeed(),
-		ctx:             ctx,
-		cancel:          cancel,
+		logger:             logger,
+		anatomy:            robot,
+		defense:            defense.NewStrategyManager(),
+		offense:            om,
+		scanner:            scanner.NewScannerWithStore(threats),
+		threats:            threats,
+		status:             &Status{Mode: common.Normal},
+		location:           common.Location{X: 0, Y: 0, Z: 0},
+		speed:              common.DefaultSpeed(),
+		parent:             parent,
+		ctx:                ctx,
+		cancel:             cancel,
 		engagementDistance: 50.0,
-		decisionMaker:   decisionMaker,
-		mode:            common.Normal,
-		availableWeapons: []string{"plasma_cannon", "missile", "emp_pulse", "laser_beam"},
-	}, nil
+		decisionMaker:      decisionMaker,
+		config:             cfg,
+		audit:              NewDecisionAudit(),
+		escalation:         make(map[string]EscalationLevel),
+		clock:              common.RealClock{},
+		latency:            newLatencyTracker(),
+		events:             events.NewBus(),
+		partBusyUntil:      make(map[string]time.Time),
+		lastActivity:       time.Now(),
+		repair:             repair,
+		engagements:        make(map[string]*engagementSpend),
+		lastSeen:           make(map[string]time.Time),
+	}
+}
+
+// AIEnabled reports whether the processor consults the AI decision maker
+func (p *Processor) AIEnabled() bool {
+	return p.decisionMaker != nil
 }
 
 // Start initializes the defensive system
 func (p *Processor) Start() error {
 	p.logger.Info("Initializing T800 defensive system")
-	
+
+	// Report loadout problems but keep running with whatever weapons work
+	for _, err := range p.SelfTest() {
+		p.logger.Warn(fmt.Sprintf("Self-test: %v", err))
+	}
+
+	p.lifecycleMu.Lock()
+	defer p.lifecycleMu.Unlock()
+
 	p.status.mu.Lock()
+	if p.status.active {
+		p.status.mu.Unlock()
+		return fmt.Errorf("processor already running")
+	}
 	p.status.active = true
 	p.status.mu.Unlock()
 
+	// A stopped processor gets a fresh context so it can be restarted
+	if p.ctx.Err() != nil {
+		p.ctx, p.cancel = context.WithCancel(p.parent)
+	}
+
 	// Start monitoring routines
-	go p.monitorThreats()
-	go p.monitorHealth()
-	go p.scanEnvironment()
+	p.spawn(p.monitorThreats)
+	p.spawn(p.monitorHealth)
+	p.spawn(p.scanEnvironment)
+	p.spawn(p.refreshCombatDecisions)
 
 	return nil
 }
 
-// Stop safely shuts down the system
-func (p *Processor) Stop() error {
+// SelfTest checks the processor's configuration for problems, such as
+// weapons that require parts the anatomy lacks
+func (p *Processor) SelfTest() []error {
+	return p.offense.ValidateLoadout(p.anatomy)
+}
+
+// Stop safely shuts down the system: background loops are cancelled and
+// Stop waits for them and any in-flight attacks to finish. If ctx expires
+// first an error is returned and the stragglers exit in the background.
+func (p *Processor) Stop(ctx context.Context) error {
 	p.logger.Info("Initiating shutdown sequence")
-	
+
+	p.lifecycleMu.Lock()
+	defer p.lifecycleMu.Unlock()
+
+	// Once inactive no new attack can begin, so the wait below is final
 	p.status.mu.Lock()
 	p.status.active = false
 	p.status.mu.Unlock()
-	
+
 	p.cancel()
-	return nil
+	drained := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		p.attacks.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("shutdown incomplete with %d goroutines running: %w", p.GoroutineCount(), ctx.Err())
+	}
+}
+
+// beginAttack registers an in-flight attack for Stop to drain, unless the
+// processor is inactive
+func (p *Processor) beginAttack() bool {
+	p.status.mu.RLock()
+	defer p.status.mu.RUnlock()
+	if !p.status.active {
+		return false
+	}
+	p.attacks.Add(1)
+	return true
+}
+
+// spawn runs fn in a background goroutine tracked for the processor's lifecycle
+func (p *Processor) spawn(fn func()) {
+	p.wg.Add(1)
+	p.goroutines.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer p.goroutines.Add(-1)
+		fn()
+	}()
+}
+
+// GoroutineCount returns the number of background goroutines still running;
+// it is zero once Stop has returned
+func (p *Processor) GoroutineCount() int {
+	return int(p.goroutines.Load())
 }
 
 // GetStatus returns the current system status
 func (p *Processor) GetStatus() Status {
 	p.status.mu.RLock()
 	defer p.status.mu.RUnlock()
-	return *p.status
+	return Status{
+		active:   p.status.active,
+		Mode:     p.status.Mode,
+		Posture:  p.status.Posture,
+		lastScan: p.status.lastScan,
+	}
+}
+
+// IsActive reports whether the processor has been started and not stopped
+func (p *Processor) IsActive() bool {
+	p.status.mu.RLock()
+	defer p.status.mu.RUnlock()
+	return p.status.active
+}
+
+// getMode returns the current operation mode
+func (p *Processor) getMode() common.OperationMode {
+	p.status.mu.RLock()
+	defer p.status.mu.RUnlock()
+	return p.status.Mode
+}
+
+// setMode switches the processor into the given operation mode. During an
+// emergency the mode is held and the request is resumed once it ends.
+func (p *Processor) setMode(mode common.OperationMode) {
+	p.status.mu.Lock()
+	previous := p.status.Mode
+	if previous == common.Emergency {
+		p.status.resumeMode = mode
+		p.status.mu.Unlock()
+		return
+	}
+	p.status.Mode = mode
+	p.status.mu.Unlock()
+
+	p.modeChanged(previous, mode)
+}
+
+// ExportCombatLog writes the recorded combat events to a file as "json" or
+// "csv" for after-action analysis
+func (p *Processor) ExportCombatLog(path, format string) error {
+	return p.logger.ExportCombatLog(path, format)
+}
+
+// Subscribe registers a consumer of processor events. The returned function
+// unsubscribes and closes the channel.
+func (p *Processor) Subscribe(opts ...events.SubscribeOption) (<-chan events.Event, func()) {
+	return p.events.Subscribe(opts...)
+}
+
+// SubscribeFunc registers a handler run asynchronously for every processor
+// event. The returned function unsubscribes.
+func (p *Processor) SubscribeFunc(handler func(events.Event), opts ...events.SubscribeOption) func() {
+	return p.events.SubscribeFunc(handler, opts...)
+}
+
+// Narrate writes human-readable battle commentary for every processor event
+// to w until the returned function is called
+func (p *Processor) Narrate(w io.Writer) func() {
+	stream, unsubscribe := p.events.Subscribe(events.WithPolicy(events.Block))
+	go events.NewNarrator(w, "T800").Run(stream)
+	return unsubscribe
+}
+
+// SetClock replaces the clock used for time-based behaviour such as schedules
+func (p *Processor) SetClock(clock common.Clock) {
+	p.clock = clock
+	p.scanner.SetClock(clock)
+	p.anatomy.SetClock(clock)
+	p.noteActivity()
 }
 
 // GetAnatomy returns the robot's anatomy
@@ -110,63 +409,127 @@ func (p *Processor) GetAnatomy() *anatomy.RobotAnatomy {
 	return p.anatomy
 }
 
-// GetActiveThreat returns the current active threat
+// DecisionAudit returns the log of tactical decisions made by the processor
+func (p *Processor) DecisionAudit() *DecisionAudit {
+	return p.audit
+}
+
+// Threats returns a snapshot of every known threat, including those not yet engaged
+func (p *Processor) Threats() []common.Threat {
+	return p.threats.List()
+}
+
+// GetActiveThreat returns a copy of the current active threat, or nil if
+// there is none
 func (p *Processor) GetActiveThreat() *common.Threat {
-	return p.activeThreat
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
+
+	if p.activeThreat == nil {
+		return nil
+	}
+	threat := *p.activeThreat
+	return &threat
 }
 
-// ReportThreat reports a new threat to the system
-func (p *Processor) ReportThreat(threat common.Threat) error {
-	if !p.status.active {
-		return fmt.Errorf("system is not active")
+// setActiveThreat makes a copy of the threat the active threat; nil clears it
+func (p *Processor) setActiveThreat(threat *common.Threat) {
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+
+	if threat == nil {
+		p.activeThreat = nil
+		return
 	}
+	active := *threat
+	p.activeThreat = &active
+}
 
-	// Log the threat
-	p.logger.LogThreat(threat.ID, threat.Severity, threat.Location)
+// isActiveThreat reports whether the threat with the given ID is the active threat
+func (p *Processor) isActiveThreat(threatID string) bool {
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
+	return p.activeThreat != nil && p.activeThreat.ID == threatID
+}
 
-	// Set as active threat and enter combat mode
-	p.activeThreat = &threat
-	p.status.mu.Lock()
-	p.status.Mode = common.Combat
-	p.status.mu.Unlock()
-	p.logger.Info(fmt.Sprintf("New primary target acquired: %s (Severity: %d)", threat.ID, threat.Severity))
+// syncActiveThreat refreshes the active threat from an updated copy of it;
+// copies of other threats are ignored
+func (p *Processor) syncActiveThreat(threat common.Threat) {
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
 
-	// Get critical parts that need protection
-	criticalParts := p.anatomy.GetCriticalParts()
+	if p.activeThreat != nil && p.activeThreat.ID == threat.ID {
+		*p.activeThreat = threat
+	}
+}
 
-	// Apply defensive strategies
-	for _, part := range criticalParts {
-		strategies := p.defense.GetDefensiveStrategies(part)
-		for _, strategy := range strategies {
-			if err := strategy.Action(part, &threat); err != nil {
-				p.logger.LogError(err, "defensive action failed")
-				continue
-			}
-			p.logger.LogDefensiveAction(strategy.Description, part.Name, true)
-		}
+// getLocation returns the robot's current position
+func (p *Processor) getLocation() common.Location {
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
+	return p.location
+}
+
+// setLocation moves the robot to a new position
+func (p *Processor) setLocation(location common.Location) {
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+	p.location = location
+	p.velocity = common.Location{}
+}
+
+// getSpeed returns the robot's movement capabilities
+func (p *Processor) getSpeed() common.MovementSpeed {
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
+	return p.speed
+}
+
+// ReportThreat reports a new threat to the system. The command is
+// checkpointed so it can be undone.
+func (p *Processor) ReportThreat(threat common.Threat) error {
+	return p.command(func() error { return p.reportThreat(threat) })
+}
+
+// reportThreat reports a new threat without checkpointing
+func (p *Processor) reportThreat(threat common.Threat) error {
+	p.status.mu.RLock()
+	active := p.status.active
+	p.status.mu.RUnlock()
+	if !active {
+		return fmt.Errorf("system is not active")
+	}
+	if severity := common.ClampSeverity(threat.Severity); severity != threat.Severity {
+		p.logger.Warn(fmt.Sprintf("Threat %s severity %d out of range, clamped to %d", threat.ID, threat.Severity, severity))
+		threat.Severity = severity
 	}
 
-	// Add offensive response
-	// Use arms for attack if available
-	for _, arm := range p.anatomy.Arms {
-		attackStrategies := p.offense.GetOffensiveStrategies(arm)
-		for _, strategy := range attackStrategies {
-			if err := strategy.Action(arm, &threat); err != nil {
-				p.logger.LogError(err, "offensive action failed")
-				continue
-			}
-			p.logger.LogDefensiveAction(strategy.Description, arm.Name, true)
-		}
+	// Log the threat
+	p.logger.LogThreat(threat.ID, threat.Severity, threat.Location)
+	p.noteDetection(threat.ID)
+	p.events.Publish(events.Event{Type: events.ThreatDetected, ThreatID: threat.ID, Value: float64(threat.Severity), Health: threat.Health})
+
+	// Set as active threat and enter combat mode; an explicit report
+	// renews the engagement budget
+	p.resetEngagement(threat.ID)
+	p.threats.Add(threat)
+	p.noteSeen(threat.ID)
+	p.shareThreat(threat)
+	p.setActiveThreat(&threat)
+	// A retreat holds until the robot has recovered, then re-engages
+	if p.getMode() != common.Retreating {
+		p.setMode(common.Combat)
 	}
+	p.escalate(threat.ID, EscalationFullEngagement)
+	p.logger.Info(fmt.Sprintf("New primary target acquired: %s (Severity: %d)", threat.ID, threat.Severity))
+	p.recordDecision(&threat, "engage", "", SourceOperator, "threat reported by operator", "primary target acquired")
 
-	// Use body-mounted weapons as backup
-	bodyAttacks := p.offense.GetOffensiveStrategies(p.anatomy.Body)
-	for _, strategy := range bodyAttacks {
-		if err := strategy.Action(p.anatomy.Body, &threat); err != nil {
-			p.logger.LogError(err, "offensive action failed")
-			continue
-		}
-		p.logger.LogDefensiveAction(strategy.Description, p.anatomy.Body.Name, true)
+	// Protect critical parts within the defense budget
+	p.applyDefenses(&threat)
+
+	// Add offensive response unless critically damaged
+	if p.getMode() != common.Emergency {
+		p.executeCoordinatedAttack(&threat)
 	}
 
 	return nil
@@ -174,201 +537,398 @@ func (p *Processor) ReportThreat(threat common.Threat) error {
 
 // monitorThreats continuously monitors for threats
 func (p *Processor) monitorThreats() {
-	ticker := time.NewTicker(100 * time.Millisecond)
+	ticker := p.clock.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-p.ctx.Done():
 			return
-		case <-ticker.C:
-			// Implement threat monitoring logic
+		case <-ticker.C():
+			p.applySchedule()
+			p.checkIdle()
 		}
 	}
 }
 
 // monitorHealth continuously monitors robot health
 func (p *Processor) monitorHealth() {
-	ticker := time.NewTicker(1 * time.Second)
+	ticker := p.clock.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-p.ctx.Done():
 			return
-		case <-ticker.C:
-			p.anatomy.UpdateAllParts(time.Now().Unix())
-			status := p.anatomy.GetHealthStatus()
-			for part, health := range status {
-				p.logger.LogHealthStatus(part, health, p.anatomy.IsPartCritical(part))
-			}
+		case <-ticker.C():
+			p.healthTick(time.Second)
 		}
 	}
 }
 
+// healthTick updates part health, recharges the power core and applies
+// hazard damage for elapsed time
+func (p *Processor) healthTick(elapsed time.Duration) {
+	// Under fire the robot does not passively heal unless configured to
+	p.anatomy.SetRegenPaused(!p.config.RegenInCombat && p.getMode() == common.Combat)
+	p.anatomy.UpdateAllParts(p.clock.Now().Unix())
+	p.anatomy.RechargeShields(p.clock.Now())
+	p.defense.Tick(p.clock.Now())
+	p.anatomy.Power.Recharge(p.anatomy.Power.RechargeRate() * elapsed.Seconds())
+	p.hazardTick(elapsed)
+	if p.getMode() == common.Maintenance {
+		p.repairTick()
+	} else {
+		p.nanobotTick()
+	}
+	p.checkEmergency()
+	status := p.anatomy.GetHealthStatus()
+	for part, health := range status {
+		p.logger.LogHealthStatus(part, health, p.anatomy.IsPartCritical(part))
+	}
+}
+
+// drive moves the robot one 100ms movement update toward a target,
+// accelerating and braking within its acceleration limit and stopping at
+// contact with any threat, and returns its new position
+func (p *Processor) drive(target common.Location) common.Location {
+	deltaTime := 0.1 // 100ms movement update
+	speed, maxAccel := p.effectiveSpeed(), p.getSpeed().MaxAccel
+
+	threats := p.threats.List()
+	radius := p.anatomy.BoundingRadius()
+
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+	from := p.location
+	p.location, p.velocity = p.location.AccelerateTowards(target, p.velocity, speed, maxAccel, deltaTime)
+
+	// Halt at contact rather than driving into or through a threat
+	for _, threat := range threats {
+		threatRadius := threat.Radius
+		if threatRadius <= 0 {
+			threatRadius = radius
+		}
+		if stop := common.StopShortOf(from, p.location, radius, threat.Location, threatRadius); stop != p.location {
+			p.location, p.velocity = stop, common.Location{}
+		}
+	}
+	return p.location
+}
+
+// Velocity returns the robot's current velocity in meters per second
+func (p *Processor) Velocity() common.Location {
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
+	return p.velocity
+}
+
 // moveTowardsTarget moves the robot towards the current target
 func (p *Processor) moveTowardsTarget(target common.Location) {
-	deltaTime := 0.1 // 100ms movement update
-	newLocation := p.location.MoveTowards(target, p.speed.Linear, deltaTime)
-	
-	// Update robot's position
-	p.location = newLocation
-	
+	newLocation := p.drive(target)
+
 	// Log movement
-	distance := common.CalculateDistance(p.location, target)
+	distance := common.CalculateDistance(newLocation, target)
 	p.logger.Info(fmt.Sprintf("Moving towards target. Distance: %.2f meters", distance))
 }
 
 // scanEnvironment continuously scans for threats and processes them
 func (p *Processor) scanEnvironment() {
-	ticker := time.NewTicker(500 * time.Millisecond)
+	interval := p.scanInterval()
+	ticker := p.clock.NewTicker(interval)
 	defer ticker.Stop()
 
-	movementTicker := time.NewTicker(100 * time.Millisecond)
+	movementTicker := p.clock.NewTicker(100 * time.Millisecond)
 	defer movementTicker.Stop()
 
 	for {
 		select {
 		case <-p.ctx.Done():
 			return
-		case <-ticker.C:
-			threats := p.scanner.ScanArea(p.location)
-			if err := p.processThreatsWithAI(p.ctx, threats); err != nil {
-				p.logger.LogError(err, "failed to process threats with AI")
-			}
-		case <-movementTicker.C:
-			if p.activeThreat != nil {
-				if err := p.moveAndEngageWithAI(p.ctx); err != nil {
-					p.logger.LogError(err, "failed to move and engage with AI")
-				}
+		case <-ticker.C():
+			p.scanTick()
+			if next := p.scanInterval(); next != interval {
+				interval = next
+				ticker.Reset(interval)
 			}
+		case <-movementTicker.C():
+			p.movementTick()
+		}
+	}
+}
+
+// scanTick scans the surroundings once and evaluates what was found
+func (p *Processor) scanTick() {
+	threats := p.scanner.Scan(p.getLocation(), p.Heading())
+	for _, threat := range threats {
+		p.logger.RecordCombat(p.clock.Now(), monitoring.CombatThreatDetected, monitoring.CombatPayload{
+			ThreatID: threat.ID,
+			Value:    float64(threat.Severity),
+			Health:   threat.Health,
+		})
+		p.events.Publish(events.Event{
+			Type:      events.ThreatDetected,
+			Timestamp: p.clock.Now(),
+			ThreatID:  threat.ID,
+			Value:     float64(threat.Severity),
+			Health:    threat.Health,
+		})
+		p.shareThreat(*threat)
+	}
+	for _, id := range p.scanner.PruneStale(p.clock.Now()) {
+		p.logger.Info(fmt.Sprintf("Lost track of %s: not re-detected", id))
+		p.forgetEscalation(id)
+	}
+	for _, threat := range threats {
+		p.noteSeen(threat.ID)
+	}
+	p.pruneExpiredThreats()
+	if len(threats) > 0 {
+		p.noteActivity()
+		switch p.getMode() {
+		case common.Standby:
+			p.wake()
+		case common.Maintenance:
+			p.logger.Info("Threat detected, abandoning maintenance")
+			p.exitMaintenance()
+		}
+	}
+	if err := p.processThreatsWithAI(p.ctx, threats); err != nil {
+		p.logger.LogError(err, "failed to process threats with AI")
+	}
+}
+
+// movementTick moves the threats, lands projectiles that have arrived, then
+// falls back if retreating, or turns toward and engages the active threat or,
+// with none, heads for any wreck awaiting salvage
+func (p *Processor) movementTick() {
+	p.AdvanceThreats(0.1) // 100ms movement update
+	p.offense.Tick(0.1)
+	p.resolveDueImpacts()
+	if p.getMode() == common.Retreating {
+		p.retreatTick()
+	} else if threat := p.GetActiveThreat(); threat != nil {
+		p.turnTowards(threat.Location)
+		if err := p.moveAndEngageWithAI(p.ctx); err != nil {
+			p.logger.LogError(err, "failed to move and engage with AI")
 		}
+	} else if site, ok := p.nextSalvageSite(); ok && p.getMode() != common.Maintenance {
+		p.salvage(site)
 	}
 }
 
 // processThreatsWithAI evaluates threats using AI decision maker
 func (p *Processor) processThreatsWithAI(ctx context.Context, threats []*common.Threat) error {
+	if !p.presence.settled(len(threats) > 0, p.clock.Now(), p.config.ModeHysteresis) {
+		return nil
+	}
+
+	if p.getMode() == common.Emergency {
+		for _, threat := range threats {
+			p.recordDecision(threat, "track", "", SourceHeuristic, "emergency: critical part failing", "threat not engaged")
+		}
+		return nil
+	}
+	if p.getMode() == common.Retreating {
+		for _, threat := range threats {
+			p.recordDecision(threat, "track", "", SourceHeuristic, "retreating", "threat not engaged")
+		}
+		return nil
+	}
+
 	if len(threats) == 0 {
-		if p.activeThreat != nil {
+		if p.GetActiveThreat() != nil || p.getMode() == common.Combat {
 			p.logger.Info("No threats detected, returning to normal mode")
-			p.mode = common.Normal
-			p.activeThreat = nil
+			p.setMode(common.Normal)
+			p.setActiveThreat(nil)
 		}
 		return nil
 	}
 
-	for _, threat := range threats {
+	// Screen out the threats that cannot be engaged first, so the AI is
+	// consulted on every remaining candidate at once
+	posture := p.getPosture()
+	var candidates []*common.Threat
+	for _, threat := range p.prioritizeThreats(threats) {
 		// Skip eliminated threats
 		if threat.Health <= 0 {
 			continue
 		}
+		p.noteDetection(threat.ID)
+
+		if posture == PosturePassive {
+			p.recordDecision(threat, "track", "", SourceHeuristic, "passive posture", "threat not engaged")
+			continue
+		}
+
+		if p.overBudget(threat.ID) {
+			p.recordDecision(threat, "track", "", SourceHeuristic, "engagement budget exhausted", "threat not engaged")
+			continue
+		}
+
+		if !p.withinROE(threat) {
+			p.recordDecision(threat, "track", "", SourceHeuristic, "beyond ROE engagement range", "threat not engaged")
+			continue
+		}
 
-		shouldEngage, err := p.decisionMaker.ShouldEngageProactively(ctx, *threat, p.location, p.getHealthStatus())
-		if err != nil {
-			return fmt.Errorf("AI decision error: %v", err)
+		if threat.Type == "predicted" && threat.Confidence < p.config.PredictedEngageConfidence {
+			p.recordDecision(threat, "track", "", SourceHeuristic,
+				fmt.Sprintf("prediction confidence %.2f below engagement bar", threat.Confidence), "threat not engaged")
+			continue
+		}
+		candidates = append(candidates, threat)
+	}
+
+	var decisions []*ai.CombatDecision
+	if posture != PostureAggressive {
+		decisions = p.engagementDecisions(ctx, candidates)
+	}
+
+	for i, threat := range candidates {
+		shouldEngage, source := posture == PostureAggressive, SourceHeuristic
+		var decision *ai.CombatDecision
+		if i < len(decisions) {
+			decision = decisions[i]
+		}
+		if decision != nil {
+			shouldEngage, source = engagingActions[decision.Action], SourceAI
+		} else if !shouldEngage {
+			// With the AI disabled, or unable to decide on this threat, the
+			// heuristics decide rather than stalling the scan
+			shouldEngage = p.shouldEngageProactively(threat)
 		}
 
 		if shouldEngage {
 			p.logger.Info("New primary target acquired: " + threat.ID)
-			p.activeThreat = threat
-			p.mode = common.Combat
+			p.setActiveThreat(threat)
+			p.setMode(common.Combat)
+			p.escalate(threat.ID, EscalationFullEngagement)
+			if decision != nil {
+				p.cacheCombatDecision(threat.ID, decision)
+			}
+			p.recordDecision(threat, "engage", "", source, "", "primary target acquired")
 			return nil
 		}
+		if common.CalculateDistance(p.getLocation(), threat.Location) < p.engagementDistance {
+			p.escalate(threat.ID, EscalationWarn)
+		}
+		p.recordDecision(threat, "track", "", source, "", "threat not engaged")
 	}
 	return nil
 }
 
 // moveAndEngageWithAI handles movement and combat using AI decisions
 func (p *Processor) moveAndEngageWithAI(ctx context.Context) error {
-	if p.activeThreat == nil {
+	threat := p.GetActiveThreat()
+	if threat == nil {
 		return nil
 	}
+	if _, tracked := p.threats.Get(threat.ID); !tracked {
+		if threat = p.promoteNextTarget(); threat == nil {
+			return nil
+		}
+	}
 
-	decision, err := p.decisionMaker.MakeCombatDecision(
-		ctx,
-		p.location,
-		p.activeThreat,
-		p.getHealthStatus(),
-		p.availableWeapons,
-	)
-	if err != nil {
-		return fmt.Errorf("AI decision error: %v", err)
+	if p.getMode() == common.Emergency {
+		p.retreatFromThreat()
+		p.recordDecision(threat, "retreat", "", SourceHeuristic, "emergency: critical part failing", p.describeOutcome(threat.ID))
+		return nil
+	}
+
+	if p.seekCover(threat) {
+		return nil
+	}
+	if p.isFlanked() {
+		p.respondToFlanking()
+	}
+
+	// The AI is too slow to consult on the movement tick, so use its most
+	// recent decision for this threat and fall back to the heuristics
+	decision, source := p.heuristicCombatDecision(threat), SourceHeuristic
+	if cached := p.cachedCombatDecision(threat.ID); cached != nil {
+		decision, source = cached, SourceAI
 	}
 
 	switch decision.Action {
 	case "move":
-		p.moveTowardsTarget(p.activeThreat.Location)
+		if intercept, _, ok := p.CanIntercept(threat); ok {
+			p.moveTowardsTarget(intercept)
+		} else {
+			p.abandonPursuit(threat)
+		}
 	case "attack":
-		p.executeAttack(decision.Weapon)
+		if !p.hasLineOfSight(threat) {
+			p.repositionForLineOfSight(threat)
+			p.recordDecision(threat, "reposition", "", SourceHeuristic, "line of sight blocked", p.describeOutcome(threat.ID))
+			return nil
+		}
+		p.executeAttack(decision.Weapon, "")
 	case "defend":
-		p.activateDefensiveMeasures()
+		p.activateDefensiveMeasures(threat)
 	case "retreat":
-		p.retreatFromThreat()
+		if err := p.retreat(); err != nil {
+			p.logger.LogError(err, "retreat failed")
+		}
 	}
 
+	p.recordDecision(threat, decision.Action, decision.Weapon, source, decision.Explanation, p.describeOutcome(threat.ID))
+
 	return nil
 }
 
-// executeAttack executes an attack against the current threat
-func (p *Processor) executeAttack(weapon string) {
-	if p.activeThreat == nil || p.activeThreat.Health <= 0 {
-		return
-	}
-
-	// Calculate damage based on weapon type
-	var damage float64
-	switch weapon {
-	case "plasma_cannon":
-		damage = 25.0
-	case "missile":
-		damage = 40.0
-	case "emp_pulse":
-		damage = 15.0
-	case "laser_beam":
-		damage = 20.0
-	default:
-		damage = 10.0
+// recordDecision adds a tactical decision and its inputs to the audit log
+func (p *Processor) recordDecision(threat *common.Threat, action, weapon string, source DecisionSource, explanation, outcome string) {
+	record := DecisionRecord{
+		Timestamp:      p.clock.Now(),
+		ThreatID:       threat.ID,
+		ThreatSeverity: threat.Severity,
+		ThreatHealth:   threat.Health,
+		Distance:       common.CalculateDistance(p.getLocation(), threat.Location),
+		Health:         p.getHealthStatus(),
+		Action:         action,
+		Weapon:         weapon,
+		Source:         source,
+		Explanation:    explanation,
+		Outcome:        outcome,
 	}
+	p.audit.Record(record)
+	p.noteRationale(record)
+}
 
-	// Apply damage to threat
-	p.activeThreat.Health -= damage
-	if p.activeThreat.Health < 0 {
-		p.activeThreat.Health = 0
-	}
-
-	// Log the attack
-	p.logger.Info(fmt.Sprintf("Attacked %s with %s (Damage: %.1f%%, Remaining Health: %.1f%%)",
-		p.activeThreat.ID, weapon, damage, p.activeThreat.Health))
-
-	// If threat is eliminated, clear it
-	if p.activeThreat.Health <= 0 {
-		p.logger.Info(fmt.Sprintf("Threat %s has been eliminated", p.activeThreat.ID))
-		p.activeThreat = nil
-		p.mode = common.Normal
+// describeOutcome summarises the state of a threat after a decision was executed
+func (p *Processor) describeOutcome(threatID string) string {
+	threat := p.GetActiveThreat()
+	if threat == nil || threat.ID != threatID {
+		return "threat eliminated"
 	}
+	return fmt.Sprintf("threat health %.1f%%, distance %.2f meters",
+		threat.Health, common.CalculateDistance(p.getLocation(), threat.Location))
 }
 
-// activateDefensiveMeasures activates defensive systems
-func (p *Processor) activateDefensiveMeasures() {
+// activateDefensiveMeasures raises the critical parts' defenses against the
+// threat. Boosts already up are left to run their course, so a defend
+// decision held across movement ticks does not keep drawing power.
+func (p *Processor) activateDefensiveMeasures(threat *common.Threat) {
+	if len(p.defense.BoostStatus()) > 0 {
+		return
+	}
 	p.logger.Info("Activating defensive measures")
-	// Implement defensive measures
+	p.applyDefenses(threat)
 }
 
-// retreatFromThreat moves away from the current threat
+// retreatFromThreat moves one movement update toward the safe zone, or away
+// from the nearest threat
 func (p *Processor) retreatFromThreat() {
-	p.logger.Info("Retreating from threat")
-	// Implement retreat logic
+	threat := p.GetActiveThreat()
+	if threat == nil {
+		return
+	}
+
+	location := p.drive(p.retreatDestination(p.getLocation()))
+	p.logger.Info(fmt.Sprintf("Retreating from threat. Distance: %.2f meters", common.CalculateDistance(location, threat.Location)))
 }
 
 // getHealthStatus returns the current health status of all parts
 func (p *Processor) getHealthStatus() map[string]float64 {
-	// Implement health status retrieval
-	return map[string]float64{
-		"head":      100.0,
-		"body":      100.0,
-		"arm_left":  100.0,
-		"arm_right": 100.0,
-		"leg_left":  100.0,
-		"leg_right": 100.0,
-	}
-} 
\ No newline at end of file
+	return p.anatomy.GetHealthStatus()
+}
@@ -0,0 +1,43 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+// testStart is the time every test processor's clock starts at
+var testStart = time.Unix(1_700_000_000, 0)
+
+// newTestProcessor returns a processor on a manual clock starting at
+// testStart, customized by the given options
+func newTestProcessor(t *testing.T, opts ...Option) (*Processor, *common.ManualClock) {
+	t.Helper()
+	return newTestProcessorWithConfig(t, DefaultProcessorConfig(), opts...)
+}
+
+// newTestProcessorWithConfig is newTestProcessor with the given configuration
+func newTestProcessorWithConfig(t *testing.T, cfg ProcessorConfig, opts ...Option) (*Processor, *common.ManualClock) {
+	t.Helper()
+	clock := common.NewManualClock(testStart)
+	p, err := NewProcessorWithConfig(context.Background(), cfg, append([]Option{WithClock(clock)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewProcessorWithConfig: %v", err)
+	}
+	return p, clock
+}
+
+// testThreat returns a full-health threat of the given severity at location
+func testThreat(id string, severity int, location common.Location) common.Threat {
+	return common.Threat{ID: id, Type: "physical", Severity: severity, Health: 100, Location: location}
+}
+
+// activate marks the processor as started without running its loops, so
+// tests can drive it step by step
+func activate(p *Processor) {
+	p.status.mu.Lock()
+	defer p.status.mu.Unlock()
+	p.status.active = true
+}
@@ -0,0 +1,61 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+// Run with -race: reporters, readers and the background loops all touch the
+// active threat, location and speed at once
+func TestConcurrentReportAndReadActiveThreat(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := p.Stop(ctx); err != nil {
+			t.Errorf("Stop: %v", err)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				threat := testThreat(fmt.Sprintf("g%d-%d", g, i), 5, common.Location{X: 20, Y: float64(g)})
+				if err := p.ReportThreat(threat); err != nil {
+					t.Errorf("ReportThreat: %v", err)
+					return
+				}
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				if threat := p.GetActiveThreat(); threat != nil {
+					threat.Health = -1 // Mutating the copy must not affect the processor
+				}
+				_ = p.getLocation()
+				_ = p.getSpeed()
+			}
+		}()
+	}
+	// Drive the background loops meanwhile
+	for i := 0; i < 20; i++ {
+		clock.Advance(100 * time.Millisecond)
+	}
+	wg.Wait()
+
+	if threat := p.GetActiveThreat(); threat != nil && threat.Health < 0 {
+		t.Error("a caller's copy of the active threat changed the processor's")
+	}
+}
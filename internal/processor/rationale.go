@@ -0,0 +1,85 @@
+package processor
+
+import "time"
+
+// Rationale is a structured explanation of why the processor took its most
+// recent action
+type Rationale struct {
+	Timestamp       time.Time         `json:"timestamp"`
+	ThreatID        string            `json:"threat_id"`
+	Action          string            `json:"action"`
+	Weapons         []string          `json:"weapons,omitempty"`
+	Source          DecisionSource    `json:"source"`
+	AIExplanation   string            `json:"ai_explanation,omitempty"`
+	HeuristicReason string            `json:"heuristic_reason,omitempty"`
+	FallbackReason  string            `json:"fallback_reason,omitempty"`
+	Doctrine        TargetingDoctrine `json:"doctrine"`
+	Posture         Posture           `json:"posture"`
+	Escalation      EscalationLevel   `json:"escalation"`
+	Outcome         string            `json:"outcome"`
+}
+
+// combatActions are the actions that would have come from the AI if it had answered
+var combatActions = map[string]bool{
+	"move":    true,
+	"attack":  true,
+	"defend":  true,
+	"retreat": true,
+}
+
+// LastDecisionRationale returns the explanation for the most recent decision
+func (p *Processor) LastDecisionRationale() Rationale {
+	p.rationaleMu.RLock()
+	defer p.rationaleMu.RUnlock()
+
+	rationale := p.rationale
+	rationale.Weapons = append([]string(nil), p.rationale.Weapons...)
+	return rationale
+}
+
+// noteRationaleWeapons records the weapons actually fired for the decision
+// on a threat, such as those chosen for a coordinated volley
+func (p *Processor) noteRationaleWeapons(threatID string, weapons []string) {
+	p.rationaleMu.Lock()
+	defer p.rationaleMu.Unlock()
+
+	if p.rationale.ThreatID == threatID && len(weapons) > 0 {
+		p.rationale.Weapons = append([]string(nil), weapons...)
+	}
+}
+
+// noteRationale records the rationale behind a decision as it is audited
+func (p *Processor) noteRationale(record DecisionRecord) {
+	rationale := Rationale{
+		Timestamp:  record.Timestamp,
+		ThreatID:   record.ThreatID,
+		Action:     record.Action,
+		Source:     record.Source,
+		Doctrine:   p.config.Targeting,
+		Posture:    p.getPosture(),
+		Escalation: p.escalationLevel(record.ThreatID),
+		Outcome:    record.Outcome,
+	}
+	if record.Weapon != "" {
+		rationale.Weapons = []string{record.Weapon}
+	}
+
+	switch record.Source {
+	case SourceAI:
+		rationale.AIExplanation = record.Explanation
+	case SourceHeuristic:
+		rationale.HeuristicReason = record.Explanation
+		switch {
+		case !p.AIEnabled():
+			rationale.FallbackReason = "AI disabled, heuristics used"
+		case combatActions[record.Action]:
+			rationale.FallbackReason = "no AI decision available yet, heuristics used"
+		}
+	default:
+		rationale.HeuristicReason = record.Explanation
+	}
+
+	p.rationaleMu.Lock()
+	p.rationale = rationale
+	p.rationaleMu.Unlock()
+}
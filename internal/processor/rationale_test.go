@@ -0,0 +1,62 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"t800/internal/ai"
+	"t800/internal/common"
+	"t800/internal/monitoring"
+)
+
+// engageActiveThreat makes a threat the active target and runs one
+// movement-loop engagement against it
+func engageActiveThreat(t *testing.T, p *Processor) {
+	t.Helper()
+	threat := testThreat("t1", 8, common.Location{X: 20})
+	p.AddThreat(threat)
+	p.setActiveThreat(&threat)
+	p.escalate(threat.ID, EscalationFullEngagement)
+	if err := p.moveAndEngageWithAI(context.Background()); err != nil {
+		t.Fatalf("moveAndEngageWithAI: %v", err)
+	}
+}
+
+func TestRationaleCarriesAIExplanationAndWeapon(t *testing.T) {
+	decisionMaker, err := ai.NewDecisionMakerWithOptions(monitoring.NewLogger(), ai.Options{})
+	if err != nil {
+		t.Fatalf("NewDecisionMakerWithOptions: %v", err)
+	}
+	p, clock := newTestProcessor(t, WithDecisionMaker(decisionMaker))
+	activate(p)
+
+	// Stand in for the background refresh having consulted the AI
+	p.combatDecision = cachedDecision{
+		threatID: "t1",
+		decision: &ai.CombatDecision{Action: "attack", Target: "t1", Weapon: "plasma_cannon", Explanation: "close enough for plasma"},
+		at:       clock.Now(),
+	}
+	engageActiveThreat(t, p)
+
+	rationale := p.LastDecisionRationale()
+	if rationale.Source != SourceAI || rationale.AIExplanation != "close enough for plasma" {
+		t.Errorf("rationale source %s, explanation %q; want the AI's explanation", rationale.Source, rationale.AIExplanation)
+	}
+	if len(rationale.Weapons) != 1 || rationale.Weapons[0] != "plasma_cannon" {
+		t.Errorf("rationale weapons = %v, want [plasma_cannon]", rationale.Weapons)
+	}
+}
+
+func TestRationaleNotesHeuristicFallback(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	activate(p)
+	engageActiveThreat(t, p)
+
+	rationale := p.LastDecisionRationale()
+	if rationale.Source != SourceHeuristic || rationale.HeuristicReason == "" {
+		t.Errorf("rationale = %+v, want a heuristic reason", rationale)
+	}
+	if rationale.FallbackReason != "AI disabled, heuristics used" {
+		t.Errorf("fallback reason = %q, want the AI noted as disabled", rationale.FallbackReason)
+	}
+}
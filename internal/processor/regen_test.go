@@ -0,0 +1,79 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"t800/internal/anatomy"
+	"t800/internal/common"
+)
+
+func TestNoRegenDuringCombat(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	arm := p.anatomy.Arms[0]
+	p.setMode(common.Combat)
+	p.healthTick(time.Second)
+
+	arm.Expose(50)
+	damaged := arm.GetHealth()
+	for i := 0; i < 5; i++ {
+		clock.Advance(time.Second)
+		p.healthTick(time.Second)
+	}
+	if got := arm.GetHealth(); got != damaged {
+		t.Fatalf("arm health in combat = %v, want %v", got, damaged)
+	}
+
+	p.setMode(common.Normal)
+	clock.Advance(time.Second)
+	p.healthTick(time.Second)
+	if got := arm.GetHealth(); got <= damaged {
+		t.Errorf("arm health after combat = %v, want regeneration above %v", got, damaged)
+	}
+}
+
+func TestRegenInCombatWhenConfigured(t *testing.T) {
+	cfg := DefaultProcessorConfig()
+	cfg.RegenInCombat = true
+	p, clock := newTestProcessorWithConfig(t, cfg)
+	arm := p.anatomy.Arms[0]
+	p.setMode(common.Combat)
+	p.healthTick(time.Second)
+
+	arm.Expose(50)
+	damaged := arm.GetHealth()
+	clock.Advance(time.Second)
+	p.healthTick(time.Second)
+	if got := arm.GetHealth(); got <= damaged {
+		t.Errorf("arm health = %v, want regeneration above %v", got, damaged)
+	}
+}
+
+func TestNanobotsSpeedUpCriticalRecovery(t *testing.T) {
+	passive, passiveClock := newTestProcessor(t)
+	active, activeClock := newTestProcessor(t)
+	policy := anatomy.DefaultNanobotPolicy()
+	policy.Enabled = true
+	active.SetNanobotPolicy(policy)
+
+	for _, p := range []*Processor{passive, active} {
+		if err := p.anatomy.Power.SetRechargeRate(0); err != nil {
+			t.Fatalf("SetRechargeRate: %v", err)
+		}
+		p.healthTick(time.Second)
+		p.anatomy.Head.Expose(50)
+	}
+	for i := 0; i < 3; i++ {
+		passiveClock.Advance(time.Second)
+		activeClock.Advance(time.Second)
+		passive.healthTick(time.Second)
+		active.healthTick(time.Second)
+	}
+
+	if got, without := active.anatomy.Head.GetHealth(), passive.anatomy.Head.GetHealth(); got <= without {
+		t.Errorf("head health with nanobots = %.2f, want above %.2f without", got, without)
+	}
+	if got, without := active.anatomy.Power.Level(), passive.anatomy.Power.Level(); got >= without {
+		t.Errorf("power with nanobots = %.2f, want below %.2f without", got, without)
+	}
+}
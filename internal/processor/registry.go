@@ -0,0 +1,107 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+
+	"t800/internal/common"
+)
+
+// AddThreat adds or refreshes a live threat in the registry without engaging
+// it. The command is checkpointed so it can be undone.
+func (p *Processor) AddThreat(threat common.Threat) {
+	p.command(func() error {
+		p.addThreat(threat)
+		return nil
+	})
+}
+
+// addThreat adds or refreshes a threat without checkpointing
+func (p *Processor) addThreat(threat common.Threat) {
+	threat.Severity = common.ClampSeverity(threat.Severity)
+	p.threats.Add(threat)
+	p.noteSeen(threat.ID)
+	p.noteDetection(threat.ID)
+}
+
+// ReportThreats validates and reports a batch of threats, such as the
+// detections delivered at once by a sensor fusion system. Invalid threats are
+// rejected without affecting the rest; the returned slice holds the error for
+// each threat by position, nil for those reported. The error is non-nil only
+// if the batch could not be reported at all. The whole batch is checkpointed
+// as a single command.
+func (p *Processor) ReportThreats(threats []common.Threat) ([]error, error) {
+	if !p.IsActive() {
+		return nil, fmt.Errorf("system is not active")
+	}
+
+	errs := make([]error, len(threats))
+	p.command(func() error {
+		for i, threat := range threats {
+			if err := threat.Validate(); err != nil {
+				errs[i] = err
+				continue
+			}
+			errs[i] = p.reportThreat(threat)
+		}
+		return nil
+	})
+	return errs, nil
+}
+
+// RemoveThreat drops a threat from the registry, promoting the next target
+// if it was the primary. It reports whether the threat was tracked. The
+// command is checkpointed so it can be undone.
+func (p *Processor) RemoveThreat(id string) bool {
+	var removed bool
+	p.command(func() error {
+		removed = p.removeThreat(id)
+		return nil
+	})
+	return removed
+}
+
+// removeThreat drops a threat without checkpointing
+func (p *Processor) removeThreat(id string) bool {
+	removed := p.threats.Remove(id)
+	p.forgetEscalation(id)
+	if p.isActiveThreat(id) {
+		p.promoteNextTarget()
+	}
+	return removed
+}
+
+// ListThreats returns every live threat, highest scoring first
+func (p *Processor) ListThreats() []common.Threat {
+	threats := p.threats.List()
+	sort.SliceStable(threats, func(i, j int) bool {
+		return p.threatScore(&threats[i]) > p.threatScore(&threats[j])
+	})
+	return threats
+}
+
+// selectPrimary returns the highest scoring threat still standing whose
+// engagement budget is not exhausted, or nil
+func (p *Processor) selectPrimary() *common.Threat {
+	for _, threat := range p.ListThreats() {
+		if threat.Health > 0 && !p.overBudget(threat.ID) {
+			return &threat
+		}
+	}
+	return nil
+}
+
+// promoteNextTarget makes the highest scoring remaining threat the primary
+// target, returning to normal mode only once no threats remain
+func (p *Processor) promoteNextTarget() *common.Threat {
+	next := p.selectPrimary()
+	p.setActiveThreat(next)
+	if next == nil {
+		p.setMode(common.Normal)
+		return nil
+	}
+
+	p.logger.Info(fmt.Sprintf("Promoting %s to primary target (Severity: %d)", next.ID, next.Severity))
+	p.setMode(common.Combat)
+	return next
+}
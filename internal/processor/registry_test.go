@@ -0,0 +1,100 @@
+package processor
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestEliminatingPrimaryRetargetsNextThreat(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	for _, threat := range []common.Threat{
+		testThreat("weak", 3, common.Location{X: 40}),
+		testThreat("top", 9, common.Location{X: 20}),
+		testThreat("second", 7, common.Location{X: 20}),
+	} {
+		p.AddThreat(threat)
+	}
+	if listed := p.ListThreats(); len(listed) != 3 || listed[0].ID != "top" || listed[1].ID != "second" {
+		t.Fatalf("ListThreats = %v, want top, second, weak", listed)
+	}
+
+	primary := p.promoteNextTarget()
+	if primary == nil || primary.ID != "top" {
+		t.Fatalf("primary = %v, want top", primary)
+	}
+
+	p.eliminateThreat(primary)
+	if active := p.GetActiveThreat(); active == nil || active.ID != "second" {
+		t.Fatalf("active threat after eliminating top = %v, want second", active)
+	}
+	if mode := p.getMode(); mode != common.Combat {
+		t.Errorf("mode with threats remaining = %v, want combat", mode)
+	}
+
+	p.RemoveThreat("second")
+	p.RemoveThreat("weak")
+	if active := p.GetActiveThreat(); active != nil {
+		t.Errorf("active threat with none remaining = %s, want none", active.ID)
+	}
+	if mode := p.getMode(); mode != common.Normal {
+		t.Errorf("mode with no threats = %v, want normal", mode)
+	}
+}
+
+func TestReportThreatsRejectsInvalidThreats(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	if _, err := p.ReportThreats([]common.Threat{testThreat("t1", 5, common.Location{X: 20})}); err == nil {
+		t.Error("ReportThreats succeeded while the system was inactive")
+	}
+	activate(p)
+
+	nan := testThreat("nan", 5, common.Location{X: 20})
+	nan.Location.Y = math.NaN()
+	batch := []common.Threat{
+		testThreat("ok1", 5, common.Location{X: 20}),
+		testThreat("negative", -1, common.Location{X: 20}),
+		testThreat("", 5, common.Location{X: 20}),
+		nan,
+		testThreat("ok2", 8, common.Location{X: 40}),
+	}
+	errs, err := p.ReportThreats(batch)
+	if err != nil {
+		t.Fatalf("ReportThreats: %v", err)
+	}
+	if len(errs) != len(batch) {
+		t.Fatalf("got %d errors for %d threats", len(errs), len(batch))
+	}
+	for i, threat := range batch {
+		valid := threat.ID == "ok1" || threat.ID == "ok2"
+		if (errs[i] == nil) != valid {
+			t.Errorf("threat %d (%q): error %v, want valid %v", i, threat.ID, errs[i], valid)
+		}
+		if _, exists := p.threats.Get(threat.ID); exists != valid {
+			t.Errorf("threat %d (%q) tracked %v, want %v", i, threat.ID, exists, valid)
+		}
+	}
+	if p.threats.Len() != 2 {
+		t.Errorf("registry holds %d threats, want 2", p.threats.Len())
+	}
+}
+
+func TestIngestedSeverityIsClamped(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	activate(p)
+
+	if err := p.ReportThreat(testThreat("huge", 9999, common.Location{X: 20})); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+	p.AddThreat(testThreat("negative", -3, common.Location{X: 40}))
+
+	for id, want := range map[string]int{"huge": common.MaxSeverity, "negative": common.MinSeverity} {
+		if stored, _ := p.threats.Get(id); stored.Severity != want {
+			t.Errorf("%s stored with severity %d, want %d", id, stored.Severity, want)
+		}
+	}
+	if active := p.GetActiveThreat(); active == nil || active.Severity != common.MaxSeverity {
+		t.Errorf("active threat = %v, want severity %d", active, common.MaxSeverity)
+	}
+}
@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+
+	"t800/internal/common"
+)
+
+// Retreat breaks off the engagement: critical parts are shielded, offense is
+// suspended and the robot falls back to the safe zone, or directly away from
+// the nearest threat. It re-engages once it is a safe distance from every
+// threat and its critical parts have recovered. The command is checkpointed
+// so it can be undone.
+func (p *Processor) Retreat() error {
+	return p.command(p.retreat)
+}
+
+// retreat breaks off the engagement without checkpointing
+func (p *Processor) retreat() error {
+	switch mode := p.getMode(); mode {
+	case common.Retreating:
+		return nil
+	case common.Normal, common.Combat:
+	default:
+		return fmt.Errorf("cannot retreat in %s mode", mode)
+	}
+
+	p.logger.Info("Retreating")
+	p.setMode(common.Retreating)
+	p.shieldCriticalParts()
+	return nil
+}
+
+// nearestThreat returns the closest live tracked threat and its distance
+func (p *Processor) nearestThreat(location common.Location) (common.Threat, float64, bool) {
+	var (
+		nearest  common.Threat
+		distance = math.Inf(1)
+		found    bool
+	)
+	for _, threat := range p.threats.List() {
+		if threat.Health <= 0 {
+			continue
+		}
+		if d := common.CalculateDistance(location, threat.Location); d < distance {
+			nearest, distance, found = threat, d, true
+		}
+	}
+	return nearest, distance, found
+}
+
+// retreatDestination returns where a retreat heads: the safe zone if one is
+// set, otherwise the point mirroring the nearest threat through the robot
+func (p *Processor) retreatDestination(location common.Location) common.Location {
+	if zone := p.config.SafeZone; zone != nil {
+		return *zone
+	}
+
+	threat, _, found := p.nearestThreat(location)
+	if !found {
+		if active := p.GetActiveThreat(); active != nil {
+			threat, found = *active, true
+		}
+	}
+	if !found {
+		return location
+	}
+	return common.Location{
+		X: 2*location.X - threat.Location.X,
+		Y: 2*location.Y - threat.Location.Y,
+		Z: location.Z,
+	}
+}
+
+// retreatTick moves a retreating robot one movement update toward safety, or
+// re-engages once it is safe and its critical parts have recovered
+func (p *Processor) retreatTick() {
+	location := p.getLocation()
+	_, distance, found := p.nearestThreat(location)
+	safe := !found || distance >= p.config.SafeDistance
+
+	if lowest := p.lowestCriticalHealth(); safe && lowest >= p.config.RetreatRecoveryHealth {
+		p.endRetreat(lowest)
+		return
+	}
+	if safe && p.config.SafeZone == nil {
+		return
+	}
+
+	location = p.drive(p.retreatDestination(location))
+	if _, distance, found = p.nearestThreat(location); found {
+		p.logger.Info(fmt.Sprintf("Retreating from threat. Distance: %.2f meters", distance))
+	}
+}
+
+// endRetreat returns to combat if a threat is still targeted, else to normal
+func (p *Processor) endRetreat(lowest float64) {
+	mode := common.Normal
+	if p.GetActiveThreat() != nil {
+		mode = common.Combat
+	}
+	p.logger.Info(fmt.Sprintf("Critical parts recovered to %.2f%%, ending retreat for %s", lowest, mode))
+	p.setMode(mode)
+}
+
+// holdFireWhileRetreating reports whether offense is suspended by a retreat
+func (p *Processor) holdFireWhileRetreating() bool {
+	return p.getMode() == common.Retreating
+}
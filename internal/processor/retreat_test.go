@@ -0,0 +1,93 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+// startRetreat reports a threat, wounds the head below the recovery health
+// and retreats
+func startRetreat(t *testing.T, p *Processor, threat common.Threat) {
+	t.Helper()
+	activate(p)
+	if err := p.ReportThreat(threat); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+	p.anatomy.Head.Expose(50)
+	if err := p.Retreat(); err != nil {
+		t.Fatalf("Retreat: %v", err)
+	}
+	if mode := p.getMode(); mode != common.Retreating {
+		t.Fatalf("mode after Retreat = %s, want retreating", mode)
+	}
+}
+
+func TestRetreatMovesAwayFromThreat(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	threat := testThreat("t1", 7, common.Location{X: 10})
+	shield := p.anatomy.Head.Protection().ShieldStrength
+	startRetreat(t, p, threat)
+
+	if got := p.anatomy.Head.Protection().ShieldStrength; got <= shield {
+		t.Errorf("head shield while retreating = %.2f, want above %.2f", got, shield)
+	}
+	if !p.holdFireWhileRetreating() {
+		t.Error("offense not suspended while retreating")
+	}
+
+	distance := common.CalculateDistance(p.getLocation(), threat.Location)
+	for i := 0; i < 5; i++ {
+		p.retreatTick()
+		next := common.CalculateDistance(p.getLocation(), threat.Location)
+		if next <= distance {
+			t.Fatalf("tick %d: distance from threat %.2f, was %.2f; want it growing", i, next, distance)
+		}
+		distance = next
+	}
+	if location := p.getLocation(); location.X >= 0 {
+		t.Errorf("robot retreated to %+v, want it moving away along -X", location)
+	}
+}
+
+func TestRetreatHeadsForSafeZone(t *testing.T) {
+	zone := common.Location{Y: -200}
+	p, _ := newTestProcessor(t, WithSafeZone(zone))
+	startRetreat(t, p, testThreat("t1", 7, common.Location{X: 10}))
+
+	distance := common.CalculateDistance(p.getLocation(), zone)
+	for i := 0; i < 5; i++ {
+		p.retreatTick()
+		next := common.CalculateDistance(p.getLocation(), zone)
+		if next >= distance {
+			t.Fatalf("tick %d: distance to safe zone %.2f, was %.2f; want it shrinking", i, next, distance)
+		}
+		distance = next
+	}
+}
+
+func TestRetreatEndsOnceSafeAndRecovered(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	threat := testThreat("t1", 7, common.Location{X: 10})
+	startRetreat(t, p, threat)
+
+	threat.Location = common.Location{X: 500}
+	if err := p.threats.Update(threat); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	p.retreatTick()
+	if mode := p.getMode(); mode != common.Retreating {
+		t.Fatalf("mode while still wounded = %s, want retreating", mode)
+	}
+
+	p.anatomy.Head.Heal(50)
+	p.retreatTick()
+	if mode := p.getMode(); mode != common.Combat {
+		t.Errorf("mode once safe and recovered = %s, want combat", mode)
+	}
+
+	p.enterEmergency(10)
+	if err := p.Retreat(); err == nil {
+		t.Error("Retreat during an emergency succeeded")
+	}
+}
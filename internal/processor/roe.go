@@ -0,0 +1,35 @@
+package processor
+
+import (
+	"fmt"
+
+	"t800/internal/common"
+)
+
+// ROE holds the rules of engagement: policy limits on when the robot may
+// fire, independent of what its weapons are capable of
+type ROE struct {
+	// MaxEngagementRange is the farthest a threat may be and still be fired
+	// upon; zero means no limit beyond weapon range
+	MaxEngagementRange float64
+}
+
+// withinROE reports whether the rules of engagement permit firing on a threat
+func (p *Processor) withinROE(threat *common.Threat) bool {
+	limit := p.config.ROE.MaxEngagementRange
+	if limit <= 0 {
+		return true
+	}
+	return common.CalculateDistance(p.getLocation(), threat.Location) <= limit
+}
+
+// holdFireOutsideROE logs and reports whether firing on a threat must be
+// withheld because it lies beyond the ROE engagement range
+func (p *Processor) holdFireOutsideROE(threat *common.Threat) bool {
+	if p.withinROE(threat) {
+		return false
+	}
+	p.logger.Info(fmt.Sprintf("Holding fire on %s: beyond ROE engagement range of %.2f meters",
+		threat.ID, p.config.ROE.MaxEngagementRange))
+	return true
+}
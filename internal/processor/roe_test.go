@@ -0,0 +1,40 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+func TestROEMaxRangeHoldsMissileFire(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		limit float64
+		fires bool
+	}{
+		{"no limit", 0, true},
+		{"40m limit", 40, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultProcessorConfig()
+			cfg.ROE.MaxEngagementRange = tc.limit
+			p, _ := newTestProcessorWithConfig(t, cfg)
+			activate(p)
+
+			pool := offense.AmmoPool(offense.MissileWeapon, offense.HighExplosive)
+			before, _ := p.offense.Ammo(pool)
+			if err := p.ReportThreat(testThreat("t1", 5, common.Location{X: 60})); err != nil {
+				t.Fatalf("ReportThreat: %v", err)
+			}
+			after, _ := p.offense.Ammo(pool)
+
+			if fired := after < before; fired != tc.fires {
+				t.Errorf("missile fired at a 60m threat = %v, want %v", fired, tc.fires)
+			}
+			if _, tracked := p.threats.Get("t1"); !tracked {
+				t.Error("threat beyond the ROE limit is not tracked")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package processor
+
+import (
+	"fmt"
+
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+// SalvageConfig controls recovering resources from the wrecks of eliminated
+// threats. Wrecks are only salvaged while the robot is running low.
+type SalvageConfig struct {
+	Enabled bool
+	// LowPower is the power level, as a fraction of capacity, below which
+	// the robot salvages
+	LowPower float64
+	// LowAmmo is the remaining rounds of any limited weapon below which the
+	// robot salvages
+	LowAmmo int
+	// Power is the energy recovered from each wreck
+	Power float64
+	// Ammo is the rounds recovered from each wreck by weapon
+	Ammo map[string]int
+	// Radius is how close the robot must get to a wreck to salvage it
+	Radius float64
+}
+
+// DefaultSalvageConfig returns the default salvage configuration
+func DefaultSalvageConfig() SalvageConfig {
+	return SalvageConfig{
+		Enabled:  true,
+		LowPower: 0.25,
+		LowAmmo:  2,
+		Power:    100.0,
+		Ammo:     map[string]int{"missile": 2},
+		Radius:   1.0,
+	}
+}
+
+// resourcesLow reports whether power or any limited ammunition is low
+// enough to justify salvaging
+func (p *Processor) resourcesLow() bool {
+	cfg := p.config.Salvage
+	if p.anatomy.Power.Level() < cfg.LowPower*p.anatomy.Power.Capacity() {
+		return true
+	}
+	for _, rounds := range p.offense.AmmoStatus() {
+		if rounds < cfg.LowAmmo {
+			return true
+		}
+	}
+	return false
+}
+
+// queueSalvage marks an eliminated threat's wreck for salvage if salvage is
+// enabled and the robot is low on resources
+func (p *Processor) queueSalvage(site common.Location) {
+	if !p.config.Salvage.Enabled || !p.resourcesLow() {
+		return
+	}
+
+	p.salvageMu.Lock()
+	defer p.salvageMu.Unlock()
+	p.salvageSites = append(p.salvageSites, site)
+}
+
+// nextSalvageSite returns the oldest wreck awaiting salvage
+func (p *Processor) nextSalvageSite() (common.Location, bool) {
+	p.salvageMu.Lock()
+	defer p.salvageMu.Unlock()
+
+	if len(p.salvageSites) == 0 {
+		return common.Location{}, false
+	}
+	return p.salvageSites[0], true
+}
+
+// salvage moves toward a wreck and, once within reach, recovers the
+// configured power and ammunition before resuming patrol
+func (p *Processor) salvage(loc common.Location) {
+	cfg := p.config.Salvage
+	if common.CalculateDistance(p.getLocation(), loc) > cfg.Radius {
+		p.moveTowardsTarget(loc)
+		if common.CalculateDistance(p.getLocation(), loc) > cfg.Radius {
+			return
+		}
+	}
+
+	recovered := p.anatomy.Power.Recharge(cfg.Power)
+	for weapon, rounds := range cfg.Ammo {
+		// Salvaged missiles are high-explosive
+		pool := offense.AmmoPool(weapon, offense.HighExplosive)
+		if current, limited := p.offense.Ammo(pool); limited {
+			p.offense.SetAmmo(pool, current+rounds)
+		}
+	}
+	p.logger.Info(fmt.Sprintf("Salvaged wreck at (%.2f, %.2f, %.2f): recovered %.1f power, resuming patrol",
+		loc.X, loc.Y, loc.Z, recovered))
+
+	p.salvageMu.Lock()
+	if len(p.salvageSites) > 0 && p.salvageSites[0] == loc {
+		p.salvageSites = p.salvageSites[1:]
+	}
+	p.salvageMu.Unlock()
+}
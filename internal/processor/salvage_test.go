@@ -0,0 +1,46 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+	"t800/internal/offense"
+)
+
+func TestLowAmmoRobotSalvagesWreck(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	he := offense.AmmoPool(offense.MissileWeapon, offense.HighExplosive)
+	cluster := offense.AmmoPool(offense.MissileWeapon, offense.Cluster)
+	p.offense.SetAmmo(he, 0)
+	clusterBefore, _ := p.offense.Ammo(cluster)
+
+	wreck := testThreat("t1", 5, common.Location{X: 5})
+	p.AddThreat(wreck)
+	p.eliminateThreat(&wreck)
+	site, queued := p.nextSalvageSite()
+	if !queued || site != wreck.Location {
+		t.Fatalf("salvage site = %+v, %v; want the wreck at %+v", site, queued, wreck.Location)
+	}
+
+	start := p.getLocation()
+	p.salvage(site)
+	if moved := p.getLocation(); common.CalculateDistance(moved, site) >= common.CalculateDistance(start, site) {
+		t.Fatalf("robot moved from %+v to %+v, want toward the wreck", start, moved)
+	}
+	for i := 0; i < 1000; i++ {
+		if _, pending := p.nextSalvageSite(); !pending {
+			break
+		}
+		p.salvage(site)
+	}
+	if _, pending := p.nextSalvageSite(); pending {
+		t.Fatalf("wreck not salvaged, robot at %+v", p.getLocation())
+	}
+
+	if rounds, _ := p.offense.Ammo(he); rounds != 2 {
+		t.Errorf("high-explosive missiles after salvage = %d, want 2", rounds)
+	}
+	if rounds, _ := p.offense.Ammo(cluster); rounds != clusterBefore {
+		t.Errorf("cluster missiles after salvage = %d, want %d", rounds, clusterBefore)
+	}
+}
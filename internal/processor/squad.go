@@ -0,0 +1,214 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"t800/internal/common"
+)
+
+// Squad coordinates several robots: a threat detected by one member is
+// shared with the rest, and each member is assigned a target by proximity so
+// the squad spreads across threats instead of piling onto the same one
+type Squad struct {
+	mu          sync.Mutex
+	members     map[string]*Processor    // by squad ID
+	threats     map[string]common.Threat // shared picture, by threat ID
+	assignments map[string]string        // assigned threat ID by squad ID
+}
+
+// NewSquad creates a squad with no members
+func NewSquad() *Squad {
+	return &Squad{
+		members:     make(map[string]*Processor),
+		threats:     make(map[string]common.Threat),
+		assignments: make(map[string]string),
+	}
+}
+
+// Join registers a processor under an ID unique within the squad. The
+// processor learns every threat the squad already knows of and shares those
+// it detects from then on. A processor belongs to at most one squad.
+func (s *Squad) Join(squadID string, p *Processor) error {
+	if squadID == "" {
+		return fmt.Errorf("squad ID is required")
+	}
+
+	p.squadMu.Lock()
+	defer p.squadMu.Unlock()
+	if p.squad != nil {
+		return fmt.Errorf("processor already in squad as %s", p.squadID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.members[squadID]; exists {
+		return fmt.Errorf("squad ID %s is already taken", squadID)
+	}
+	s.members[squadID] = p
+	p.squad, p.squadID = s, squadID
+
+	for _, threat := range s.threats {
+		p.addThreat(threat)
+	}
+	s.assign()
+	return nil
+}
+
+// Leave removes a member from the squad and reassigns targets among the rest
+func (s *Squad) Leave(squadID string) {
+	s.mu.Lock()
+	p, exists := s.members[squadID]
+	if exists {
+		delete(s.members, squadID)
+		delete(s.assignments, squadID)
+		s.assign()
+	}
+	s.mu.Unlock()
+
+	if exists {
+		p.squadMu.Lock()
+		p.squad, p.squadID = nil, ""
+		p.squadMu.Unlock()
+	}
+}
+
+// Publish shares a threat detected by a member with the rest of the squad,
+// adding it to their registries without engaging it, and reassigns targets
+func (s *Squad) Publish(from string, threat common.Threat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.threats[threat.ID] = threat
+	for squadID, member := range s.members {
+		if squadID != from {
+			member.addThreat(threat)
+		}
+	}
+	s.assign()
+}
+
+// Remove drops an eliminated threat from the squad and from every member's
+// registry, and reassigns targets
+func (s *Squad) Remove(threatID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, known := s.threats[threatID]; !known {
+		return
+	}
+	delete(s.threats, threatID)
+	for _, member := range s.members {
+		member.removeThreat(threatID)
+	}
+	s.assign()
+}
+
+// Assignment returns the threat assigned to a member, if any, by the
+// members' current positions
+func (s *Squad) Assignment(squadID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assign()
+	threatID, assigned := s.assignments[squadID]
+	return threatID, assigned
+}
+
+// Assignments returns the threat assigned to each member by squad ID, by
+// the members' current positions
+func (s *Squad) Assignments() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assign()
+
+	assignments := make(map[string]string, len(s.assignments))
+	for squadID, threatID := range s.assignments {
+		assignments[squadID] = threatID
+	}
+	return assignments
+}
+
+// assign gives each member a target, pairing the closest member and threat
+// first so every threat is covered before any is doubled up; with more
+// members than threats the rest take their nearest threat. The caller must
+// hold s.mu.
+func (s *Squad) assign() {
+	type pairing struct {
+		squadID, threatID string
+		distance          float64
+	}
+
+	var pairings []pairing
+	for squadID, member := range s.members {
+		location := member.getLocation()
+		for threatID, threat := range s.threats {
+			pairings = append(pairings, pairing{squadID, threatID, common.CalculateDistance(location, threat.Location)})
+		}
+	}
+	sort.Slice(pairings, func(i, j int) bool {
+		a, b := pairings[i], pairings[j]
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		if a.squadID != b.squadID {
+			return a.squadID < b.squadID
+		}
+		return a.threatID < b.threatID
+	})
+
+	s.assignments = make(map[string]string, len(s.members))
+	covered := make(map[string]bool, len(s.threats))
+	for _, pair := range pairings {
+		if _, assigned := s.assignments[pair.squadID]; assigned || covered[pair.threatID] {
+			continue
+		}
+		s.assignments[pair.squadID] = pair.threatID
+		covered[pair.threatID] = true
+	}
+
+	for _, pair := range pairings {
+		if _, assigned := s.assignments[pair.squadID]; !assigned {
+			s.assignments[pair.squadID] = pair.threatID
+		}
+	}
+}
+
+// SquadID returns the processor's ID within its squad, or "" if it has not
+// joined one
+func (p *Processor) SquadID() string {
+	p.squadMu.RLock()
+	defer p.squadMu.RUnlock()
+	return p.squadID
+}
+
+// AssignedTarget returns the ID of the threat the squad assigned the
+// processor, if it belongs to a squad and has been assigned one
+func (p *Processor) AssignedTarget() (string, bool) {
+	squad, squadID := p.currentSquad()
+	if squad == nil {
+		return "", false
+	}
+	return squad.Assignment(squadID)
+}
+
+// currentSquad returns the processor's squad and ID within it, if any
+func (p *Processor) currentSquad() (*Squad, string) {
+	p.squadMu.RLock()
+	defer p.squadMu.RUnlock()
+	return p.squad, p.squadID
+}
+
+// shareThreat publishes a detected threat to the processor's squad, if any
+func (p *Processor) shareThreat(threat common.Threat) {
+	if squad, squadID := p.currentSquad(); squad != nil {
+		squad.Publish(squadID, threat)
+	}
+}
+
+// shareElimination tells the processor's squad, if any, a threat is gone
+func (p *Processor) shareElimination(threatID string) {
+	if squad, _ := p.currentSquad(); squad != nil {
+		squad.Remove(threatID)
+	}
+}
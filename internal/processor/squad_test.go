@@ -0,0 +1,65 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestSquadAssignsDistinctTargets(t *testing.T) {
+	alpha, _ := newTestProcessor(t)
+	bravo, _ := newTestProcessor(t, WithInitialLocation(common.Location{X: 5}))
+	activate(alpha)
+	activate(bravo)
+
+	squad := NewSquad()
+	if err := squad.Join("alpha", alpha); err != nil {
+		t.Fatalf("Join alpha: %v", err)
+	}
+	if err := squad.Join("bravo", bravo); err != nil {
+		t.Fatalf("Join bravo: %v", err)
+	}
+	if err := squad.Join("bravo", alpha); err == nil {
+		t.Error("processor joined a second time")
+	}
+
+	// Both threats are closer to bravo, but the squad still spreads out
+	if err := alpha.ReportThreat(testThreat("t1", 5, common.Location{X: 10})); err != nil {
+		t.Fatalf("ReportThreat t1: %v", err)
+	}
+	if err := bravo.ReportThreat(testThreat("t2", 5, common.Location{X: 50})); err != nil {
+		t.Fatalf("ReportThreat t2: %v", err)
+	}
+	for _, p := range []*Processor{alpha, bravo} {
+		for _, id := range []string{"t1", "t2"} {
+			if _, exists := p.threats.Get(id); !exists {
+				t.Errorf("%s does not know of shared threat %s", p.SquadID(), id)
+			}
+		}
+	}
+
+	a, aok := alpha.AssignedTarget()
+	b, bok := bravo.AssignedTarget()
+	if !aok || !bok {
+		t.Fatalf("assignments = %q (%v), %q (%v); want both assigned", a, aok, b, bok)
+	}
+	if a != "t2" || b != "t1" {
+		t.Errorf("alpha assigned %s, bravo %s; want t2 and t1", a, b)
+	}
+
+	squad.Remove("t1")
+	if _, exists := bravo.threats.Get("t1"); exists {
+		t.Error("eliminated threat still tracked by a squad member")
+	}
+	if a, b := squad.Assignments()["alpha"], squad.Assignments()["bravo"]; a != "t2" || b != "t2" {
+		t.Errorf("after t1 is gone alpha assigned %s, bravo %s; want both on t2", a, b)
+	}
+
+	squad.Leave("bravo")
+	if bravo.SquadID() != "" {
+		t.Errorf("squad ID after leaving = %q, want none", bravo.SquadID())
+	}
+	if _, assigned := bravo.AssignedTarget(); assigned {
+		t.Error("processor outside a squad has an assigned target")
+	}
+}
@@ -0,0 +1,65 @@
+package processor
+
+import (
+	"fmt"
+	"time"
+
+	"t800/internal/common"
+)
+
+// noteSeen records that a threat was reported or re-detected now, renewing
+// its expiry
+func (p *Processor) noteSeen(threatID string) {
+	p.seenMu.Lock()
+	defer p.seenMu.Unlock()
+	p.lastSeen[threatID] = p.clock.Now()
+}
+
+// pruneExpiredThreats drops threats that have been neither reported nor
+// re-detected within the configured timeout, returning the IDs dropped.
+// Threats seen for the first time start their expiry now. Once no threats
+// remain the processor leaves combat.
+func (p *Processor) pruneExpiredThreats() []string {
+	timeout := p.config.ThreatTimeout
+	if timeout <= 0 {
+		return nil
+	}
+
+	now := p.clock.Now()
+	var expired []string
+	p.seenMu.Lock()
+	tracked := make(map[string]bool)
+	for _, threat := range p.threats.List() {
+		tracked[threat.ID] = true
+		seen, ok := p.lastSeen[threat.ID]
+		if !ok {
+			p.lastSeen[threat.ID] = now
+			continue
+		}
+		if now.Sub(seen) >= timeout {
+			expired = append(expired, threat.ID)
+		}
+	}
+	for id := range p.lastSeen {
+		if !tracked[id] {
+			delete(p.lastSeen, id)
+		}
+	}
+	for _, id := range expired {
+		delete(p.lastSeen, id)
+	}
+	p.seenMu.Unlock()
+
+	for _, id := range expired {
+		p.logger.Info(fmt.Sprintf("Threat %s expired: not seen for %v", id, timeout.Round(time.Millisecond)))
+		p.removeThreat(id)
+	}
+	if len(expired) > 0 && p.threats.Len() == 0 {
+		p.setActiveThreat(nil)
+		if p.getMode() == common.Combat {
+			p.logger.Info("No threats remain, returning to normal mode")
+			p.setMode(common.Normal)
+		}
+	}
+	return expired
+}
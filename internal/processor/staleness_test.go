@@ -0,0 +1,61 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestUnseenThreatExpiresAndModeReturnsToNormal(t *testing.T) {
+	cfg := DefaultProcessorConfig()
+	cfg.ThreatTimeout = 10 * time.Second
+	p, clock := newTestProcessorWithConfig(t, cfg)
+	activate(p)
+
+	if err := p.ReportThreat(testThreat("t1", 7, common.Location{X: 30})); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+	clock.Advance(8 * time.Second)
+	if expired := p.pruneExpiredThreats(); len(expired) != 0 {
+		t.Fatalf("threats expired before the timeout: %v", expired)
+	}
+
+	// A fresh report renews the expiry
+	if err := p.ReportThreat(testThreat("t1", 7, common.Location{X: 30})); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+	clock.Advance(8 * time.Second)
+	if expired := p.pruneExpiredThreats(); len(expired) != 0 {
+		t.Fatalf("re-reported threat expired early: %v", expired)
+	}
+	if mode := p.getMode(); mode != common.Combat {
+		t.Fatalf("mode while the threat is fresh = %s, want combat", mode)
+	}
+
+	clock.Advance(2 * time.Second)
+	if expired := p.pruneExpiredThreats(); len(expired) != 1 || expired[0] != "t1" {
+		t.Fatalf("expired %v after the timeout, want t1", expired)
+	}
+	if p.threats.Len() != 0 || p.GetActiveThreat() != nil {
+		t.Error("expired threat still tracked")
+	}
+	if mode := p.getMode(); mode != common.Normal {
+		t.Errorf("mode once no threats remain = %s, want normal", mode)
+	}
+}
+
+func TestZeroThreatTimeoutNeverExpires(t *testing.T) {
+	cfg := DefaultProcessorConfig()
+	cfg.ThreatTimeout = 0
+	p, clock := newTestProcessorWithConfig(t, cfg)
+	activate(p)
+
+	if err := p.ReportThreat(testThreat("t1", 7, common.Location{X: 30})); err != nil {
+		t.Fatalf("ReportThreat: %v", err)
+	}
+	clock.Advance(time.Hour)
+	if expired := p.pruneExpiredThreats(); len(expired) != 0 {
+		t.Errorf("threats expired with no timeout: %v", expired)
+	}
+}
@@ -0,0 +1,91 @@
+package processor
+
+import (
+	"fmt"
+	"time"
+
+	"t800/internal/common"
+)
+
+// scanInterval is how often the environment is scanned when fully awake
+const scanInterval = 500 * time.Millisecond
+
+// EnterStandby puts an idle processor into low-power standby: scanning slows
+// to the configured standby interval and movement and engagement are
+// suspended until a detection wakes it. A processor in combat cannot stand
+// by. The command is checkpointed so it can be undone.
+func (p *Processor) EnterStandby() error {
+	return p.command(p.enterStandby)
+}
+
+// enterStandby switches into standby without checkpointing
+func (p *Processor) enterStandby() error {
+	switch mode := p.getMode(); mode {
+	case common.Standby:
+		return nil
+	case common.Normal:
+	default:
+		return fmt.Errorf("cannot enter standby in %s mode", mode)
+	}
+
+	p.logger.Info("Entering standby")
+	p.setMode(common.Standby)
+	return nil
+}
+
+// ExitStandby returns the processor from standby to normal operation. The
+// command is checkpointed so it can be undone.
+func (p *Processor) ExitStandby() {
+	p.command(func() error {
+		p.exitStandby()
+		return nil
+	})
+}
+
+// exitStandby leaves standby without checkpointing
+func (p *Processor) exitStandby() {
+	if p.getMode() != common.Standby {
+		return
+	}
+	p.logger.Info("Exiting standby")
+	p.setMode(common.Normal)
+}
+
+// wake brings the processor straight from standby to combat on a detection
+func (p *Processor) wake() {
+	p.logger.Info("Detection in standby, waking to combat")
+	p.setMode(common.Combat)
+}
+
+// scanInterval returns the current scan period, which lengthens in standby
+func (p *Processor) scanInterval() time.Duration {
+	if p.getMode() == common.Standby && p.config.StandbyScanInterval > scanInterval {
+		return p.config.StandbyScanInterval
+	}
+	return scanInterval
+}
+
+// noteActivity restarts the idle timer
+func (p *Processor) noteActivity() {
+	p.activityMu.Lock()
+	defer p.activityMu.Unlock()
+	p.lastActivity = p.clock.Now()
+}
+
+// checkIdle enters standby once the processor has been idle in normal mode
+// for longer than the configured idle period
+func (p *Processor) checkIdle() {
+	if p.config.StandbyIdle <= 0 || p.getMode() != common.Normal {
+		return
+	}
+
+	p.activityMu.Lock()
+	idle := p.clock.Now().Sub(p.lastActivity)
+	p.activityMu.Unlock()
+
+	if idle >= p.config.StandbyIdle {
+		if err := p.enterStandby(); err != nil {
+			p.logger.LogError(err, "automatic standby failed")
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestIdleProcessorEntersStandby(t *testing.T) {
+	p, clock := newTestProcessor(t)
+	p.noteActivity()
+
+	clock.Advance(p.config.StandbyIdle / 2)
+	p.checkIdle()
+	if mode := p.getMode(); mode != common.Normal {
+		t.Fatalf("mode after half the idle period = %v, want normal", mode)
+	}
+
+	clock.Advance(p.config.StandbyIdle / 2)
+	p.checkIdle()
+	if mode := p.GetStatus().Mode; mode != common.Standby {
+		t.Errorf("status mode after the idle period = %v, want standby", mode)
+	}
+}
+
+func TestStandbyLengthensScanAndWakesOnDetection(t *testing.T) {
+	p, _ := newTestProcessor(t, WithSeed(4))
+	if err := p.EnterStandby(); err != nil {
+		t.Fatalf("EnterStandby: %v", err)
+	}
+	if interval := p.scanInterval(); interval <= scanInterval {
+		t.Errorf("standby scan interval = %v, want longer than %v", interval, scanInterval)
+	}
+
+	p.scanTick()
+	if mode := p.getMode(); mode != common.Combat {
+		t.Fatalf("mode after a detection in standby = %v, want combat", mode)
+	}
+	if interval := p.scanInterval(); interval != scanInterval {
+		t.Errorf("scan interval after waking = %v, want %v", interval, scanInterval)
+	}
+}
@@ -0,0 +1,44 @@
+package processor
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// StartHeadless activates the processor without its background loops, so
+// that it is driven entirely by calls to Step
+func (p *Processor) StartHeadless() error {
+	p.status.mu.Lock()
+	defer p.status.mu.Unlock()
+
+	if p.status.active {
+		return fmt.Errorf("processor already running")
+	}
+	p.status.active = true
+	return nil
+}
+
+// Step advances a headless processor by one tick of elapsed time: the
+// schedule and idle timer are checked, the area is scanned, the active
+// threat is engaged and health and power are updated. Together with
+// SetClock and SetSeed this makes runs fully reproducible.
+func (p *Processor) Step(elapsed time.Duration) {
+	p.applySchedule()
+	p.checkIdle()
+	p.scanTick()
+	p.movementTick()
+	p.healthTick(elapsed)
+}
+
+// SetSeed reseeds every random source of the processor, which also switches
+// threat IDs to a deterministic counter, for reproducible runs
+func (p *Processor) SetSeed(seed int64) {
+	p.scanner.SetSeed(seed)
+	p.anatomy.SetSeed(seed)
+}
+
+// SetLogOutput redirects the processor's log output; call it before starting
+func (p *Processor) SetLogOutput(w io.Writer) {
+	p.logger.SetOutput(w)
+}
@@ -0,0 +1,156 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"t800/internal/common"
+)
+
+// TargetingDoctrine decides which threat is considered first for engagement
+type TargetingDoctrine int
+
+const (
+	// TargetNearest engages the closest threat first, favouring survival
+	TargetNearest TargetingDoctrine = iota
+	// TargetStrongest engages the most severe threat first, favouring threat reduction
+	TargetStrongest
+	// TargetScored engages the threat with the highest threatScore first
+	TargetScored
+	// TargetImminent engages the threat rated most urgent by severity and
+	// time to impact first
+	TargetImminent
+)
+
+// assumedApproachSpeed is the closing speed in meters per second assumed for
+// threats whose motion is unknown, matching the scanner's predictions
+const assumedApproachSpeed = 10.0
+
+// ImminenceWeights balances severity against time to impact when rating
+// threats under the imminent doctrine
+type ImminenceWeights struct {
+	Severity  float64
+	Imminence float64
+	// Horizon is the time to impact at which the imminence term is halved
+	Horizon time.Duration
+}
+
+// DefaultImminenceWeights returns weights under which a medium-severity
+// threat seconds from impact outranks a high-severity one far away
+func DefaultImminenceWeights() ImminenceWeights {
+	return ImminenceWeights{
+		Severity:  1.0,
+		Imminence: 1.0,
+		Horizon:   5 * time.Second,
+	}
+}
+
+// Score rates a threat of the given severity, clamped to 1-10, expected to reach the
+// robot in timeToImpact seconds. A negative or infinite time to impact,
+// such as for a threat that is not closing, adds no imminence.
+func (w ImminenceWeights) Score(severity int, timeToImpact float64) float64 {
+	score := w.Severity * float64(common.ClampSeverity(severity)) / common.MaxSeverity
+	if timeToImpact >= 0 && !math.IsInf(timeToImpact, 1) {
+		horizon := w.Horizon.Seconds()
+		score += w.Imminence * horizon / (horizon + timeToImpact)
+	}
+	return score
+}
+
+// String returns a human readable name for the doctrine
+func (d TargetingDoctrine) String() string {
+	switch d {
+	case TargetNearest:
+		return "nearest"
+	case TargetStrongest:
+		return "strongest"
+	case TargetScored:
+		return "scored"
+	case TargetImminent:
+		return "imminent"
+	default:
+		return fmt.Sprintf("doctrine(%d)", int(d))
+	}
+}
+
+// prioritizeThreats returns the threats ordered by the configured targeting
+// doctrine; the input slice is left untouched
+func (p *Processor) prioritizeThreats(threats []*common.Threat) []*common.Threat {
+	ordered := make([]*common.Threat, len(threats))
+	copy(ordered, threats)
+
+	distance := func(threat *common.Threat) float64 {
+		return common.CalculateDistance(p.getLocation(), threat.Location)
+	}
+
+	var less func(a, b *common.Threat) bool
+	switch p.config.Targeting {
+	case TargetStrongest:
+		less = func(a, b *common.Threat) bool {
+			if a.Severity != b.Severity {
+				return a.Severity > b.Severity
+			}
+			return a.Health > b.Health
+		}
+	case TargetScored:
+		less = func(a, b *common.Threat) bool {
+			return p.threatScore(a) > p.threatScore(b)
+		}
+	case TargetImminent:
+		less = func(a, b *common.Threat) bool {
+			return p.imminenceScore(a) > p.imminenceScore(b)
+		}
+	default:
+		less = func(a, b *common.Threat) bool {
+			return distance(a) < distance(b)
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return less(ordered[i], ordered[j])
+	})
+	return ordered
+}
+
+// threatScore rates how urgently a threat should be engaged: severity
+// weighted by the threat's remaining health, discounted by distance in
+// units of the engagement distance. Threats of unknown health count as full.
+func (p *Processor) threatScore(threat *common.Threat) float64 {
+	health := threat.Health / 100
+	if health <= 0 {
+		health = 1
+	}
+	distance := common.CalculateDistance(p.getLocation(), threat.Location)
+	return float64(threat.Severity) * health / (1 + distance/p.engagementDistance)
+}
+
+// imminenceScore rates a threat by its severity and time to impact
+func (p *Processor) imminenceScore(threat *common.Threat) float64 {
+	return p.config.Imminence.Score(threat.Severity, p.timeToImpact(threat))
+}
+
+// timeToImpact estimates the seconds until a threat reaches the robot from
+// its closing speed: its velocity toward the robot, or for threats without
+// one the configured approach speed, falling back to the speed the scanner
+// assumes. Threats that are not closing never arrive.
+func (p *Processor) timeToImpact(threat *common.Threat) float64 {
+	location := p.getLocation()
+	dx, dy, dz := location.X-threat.Location.X, location.Y-threat.Location.Y, location.Z-threat.Location.Z
+	distance := math.Sqrt(dx*dx + dy*dy + dz*dz)
+	if distance == 0 {
+		return 0
+	}
+
+	closing := p.config.ThreatApproachSpeed
+	if v := threat.Velocity; v != (common.Location{}) {
+		closing = (v.X*dx + v.Y*dy + v.Z*dz) / distance
+	} else if closing <= 0 {
+		closing = assumedApproachSpeed
+	}
+	if closing <= 0 {
+		return math.Inf(1)
+	}
+	return distance / closing
+}
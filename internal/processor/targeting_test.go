@@ -0,0 +1,84 @@
+package processor
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestTargetingDoctrinePicksNearestOrStrongest(t *testing.T) {
+	for _, tc := range []struct {
+		doctrine TargetingDoctrine
+		want     string
+	}{
+		{TargetNearest, "near"},
+		{TargetStrongest, "strong"},
+	} {
+		t.Run(tc.doctrine.String(), func(t *testing.T) {
+			cfg := DefaultProcessorConfig()
+			cfg.Targeting = tc.doctrine
+			cfg.ModeHysteresis = 0
+			p, _ := newTestProcessorWithConfig(t, cfg)
+
+			near := testThreat("near", 2, common.Location{X: 20})
+			strong := testThreat("strong", 9, common.Location{X: 90})
+			if err := p.processThreatsWithAI(context.Background(), []*common.Threat{&strong, &near}); err != nil {
+				t.Fatalf("processThreatsWithAI: %v", err)
+			}
+			if active := p.GetActiveThreat(); active == nil || active.ID != tc.want {
+				t.Errorf("active threat = %v, want %s", active, tc.want)
+			}
+		})
+	}
+}
+
+func TestImminentDoctrinePrefersThreatAboutToHit(t *testing.T) {
+	for _, tc := range []struct {
+		doctrine TargetingDoctrine
+		want     string
+	}{
+		{TargetStrongest, "far"},
+		{TargetImminent, "imminent"},
+	} {
+		t.Run(tc.doctrine.String(), func(t *testing.T) {
+			cfg := DefaultProcessorConfig()
+			cfg.Targeting = tc.doctrine
+			cfg.ModeHysteresis = 0
+			p, _ := newTestProcessorWithConfig(t, cfg)
+
+			imminent := testThreat("imminent", 5, common.Location{X: 15})
+			far := testThreat("far", 9, common.Location{X: 200})
+			if err := p.processThreatsWithAI(context.Background(), []*common.Threat{&far, &imminent}); err != nil {
+				t.Fatalf("processThreatsWithAI: %v", err)
+			}
+			if active := p.GetActiveThreat(); active == nil || active.ID != tc.want {
+				t.Errorf("active threat = %v, want %s", active, tc.want)
+			}
+		})
+	}
+}
+
+func TestImminenceScore(t *testing.T) {
+	w := DefaultImminenceWeights()
+	if imminent, distant := w.Score(5, 1.5), w.Score(9, 20); imminent <= distant {
+		t.Errorf("severity 5 at 1.5s scored %.3f, severity 9 at 20s %.3f; want the imminent one higher", imminent, distant)
+	}
+	if got, want := w.Score(9, math.Inf(1)), 0.9; got != want {
+		t.Errorf("score of a threat that never arrives = %v, want severity alone %v", got, want)
+	}
+	if got, want := w.Score(9, -1), w.Score(9, math.Inf(1)); got != want {
+		t.Errorf("score with negative time to impact = %v, want %v", got, want)
+	}
+	if w.Score(15, 10) != w.Score(10, 10) {
+		t.Error("severity above the maximum was not clamped")
+	}
+
+	p, _ := newTestProcessor(t)
+	receding := testThreat("receding", 5, common.Location{X: 15})
+	receding.Velocity = common.Location{X: 3}
+	if tti := p.timeToImpact(&receding); !math.IsInf(tti, 1) {
+		t.Errorf("time to impact of a receding threat = %v, want never", tti)
+	}
+}
@@ -0,0 +1,29 @@
+package processor
+
+import (
+	"t800/internal/common"
+)
+
+// AdvanceThreats moves every tracked threat along its velocity for
+// deltaTime seconds. Threats without a velocity of their own close on the
+// robot at the configured approach speed, stopping when they reach it.
+func (p *Processor) AdvanceThreats(deltaTime float64) {
+	location := p.getLocation()
+	for _, threat := range p.threats.List() {
+		var next common.Location
+		if threat.Velocity == (common.Location{}) {
+			if p.config.ThreatApproachSpeed <= 0 {
+				continue
+			}
+			next = threat.Location.MoveTowards(location, p.config.ThreatApproachSpeed, deltaTime)
+		} else {
+			next = threat.Location.Add(threat.Velocity.Scale(deltaTime))
+		}
+
+		moved, err := p.threats.Relocate(threat.ID, next)
+		if err != nil {
+			continue
+		}
+		p.syncActiveThreat(moved)
+	}
+}
@@ -0,0 +1,43 @@
+package processor
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestAdvanceThreatsMovesAlongVelocity(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	threat := testThreat("t1", 5, common.Location{X: 40, Y: 10})
+	threat.Velocity = common.Location{X: -2}
+	p.AddThreat(threat)
+	before := common.CalculateDistance(p.getLocation(), threat.Location)
+
+	p.AdvanceThreats(5)
+	moved, _ := p.threats.Get(threat.ID)
+	if moved.Location != (common.Location{X: 30, Y: 10}) {
+		t.Errorf("threat at %+v after 5s, want (30, 10)", moved.Location)
+	}
+	if after := common.CalculateDistance(p.getLocation(), moved.Location); after >= before {
+		t.Errorf("distance after advancing = %.2f, want closer than %.2f", after, before)
+	}
+}
+
+func TestAdvanceThreatsApproachesAtConfiguredSpeed(t *testing.T) {
+	cfg := DefaultProcessorConfig()
+	cfg.ThreatApproachSpeed = 3
+	p, _ := newTestProcessorWithConfig(t, cfg)
+	threat := testThreat("t1", 5, common.Location{X: 30, Y: 40})
+	p.AddThreat(threat)
+	p.setActiveThreat(&threat)
+
+	p.AdvanceThreats(2)
+	moved, _ := p.threats.Get(threat.ID)
+	if distance := common.CalculateDistance(p.getLocation(), moved.Location); math.Abs(distance-44) > 1e-9 {
+		t.Errorf("distance after 2s at 3 m/s = %.2f, want 44", distance)
+	}
+	if active := p.GetActiveThreat(); active == nil || active.Location != moved.Location {
+		t.Errorf("active threat = %+v, want it to follow the stored threat", active)
+	}
+}
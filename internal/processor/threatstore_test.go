@@ -0,0 +1,40 @@
+package processor
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestScannerDetectionsVisibleToProcessor(t *testing.T) {
+	p, _ := newTestProcessor(t, WithSeed(4))
+
+	detected := p.scanner.ScanArea(p.getLocation())
+	if len(detected) == 0 {
+		t.Fatal("seeded scan detected nothing")
+	}
+
+	visible := make(map[string]bool)
+	for _, threat := range p.ListThreats() {
+		visible[threat.ID] = true
+	}
+	for _, threat := range detected {
+		if !visible[threat.ID] {
+			t.Errorf("scanned threat %s not in the processor's threat list", threat.ID)
+		}
+	}
+
+	p.RemoveThreat(detected[0].ID)
+	if _, exists := p.scanner.Store().Get(detected[0].ID); exists {
+		t.Errorf("threat %s removed by the processor still in the scanner's store", detected[0].ID)
+	}
+}
+
+func TestProcessorUpdatesVisibleToScanner(t *testing.T) {
+	p, _ := newTestProcessor(t)
+	p.AddThreat(testThreat("t1", 5, common.Location{X: 40}))
+
+	if _, exists := p.scanner.Store().Get("t1"); !exists {
+		t.Error("threat added by the processor not in the scanner's store")
+	}
+}
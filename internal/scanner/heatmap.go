@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"math"
+	"time"
+
+	"t800/internal/common"
+)
+
+// GridCell is a square cell of the ground plane, indexed by how many cell
+// widths it lies from the origin along X and Y
+type GridCell struct {
+	X int
+	Y int
+}
+
+// Center returns the midpoint of the cell for the given cell size
+func (c GridCell) Center(cellSize float64) common.Location {
+	return common.Location{
+		X: (float64(c.X) + 0.5) * cellSize,
+		Y: (float64(c.Y) + 0.5) * cellSize,
+	}
+}
+
+// sighting is where and when a detection was recorded
+type sighting struct {
+	at       time.Time
+	location common.Location
+}
+
+// noteSighting adds a detection to the heatmap and drops sightings older
+// than the threat TTL. The caller must hold s.mu.
+func (s *Scanner) noteSighting(location common.Location, now time.Time) {
+	s.sightings = append(s.sightings, sighting{at: now, location: location})
+	s.expireSightings(now)
+}
+
+// expireSightings drops sightings older than the threat TTL; sightings are
+// kept in the order recorded. The caller must hold s.mu.
+func (s *Scanner) expireSightings(now time.Time) {
+	expired := 0
+	for expired < len(s.sightings) && now.Sub(s.sightings[expired].at) >= s.threatTTL {
+		expired++
+	}
+	s.sightings = append(s.sightings[:0], s.sightings[expired:]...)
+}
+
+// Heatmap counts the detections of the last threat TTL in each cell of a
+// grid with the given cell size, showing where threats are concentrated.
+// Every detection counts, including repeated sightings of one threat.
+func (s *Scanner) Heatmap(cellSize float64) map[GridCell]int {
+	heatmap := make(map[GridCell]int)
+	if cellSize <= 0 {
+		return heatmap
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expireSightings(s.clock.Now())
+	for _, sighting := range s.sightings {
+		cell := GridCell{
+			X: int(math.Floor(sighting.location.X / cellSize)),
+			Y: int(math.Floor(sighting.location.Y / cellSize)),
+		}
+		heatmap[cell]++
+	}
+	return heatmap
+}
@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestHeatmapCountsClusteredDetections(t *testing.T) {
+	s := newTestScanner(t)
+	clock := common.NewManualClock(time.Unix(1000, 0))
+	s.SetClock(clock)
+	if err := s.SetThreatTTL(10 * time.Second); err != nil {
+		t.Fatalf("SetThreatTTL: %v", err)
+	}
+
+	for _, location := range []common.Location{
+		{X: 12, Y: 3}, {X: 15, Y: 8}, {X: 18, Y: 1},
+		{X: -4, Y: -6}, {X: -9, Y: -1},
+	} {
+		s.record(&common.Threat{Type: "physical", Severity: 5, Location: location})
+	}
+	clock.Advance(6 * time.Second)
+	s.record(&common.Threat{Type: "physical", Severity: 5, Location: common.Location{X: 45, Y: 22}})
+
+	want := map[GridCell]int{{X: 1, Y: 0}: 3, {X: -1, Y: -1}: 2, {X: 4, Y: 2}: 1}
+	if got := s.Heatmap(10); !reflect.DeepEqual(got, want) {
+		t.Errorf("Heatmap(10) = %v, want %v", got, want)
+	}
+	if got := s.Heatmap(100); !reflect.DeepEqual(got, map[GridCell]int{{X: 0, Y: 0}: 4, {X: -1, Y: -1}: 2}) {
+		t.Errorf("Heatmap(100) = %v", got)
+	}
+	if got := s.Heatmap(0); len(got) != 0 {
+		t.Errorf("Heatmap(0) = %v, want empty", got)
+	}
+
+	// The first cluster ages out with the threat TTL
+	clock.Advance(6 * time.Second)
+	if got := s.Heatmap(10); !reflect.DeepEqual(got, map[GridCell]int{{X: 4, Y: 2}: 1}) {
+		t.Errorf("Heatmap(10) after the TTL = %v, want only the later detection", got)
+	}
+}
+
+func TestGridCellCenter(t *testing.T) {
+	if got, want := (GridCell{X: -1, Y: 2}).Center(10), (common.Location{X: -5, Y: 25}); got != want {
+		t.Errorf("Center = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package scanner
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestCounterIDGeneratorIsSequential(t *testing.T) {
+	gen := NewCounterIDGenerator()
+	for i := 1; i <= 5; i++ {
+		if id, want := gen(), fmt.Sprintf("THREAT-%d", i); id != want {
+			t.Fatalf("ID %d = %s, want %s", i, id, want)
+		}
+	}
+
+	if id := NewCounterIDGenerator()(); id != "THREAT-1" {
+		t.Errorf("a new generator started at %s, want THREAT-1", id)
+	}
+}
+
+func TestCounterIDGeneratorIsUniqueAcrossGoroutines(t *testing.T) {
+	const workers, perWorker = 8, 100
+	gen := NewCounterIDGenerator()
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		ids = make(map[string]bool)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id := gen()
+				mu.Lock()
+				ids[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(ids) != workers*perWorker {
+		t.Errorf("got %d unique IDs, want %d", len(ids), workers*perWorker)
+	}
+}
+
+func TestScannerUsesIDGenerator(t *testing.T) {
+	s := newTestScanner(t)
+	s.SetIDGenerator(NewCounterIDGenerator())
+
+	threats := scanUntilDetected(t, s, common.Location{})
+	if threats[0].ID != "THREAT-1" {
+		t.Errorf("first detection ID = %s, want THREAT-1", threats[0].ID)
+	}
+}
+
+func TestDeterministicScannerDefaultsToCounterIDs(t *testing.T) {
+	recordID := func(s *Scanner) string {
+		return s.record(&common.Threat{Severity: 5, Location: common.Location{X: 10}}).ID
+	}
+
+	for _, tc := range []struct {
+		name    string
+		prepare func(s *Scanner)
+	}{
+		{"seeded", func(s *Scanner) { s.SetSeed(1) }},
+		{"manual clock", func(s *Scanner) { s.SetClock(common.NewManualClock(time.Unix(1000, 0))) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewScanner()
+			tc.prepare(s)
+			if id := recordID(s); id != "THREAT-1" {
+				t.Errorf("first ID = %s, want THREAT-1", id)
+			}
+		})
+	}
+
+	if id := recordID(NewScanner()); id == "THREAT-1" {
+		t.Errorf("non-deterministic scanner produced counter ID %s", id)
+	}
+
+	custom := NewScanner()
+	custom.SetIDGenerator(func() string { return "custom" })
+	custom.SetSeed(1)
+	if id := recordID(custom); id != "custom" {
+		t.Errorf("seeding replaced the custom generator: got %s", id)
+	}
+	custom.SetIDGenerator(nil)
+	// Forget the first detection so the next one is not merged into it
+	custom.PruneStale(time.Now().Add(time.Hour))
+	if id := recordID(custom); id != "THREAT-1" {
+		t.Errorf("restored default in deterministic mode gave %s, want THREAT-1", id)
+	}
+}
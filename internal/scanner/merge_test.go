@@ -0,0 +1,45 @@
+package scanner
+
+import (
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestNearbyDetectionsKeepStableID(t *testing.T) {
+	s := newTestScanner(t)
+	s.SetIDGenerator(NewCounterIDGenerator())
+
+	first := s.record(&common.Threat{Type: "physical", Severity: 4, Location: common.Location{X: 20, Y: 5}})
+	second := s.record(&common.Threat{Type: "physical", Severity: 7, Location: common.Location{X: 20.5, Y: 5.5}})
+
+	if second.ID != first.ID {
+		t.Fatalf("second detection got ID %s, want the tracked %s", second.ID, first.ID)
+	}
+	if n := len(s.Store().List()); n != 1 {
+		t.Fatalf("store holds %d threats, want 1", n)
+	}
+	stored, _ := s.Store().Get(first.ID)
+	if stored.Location != (common.Location{X: 20.5, Y: 5.5}) || stored.Severity != 7 {
+		t.Errorf("tracked threat = %+v, want the latest position and severity", stored)
+	}
+
+	distant := s.record(&common.Threat{Type: "physical", Severity: 4, Location: common.Location{X: 30, Y: 5}})
+	if distant.ID == first.ID {
+		t.Error("detection 10m away merged into the tracked threat")
+	}
+}
+
+func TestZeroMergeRadiusNeverMerges(t *testing.T) {
+	s := newTestScanner(t)
+	s.SetIDGenerator(NewCounterIDGenerator())
+	if err := s.SetMergeRadius(0); err != nil {
+		t.Fatalf("SetMergeRadius: %v", err)
+	}
+
+	first := s.record(&common.Threat{Type: "physical", Severity: 4, Location: common.Location{X: 20}})
+	second := s.record(&common.Threat{Type: "physical", Severity: 4, Location: common.Location{X: 20.5}})
+	if second.ID == first.ID {
+		t.Error("detections merged with a zero merge radius")
+	}
+}
@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestNoisePerturbsPositionsWithinBounds(t *testing.T) {
+	const stdDev = 0.5
+	s := newTestScanner(t)
+	s.SetNoise(stdDev, 0)
+
+	threats := scanUntilDetected(t, s, common.Location{})
+
+	perturbed := false
+	for _, threat := range threats {
+		// Samples lie on the range circle; noise moves each axis by at most
+		// a few standard deviations
+		offset := math.Abs(common.CalculateDistance(common.Location{}, threat.Location) - s.range_)
+		if offset > 5*stdDev*math.Sqrt(3) {
+			t.Errorf("threat %s reported %.2f meters off its true range", threat.ID, offset)
+		}
+		if offset > 1e-9 || threat.Location.Z != 0 {
+			perturbed = true
+		}
+	}
+	if !perturbed {
+		t.Error("noise left every reported position exact")
+	}
+}
+
+func TestFalsePositivesAreLowConfidence(t *testing.T) {
+	s := newTestScanner(t)
+	s.SetNoise(0, 1)
+
+	// Phantoms merged into a real detection take its confidence; the rest
+	// stay at low confidence
+	phantoms := 0
+	for _, threat := range s.ScanArea(common.Location{}) {
+		if threat.Confidence == lowConfidence {
+			phantoms++
+		}
+	}
+	if phantoms == 0 {
+		t.Error("a false positive rate of 1 produced no low-confidence detections")
+	}
+}
+
+func TestNoNoiseByDefault(t *testing.T) {
+	s := newTestScanner(t)
+	for _, threat := range s.ScanArea(common.Location{}) {
+		if offset := math.Abs(common.CalculateDistance(common.Location{}, threat.Location) - s.range_); offset > 1e-9 {
+			t.Errorf("threat %s reported %.2f meters off its true range without noise", threat.ID, offset)
+		}
+	}
+}
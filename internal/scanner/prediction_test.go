@@ -0,0 +1,31 @@
+package scanner
+
+import "testing"
+
+func TestLowerPredictionThresholdConvertsMorePredictions(t *testing.T) {
+	s := newTestScanner(t)
+	probabilities := []float64{0.4, 0.55, 0.6, 0.65, 0.75, 0.9}
+
+	converted := func() int {
+		count := 0
+		for _, probability := range probabilities {
+			if s.acceptsPrediction(&ThreatPrediction{Probability: probability}) {
+				count++
+			}
+		}
+		return count
+	}
+
+	if got := converted(); got != 2 {
+		t.Errorf("default threshold converted %d predictions, want 2", got)
+	}
+	if err := s.SetPredictionThreshold(0.5); err != nil {
+		t.Fatalf("SetPredictionThreshold: %v", err)
+	}
+	if got := converted(); got != 5 {
+		t.Errorf("threshold 0.5 converted %d predictions, want 5", got)
+	}
+	if err := s.SetPredictionThreshold(1.5); err == nil {
+		t.Error("threshold above 1 accepted")
+	}
+}
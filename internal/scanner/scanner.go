@@ -4,19 +4,78 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
-	"time"
+	"sync"
+	"sync/atomic"
 	"t800/internal/common"
+	"t800/internal/config"
+	"time"
 )
 
+// lowConfidence is the confidence assigned to spurious detections
+const lowConfidence = 0.2
+
+// detectedThreatHealth is the health pool assigned to newly detected threats
+const detectedThreatHealth = 100.0
+
+// defaultMergeRadius is the distance within which a detection is taken to be
+// an already tracked threat
+const defaultMergeRadius = 2.0
+
+// defaultThreatTTL is how long a detection stays live without being re-detected
+const defaultThreatTTL = 30 * time.Second
+
 // Scanner represents the threat detection system
 type Scanner struct {
-	range_      float64
-	resolution  float64
-	lastScan    time.Time
-	activeRange map[string]*common.Threat
-	predictions map[string]*ThreatPrediction
+	range_     float64
+	resolution float64
+	lastScan   time.Time
+	store      *common.ThreatStore
+	rng        *rand.Rand
+	idGen      func() string
+	clock      common.Clock
+
+	// A seeded scanner or one on a manual clock is deterministic and, unless
+	// given its own ID generator, numbers threats with a counter
+	deterministic bool
+	customIDGen   bool
+
+	// Detections are purged from the store once unseen for threatTTL
+	mu          sync.Mutex
+	lastSeen    map[string]time.Time
+	threatTTL   time.Duration
+	mergeRadius float64
+	sightings   []sighting // Recent detections for the heatmap, oldest first
+
+	// predictionThreshold is the minimum probability for a prediction to become a threat
+	predictionThreshold float64
+
+	// Directional scans cover fovDegrees about the heading
+	mode       ScanMode
+	fovDegrees float64
+
+	// Sensor noise simulation
+	posStdDev         float64
+	falsePositiveRate float64
 }
 
+// ScanMode selects how much of the surroundings each scan covers
+type ScanMode int
+
+const (
+	// ScanFull sweeps all 360 degrees
+	ScanFull ScanMode = iota
+	// ScanDirectional covers only a field of view about the heading
+	ScanDirectional
+)
+
+// areaScanStep is the angle in degrees between the samples of a full sweep
+const areaScanStep = 10.0
+
+// maxSweepSamples bounds the sensor reads a cone scan may spend on a full
+// circle, so a fine resolution at long range cannot make a single scan
+// unboundedly expensive
+const maxSweepSamples = 360
+
 // ThreatPrediction represents a predicted threat
 type ThreatPrediction struct {
 	Location     common.Location
@@ -25,71 +84,365 @@ type ThreatPrediction struct {
 	Severity     int
 }
 
-// NewScanner creates a new scanner system
+// NewScanner creates a new scanner system with its own threat store
 func NewScanner() *Scanner {
+	return NewScannerWithStore(common.NewThreatStore())
+}
+
+// NewScannerWithStore creates a new scanner that records detections into a shared threat store
+func NewScannerWithStore(store *common.ThreatStore) *Scanner {
 	return &Scanner{
 		range_:      100.0, // 100 meter range
 		resolution:  0.1,   // 10cm resolution
-		activeRange: make(map[string]*common.Threat),
-		predictions: make(map[string]*ThreatPrediction),
+		store:       store,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		idGen:       TimestampIDGenerator,
+		clock:       common.RealClock{},
+		lastSeen:    make(map[string]time.Time),
+		threatTTL:   defaultThreatTTL,
+		mergeRadius: defaultMergeRadius,
+
+		predictionThreshold: 0.7,
+	}
+}
+
+// NewScannerFromConfig creates a scanner recording into a shared threat
+// store, tuned by the given settings
+func NewScannerFromConfig(store *common.ThreatStore, cfg config.ScannerSettings) (*Scanner, error) {
+	if cfg.Range <= 0 || cfg.Resolution <= 0 {
+		return nil, fmt.Errorf("scanner range and resolution must be positive")
+	}
+	s := NewScannerWithStore(store)
+	s.range_ = cfg.Range
+	s.resolution = cfg.Resolution
+	if err := s.SetPredictionThreshold(cfg.PredictionThreshold); err != nil {
+		return nil, err
+	}
+	if err := s.SetMergeRadius(cfg.MergeRadius); err != nil {
+		return nil, err
+	}
+	if cfg.FieldOfView > 0 && cfg.FieldOfView < 360 {
+		if err := s.SetScanMode(ScanDirectional, cfg.FieldOfView); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// SetPredictionThreshold sets the minimum probability (0-1) at which a
+// predicted threat is reported as a threat
+func (s *Scanner) SetPredictionThreshold(threshold float64) error {
+	if threshold < 0 || threshold > 1 {
+		return fmt.Errorf("prediction threshold must be between 0 and 1")
+	}
+	s.predictionThreshold = threshold
+	return nil
+}
+
+// SetIDGenerator replaces the function used to assign IDs to new threats.
+// Passing nil restores the default: a counter in deterministic mode, the
+// timestamp-based generator otherwise.
+func (s *Scanner) SetIDGenerator(gen func() string) {
+	s.customIDGen = gen != nil
+	if gen == nil {
+		gen = TimestampIDGenerator
+		if s.deterministic {
+			gen = NewCounterIDGenerator()
+		}
+	}
+	s.idGen = gen
+}
+
+// setDeterministic switches the scanner to deterministic mode, numbering
+// new threats with a counter unless it was given its own ID generator
+func (s *Scanner) setDeterministic() {
+	if s.deterministic {
+		return
+	}
+	s.deterministic = true
+	if !s.customIDGen {
+		s.idGen = NewCounterIDGenerator()
+	}
+}
+
+// Store returns the threat store the scanner records detections into
+func (s *Scanner) Store() *common.ThreatStore {
+	return s.store
+}
+
+// SetClock replaces the clock used to timestamp scans and detections. A
+// manual clock puts the scanner in deterministic mode.
+func (s *Scanner) SetClock(clock common.Clock) {
+	s.clock = clock
+	if _, manual := clock.(*common.ManualClock); manual {
+		s.setDeterministic()
+	}
+}
+
+// SetThreatTTL sets how long a detection stays live without being re-detected
+func (s *Scanner) SetThreatTTL(ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("threat TTL must be positive")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.threatTTL = ttl
+	return nil
+}
+
+// SetMergeRadius sets the distance in meters within which a detection is
+// treated as an already tracked threat rather than a new one
+func (s *Scanner) SetMergeRadius(radius float64) error {
+	if radius < 0 {
+		return fmt.Errorf("merge radius must not be negative")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mergeRadius = radius
+	return nil
+}
+
+// SetSeed reseeds the scanner's random source for reproducible scans and
+// puts the scanner in deterministic mode
+func (s *Scanner) SetSeed(seed int64) {
+	s.rng = rand.New(rand.NewSource(seed))
+	s.setDeterministic()
+}
+
+// SetNoise configures simulated sensor noise. posStdDev is the standard
+// deviation in meters applied to each reported coordinate and
+// falsePositiveRate is the per-sample chance of a phantom detection.
+func (s *Scanner) SetNoise(posStdDev, falsePositiveRate float64) {
+	s.posStdDev = max(0, posStdDev)
+	s.falsePositiveRate = min(1, max(0, falsePositiveRate))
+}
+
+// SetScanMode chooses between full sweeps and directional scans covering
+// fovDegrees about the robot's heading
+func (s *Scanner) SetScanMode(mode ScanMode, fovDegrees float64) error {
+	if mode == ScanDirectional && (fovDegrees <= 0 || fovDegrees > 360) {
+		return fmt.Errorf("field of view must be between 0 and 360 degrees")
+	}
+	s.mode = mode
+	s.fovDegrees = fovDegrees
+	return nil
+}
+
+// Scan scans according to the scan mode: a full sweep, or a cone about the
+// heading (radians, counterclockwise from the X axis)
+func (s *Scanner) Scan(currentLocation common.Location, heading float64) []*common.Threat {
+	if s.mode == ScanDirectional {
+		return s.ScanCone(currentLocation, heading, s.fovDegrees)
 	}
+	return s.ScanArea(currentLocation)
+}
+
+// angularStep returns the angle in degrees between cone scan samples: the
+// angle one resolution cell subtends at the edge of the range, so
+// neighbouring samples are never further apart than the resolution, but no
+// finer than maxSweepSamples allows
+func (s *Scanner) angularStep() float64 {
+	return max(360.0/maxSweepSamples, s.resolution/s.range_*180/math.Pi)
 }
 
 // ScanArea performs a 360-degree scan of the surrounding area
 func (s *Scanner) ScanArea(currentLocation common.Location) []*common.Threat {
-	s.lastScan = time.Now()
+	s.lastScan = s.clock.Now()
 	threats := make([]*common.Threat, 0)
+	for angle := 0.0; angle < 360.0; angle += areaScanStep {
+		threats = append(threats, s.sample(currentLocation, angle)...)
+	}
+	return threats
+}
+
+// ScanCone scans only within fovDegrees centered on heading (radians,
+// counterclockwise from the X axis); threats outside the cone go undetected
+func (s *Scanner) ScanCone(origin common.Location, heading float64, fovDegrees float64) []*common.Threat {
+	if fovDegrees >= 360 {
+		return s.ScanArea(origin)
+	}
+	s.lastScan = s.clock.Now()
+	threats := make([]*common.Threat, 0)
+	if fovDegrees <= 0 {
+		return threats
+	}
+
+	center := heading * 180 / math.Pi
+	step := s.angularStep()
+	for offset := -fovDegrees / 2; offset <= fovDegrees/2; offset += step {
+		threats = append(threats, s.sample(origin, center+offset)...)
+	}
+	return threats
+}
+
+// sample looks for threats along the bearing angle (degrees) at range
+func (s *Scanner) sample(currentLocation common.Location, angle float64) []*common.Threat {
+	var threats []*common.Threat
 
 	// Simulate finding threats in the area
 	// This is where you would integrate with actual sensors
-	for angle := 0.0; angle < 360.0; angle += 10.0 {
-		// Convert angle to radians
-		rad := angle * math.Pi / 180.0
-
-		// Calculate potential threat position
-		threatLoc := common.Location{
-			X: currentLocation.X + s.range_*math.Cos(rad),
-			Y: currentLocation.Y + s.range_*math.Sin(rad),
-			Z: currentLocation.Z,
+	rad := angle * math.Pi / 180.0
+
+	// Calculate potential threat position
+	threatLoc := common.Location{
+		X: currentLocation.X + s.range_*math.Cos(rad),
+		Y: currentLocation.Y + s.range_*math.Sin(rad),
+		Z: currentLocation.Z,
+	}
+
+	// Check for actual threats
+	if s.detectThreat(threatLoc) {
+		threat := &common.Threat{
+			Type:       "unknown",
+			Location:   s.applyNoise(threatLoc),
+			Severity:   calculateThreatLevel(threatLoc, currentLocation),
+			Timestamp:  s.clock.Now().Unix(),
+			Health:     detectedThreatHealth,
+			Confidence: 1.0,
 		}
+		threats = append(threats, s.record(threat))
+	}
 
-		// Check for actual threats
-		if s.detectThreat(threatLoc) {
-			threat := &common.Threat{
-				ID:        generateThreatID(),
-				Type:      "unknown",
-				Location:  threatLoc,
-				Severity:  calculateThreatLevel(threatLoc, currentLocation),
-				Timestamp: time.Now().Unix(),
-			}
-			threats = append(threats, threat)
-			s.activeRange[threat.ID] = threat
+	// Simulate phantom detections from sensor noise
+	if s.falsePositiveRate > 0 && s.rng.Float64() < s.falsePositiveRate {
+		threat := &common.Threat{
+			Type:        "unknown",
+			Location:    s.applyNoise(threatLoc),
+			Severity:    calculateThreatLevel(threatLoc, currentLocation),
+			Timestamp:   s.clock.Now().Unix(),
+			Description: "low-confidence detection",
+			Health:      detectedThreatHealth,
+			Confidence:  lowConfidence,
 		}
+		threats = append(threats, s.record(threat))
+	}
 
-		// Check for potential threats
-		if prediction := s.predictThreat(threatLoc, currentLocation); prediction != nil {
-			// Convert prediction to threat if probability is high enough
-			if prediction.Probability > 0.7 {
-				threat := &common.Threat{
-					ID:        generateThreatID(),
-					Type:      "predicted",
-					Location:  prediction.Location,
-					Severity:  prediction.Severity,
-					Timestamp: time.Now().Unix(),
-				}
-				threats = append(threats, threat)
-				s.activeRange[threat.ID] = threat
-			}
+	// Check for potential threats
+	// Convert prediction to threat if probability is high enough
+	if prediction := s.predictThreat(threatLoc, currentLocation); prediction != nil && s.acceptsPrediction(prediction) {
+		threat := &common.Threat{
+			Type:       "predicted",
+			Location:   s.applyNoise(prediction.Location),
+			Severity:   prediction.Severity,
+			Timestamp:  s.clock.Now().Unix(),
+			Health:     detectedThreatHealth,
+			Confidence: prediction.Probability,
 		}
+		threats = append(threats, s.record(threat))
 	}
 
 	return threats
 }
 
+// record adds a detection to the store and marks it as seen now. A
+// detection within the merge radius of a live tracked threat is that threat:
+// it keeps its ID and health and takes the new position and severity.
+// Otherwise the detection is assigned a new ID.
+func (s *Scanner) record(threat *common.Threat) *common.Threat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	threat.Severity = common.ClampSeverity(threat.Severity)
+	recorded := false
+	if tracked, ok := s.nearestTracked(threat.Location, now); ok {
+		sighting := *threat
+		if sighting.Type == "predicted" {
+			sighting.Type, sighting.Description = "", ""
+		}
+		if observed, err := s.store.Observe(tracked.ID, sighting); err == nil {
+			threat, recorded = &observed, true
+		}
+	}
+	if !recorded {
+		threat.ID = s.idGen()
+		s.store.Add(*threat)
+	}
+
+	s.lastSeen[threat.ID] = now
+	s.noteSighting(threat.Location, now)
+	return threat
+}
+
+// nearestTracked returns the closest live tracked threat within the merge
+// radius of a location. The caller must hold s.mu.
+func (s *Scanner) nearestTracked(loc common.Location, now time.Time) (common.Threat, bool) {
+	var (
+		nearest common.Threat
+		found   bool
+	)
+	closest := s.mergeRadius
+	for id, seen := range s.lastSeen {
+		if now.Sub(seen) >= s.threatTTL {
+			continue
+		}
+		tracked, exists := s.store.Get(id)
+		if !exists {
+			continue
+		}
+		distance := common.CalculateDistance(loc, tracked.Location)
+		if distance > closest || (found && distance == closest && id > nearest.ID) {
+			continue
+		}
+		nearest, closest, found = tracked, distance, true
+	}
+	return nearest, found
+}
+
+// PruneStale purges every detection not re-detected within the threat TTL
+// from the store and returns the IDs removed. Detections already removed
+// from the store, such as eliminated threats, are forgotten.
+func (s *Scanner) PruneStale(now time.Time) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pruned []string
+	for id, seen := range s.lastSeen {
+		if _, exists := s.store.Get(id); !exists {
+			delete(s.lastSeen, id)
+			continue
+		}
+		if now.Sub(seen) < s.threatTTL {
+			continue
+		}
+		s.store.Remove(id)
+		delete(s.lastSeen, id)
+		pruned = append(pruned, id)
+	}
+	return pruned
+}
+
+// ActiveThreats returns the live detections, those seen within the threat TTL
+func (s *Scanner) ActiveThreats() []common.Threat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	var active []common.Threat
+	for _, threat := range s.store.List() {
+		if seen, ok := s.lastSeen[threat.ID]; ok && now.Sub(seen) < s.threatTTL {
+			active = append(active, threat)
+		}
+	}
+	return active
+}
+
 // detectThreat simulates threat detection (replace with actual sensor logic)
 func (s *Scanner) detectThreat(loc common.Location) bool {
 	// Simulate random threat detection (5% chance)
-	return rand.Float64() < 0.05
+	return s.rng.Float64() < 0.05
+}
+
+// applyNoise jitters a location with Gaussian noise on each axis
+func (s *Scanner) applyNoise(loc common.Location) common.Location {
+	if s.posStdDev == 0 {
+		return loc
+	}
+	return common.Location{
+		X: loc.X + s.rng.NormFloat64()*s.posStdDev,
+		Y: loc.Y + s.rng.NormFloat64()*s.posStdDev,
+		Z: loc.Z + s.rng.NormFloat64()*s.posStdDev,
+	}
 }
 
 // calculateThreatLevel determines threat severity based on distance
@@ -107,10 +460,16 @@ func calculateThreatLevel(threatLoc, currentLoc common.Location) int {
 	return 1 // Low
 }
 
+// acceptsPrediction reports whether a prediction is likely enough to be
+// reported as a threat
+func (s *Scanner) acceptsPrediction(prediction *ThreatPrediction) bool {
+	return prediction.Probability > s.predictionThreshold
+}
+
 // predictThreat analyzes a location for potential threats
 func (s *Scanner) predictThreat(loc, currentLoc common.Location) *ThreatPrediction {
 	// Simulate threat prediction logic
-	if rand.Float64() < 0.1 { // 10% chance of predicting a threat
+	if s.rng.Float64() < 0.1 { // 10% chance of predicting a threat
 		distance := common.CalculateDistance(loc, currentLoc)
 		probability := 1.0 - (distance / s.range_)
 		timeToImpact := distance / 10.0 // Assuming 10m/s movement speed
@@ -126,12 +485,16 @@ func (s *Scanner) predictThreat(loc, currentLoc common.Location) *ThreatPredicti
 	return nil
 }
 
-// Helper functions
-func generateThreatID() string {
+// TimestampIDGenerator returns a threat ID derived from the current time
+func TimestampIDGenerator() string {
 	return fmt.Sprintf("THREAT-%d", time.Now().UnixNano())
 }
 
-func init() {
-	// Initialize random seed
-	rand.Seed(time.Now().UnixNano())
-} 
\ No newline at end of file
+// NewCounterIDGenerator returns a deterministic generator producing
+// sequential IDs THREAT-1, THREAT-2, ... that are unique across goroutines
+func NewCounterIDGenerator() func() string {
+	var counter atomic.Uint64
+	return func() string {
+		return fmt.Sprintf("THREAT-%d", counter.Add(1))
+	}
+}
@@ -0,0 +1,104 @@
+package scanner
+
+import (
+	"math"
+	"testing"
+
+	"t800/internal/common"
+	"t800/internal/config"
+)
+
+// newTestScanner returns a scanner with a fixed random source
+func newTestScanner(t *testing.T) *Scanner {
+	t.Helper()
+	s := NewScanner()
+	s.SetSeed(1)
+	return s
+}
+
+// scanUntilDetected repeats full sweeps from origin until one detects
+// something, since a single 36-sample sweep often finds nothing
+func scanUntilDetected(t *testing.T, s *Scanner, origin common.Location) []*common.Threat {
+	t.Helper()
+	for i := 0; i < 50; i++ {
+		if threats := s.ScanArea(origin); len(threats) > 0 {
+			return threats
+		}
+	}
+	t.Fatal("repeated scans detected nothing")
+	return nil
+}
+
+func TestAngularStepFollowsResolution(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		resolution  float64
+		scanRange   float64
+		wantDegrees float64
+	}{
+		{"coarse", 5, 100, 5.0 / 100 * 180 / math.Pi},
+		{"fine", 1, 20, 1.0 / 20 * 180 / math.Pi},
+		{"finer than the sample budget", 0.1, 100, 360.0 / maxSweepSamples},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewScannerFromConfig(common.NewThreatStore(), config.ScannerSettings{
+				Range:               tc.scanRange,
+				Resolution:          tc.resolution,
+				PredictionThreshold: 0.7,
+				MergeRadius:         defaultMergeRadius,
+			})
+			if err != nil {
+				t.Fatalf("NewScannerFromConfig: %v", err)
+			}
+			if got := s.angularStep(); math.Abs(got-tc.wantDegrees) > 1e-9 {
+				t.Errorf("angularStep = %v, want %v", got, tc.wantDegrees)
+			}
+		})
+	}
+}
+
+func TestScanAreaSamplesEveryTenDegrees(t *testing.T) {
+	s := newTestScanner(t)
+	s.posStdDev = 0
+	origin := common.Location{X: 10, Y: -5}
+
+	for _, threat := range scanUntilDetected(t, s, origin) {
+		degrees := common.Bearing(origin, threat.Location) * 180 / math.Pi
+		if offset := math.Abs(degrees - areaScanStep*math.Round(degrees/areaScanStep)); offset > 1e-6 {
+			t.Errorf("threat %s at bearing %.2f degrees, off the %v degree sweep", threat.ID, degrees, areaScanStep)
+		}
+	}
+}
+
+func TestScanConeOnlyReturnsThreatsInsideFOV(t *testing.T) {
+	s := newTestScanner(t)
+	origin := common.Location{X: 10, Y: -5}
+	heading := math.Pi / 2
+
+	threats := s.ScanCone(origin, heading, 60)
+	if len(threats) == 0 {
+		t.Fatal("ScanCone detected nothing inside the field of view")
+	}
+	for _, threat := range threats {
+		bearing := common.Bearing(origin, threat.Location)
+		if offset := math.Abs(common.NormalizeAngle(bearing-heading)) * 180 / math.Pi; offset > 30+1e-6 {
+			t.Errorf("threat %s at %.1f degrees off the heading, outside the 60 degree cone", threat.ID, offset)
+		}
+	}
+}
+
+func TestRecordedSeverityIsClamped(t *testing.T) {
+	s := newTestScanner(t)
+	for _, tc := range []struct {
+		severity, want int
+		location       common.Location
+	}{
+		{50, common.MaxSeverity, common.Location{X: 10}},
+		{-2, common.MinSeverity, common.Location{X: 60}},
+	} {
+		threat := common.Threat{Severity: tc.severity, Location: tc.location, Health: 100}
+		if got := s.record(&threat); got.Severity != tc.want {
+			t.Errorf("detection with severity %d recorded as %d, want %d", tc.severity, got.Severity, tc.want)
+		}
+	}
+}
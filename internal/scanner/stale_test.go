@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"testing"
+	"time"
+
+	"t800/internal/common"
+)
+
+func TestPruneStaleRemovesUnseenThreats(t *testing.T) {
+	s := newTestScanner(t)
+	clock := common.NewManualClock(time.Unix(1000, 0))
+	s.SetClock(clock)
+	if err := s.SetThreatTTL(10 * time.Second); err != nil {
+		t.Fatalf("SetThreatTTL: %v", err)
+	}
+
+	old := s.record(&common.Threat{Type: "physical", Severity: 5, Location: common.Location{X: 10}})
+	clock.Advance(6 * time.Second)
+	fresh := s.record(&common.Threat{Type: "physical", Severity: 5, Location: common.Location{X: 50}})
+	clock.Advance(6 * time.Second)
+
+	if active := s.ActiveThreats(); len(active) != 1 || active[0].ID != fresh.ID {
+		t.Errorf("ActiveThreats = %+v, want only %s", active, fresh.ID)
+	}
+	pruned := s.PruneStale(clock.Now())
+	if len(pruned) != 1 || pruned[0] != old.ID {
+		t.Fatalf("PruneStale = %v, want [%s]", pruned, old.ID)
+	}
+	if _, exists := s.Store().Get(old.ID); exists {
+		t.Error("stale threat still in the store")
+	}
+	if _, exists := s.Store().Get(fresh.ID); !exists {
+		t.Error("live threat pruned")
+	}
+	if _, tracked := s.lastSeen[old.ID]; tracked {
+		t.Error("stale threat still tracked as seen")
+	}
+
+	clock.Advance(10 * time.Second)
+	if pruned := s.PruneStale(clock.Now()); len(pruned) != 1 || pruned[0] != fresh.ID {
+		t.Errorf("PruneStale = %v, want [%s]", pruned, fresh.ID)
+	}
+	if n := len(s.lastSeen); n != 0 {
+		t.Errorf("%d threats still tracked after all went stale", n)
+	}
+}
+
+func TestPruneStaleForgetsRemovedThreats(t *testing.T) {
+	s := newTestScanner(t)
+	clock := common.NewManualClock(time.Unix(1000, 0))
+	s.SetClock(clock)
+
+	threat := s.record(&common.Threat{Type: "physical", Severity: 5, Location: common.Location{X: 10}})
+	s.Store().Remove(threat.ID)
+
+	if pruned := s.PruneStale(clock.Now()); len(pruned) != 0 {
+		t.Errorf("PruneStale = %v, want nothing reported for an eliminated threat", pruned)
+	}
+	if _, tracked := s.lastSeen[threat.ID]; tracked {
+		t.Error("eliminated threat still tracked as seen")
+	}
+}
@@ -0,0 +1,125 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"t800/internal/common"
+	"t800/internal/processor"
+)
+
+// simEpoch is the simulated start time used when none is configured
+var simEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// SimConfig describes a headless simulation run
+type SimConfig struct {
+	Seed         int64
+	Steps        int
+	StepInterval time.Duration // Simulated time per step
+	Start        time.Time     // Simulated start time; zero uses a fixed epoch
+	Processor    processor.ProcessorConfig
+	Scenario     []ScenarioThreat
+	Obstacles    []common.Obstacle
+	Hazards      []processor.Hazard
+}
+
+// ScenarioThreat is a threat reported to the processor before a given step
+type ScenarioThreat struct {
+	Step   int
+	Threat common.Threat
+}
+
+// SimResult aggregates the outcome of a simulation run
+type SimResult struct {
+	Steps             int
+	ThreatsEliminated int
+	ThreatsRemaining  int
+	Integrity         float64 // Average health of all parts (0-100)
+	PowerRemaining    float64
+	AmmoRemaining     map[string]int
+	Decisions         int
+	FinalMode         common.OperationMode
+}
+
+// DefaultSimConfig returns a simulation configuration using the default
+// processor configuration and 100ms steps
+func DefaultSimConfig() SimConfig {
+	return SimConfig{
+		Steps:        100,
+		StepInterval: 100 * time.Millisecond,
+		Processor:    processor.DefaultProcessorConfig(),
+	}
+}
+
+// Simulate builds a processor, feeds it the scenario and runs it headlessly
+// for the configured number of steps on a simulated clock. Runs with the
+// same configuration produce identical results.
+func Simulate(cfg SimConfig) (SimResult, error) {
+	if cfg.Steps <= 0 {
+		return SimResult{}, fmt.Errorf("invalid step count: %d", cfg.Steps)
+	}
+	if cfg.StepInterval <= 0 {
+		return SimResult{}, fmt.Errorf("invalid step interval: %s", cfg.StepInterval)
+	}
+	start := cfg.Start
+	if start.IsZero() {
+		start = simEpoch
+	}
+
+	proc, err := processor.NewProcessorWithConfig(context.Background(), cfg.Processor)
+	if err != nil {
+		return SimResult{}, fmt.Errorf("failed to create processor: %v", err)
+	}
+	defer proc.Stop(context.Background())
+
+	clock := common.NewManualClock(start)
+	proc.SetLogOutput(io.Discard)
+	proc.SetClock(clock)
+	proc.SetSeed(cfg.Seed)
+	proc.SetObstacles(cfg.Obstacles)
+	proc.SetHazards(cfg.Hazards)
+	if err := proc.StartHeadless(); err != nil {
+		return SimResult{}, err
+	}
+
+	arrivals := make(map[int][]common.Threat)
+	for _, scripted := range cfg.Scenario {
+		arrivals[scripted.Step] = append(arrivals[scripted.Step], scripted.Threat)
+	}
+
+	for step := 0; step < cfg.Steps; step++ {
+		for _, threat := range arrivals[step] {
+			if err := proc.ReportThreat(threat); err != nil {
+				return SimResult{}, fmt.Errorf("step %d: failed to report %s: %v", step, threat.ID, err)
+			}
+		}
+		proc.Step(cfg.StepInterval)
+		clock.Advance(cfg.StepInterval)
+	}
+
+	return summarize(proc.Metrics(), cfg.Steps), nil
+}
+
+// summarize reduces the processor's final metrics to a simulation result
+func summarize(metrics processor.ProcessorMetrics, steps int) SimResult {
+	var integrity float64
+	for _, health := range metrics.Health {
+		integrity += health
+	}
+	if len(metrics.Health) > 0 {
+		integrity /= float64(len(metrics.Health))
+	}
+
+	return SimResult{
+		Steps:             steps,
+		ThreatsEliminated: metrics.ThreatsEliminated,
+		ThreatsRemaining:  metrics.ThreatCount,
+		Integrity:         integrity,
+		PowerRemaining:    metrics.PowerLevel,
+		AmmoRemaining:     metrics.Ammo,
+		Decisions:         metrics.Decisions,
+		FinalMode:         metrics.Mode,
+	}
+}
@@ -0,0 +1,42 @@
+package simulation
+
+import (
+	"reflect"
+	"testing"
+
+	"t800/internal/common"
+)
+
+func TestSimulateIsDeterministic(t *testing.T) {
+	cfg := DefaultSimConfig()
+	cfg.Seed = 42
+	cfg.Steps = 60
+	cfg.Scenario = []ScenarioThreat{
+		{Step: 0, Threat: common.Threat{ID: "alpha", Type: "physical", Severity: 7, Health: 100, Location: common.Location{X: 30}}},
+		{Step: 10, Threat: common.Threat{ID: "bravo", Type: "electronic", Severity: 5, Health: 150, Location: common.Location{X: -20, Y: 25},
+			Velocity: common.Location{X: 1}}},
+	}
+
+	first, err := Simulate(cfg)
+	if err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	second, err := Simulate(cfg)
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("runs differ:\n%+v\n%+v", first, second)
+	}
+	if first.Decisions == 0 {
+		t.Errorf("result %+v records no decisions, want the scenario engaged", first)
+	}
+}
+
+func TestSimulateRejectsInvalidConfig(t *testing.T) {
+	cfg := DefaultSimConfig()
+	cfg.Steps = 0
+	if _, err := Simulate(cfg); err == nil {
+		t.Error("Simulate accepted zero steps")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
@@ -9,15 +10,31 @@ import (
 	"time"
 
 	"t800/internal/common"
+	"t800/internal/config"
 	"t800/internal/processor"
 )
 
+// shutdownTimeout bounds how long shutdown waits for in-flight work to drain
+const shutdownTimeout = 5 * time.Second
+
 func main() {
+	configPath := flag.String("config", "", "path to a JSON config file")
+	flag.Parse()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Create processor
-	proc, err := processor.NewProcessor(ctx)
+	// Create processor, tuned by the config file if one is given
+	var proc *processor.Processor
+	var err error
+	if *configPath != "" {
+		var cfg *config.Config
+		if cfg, err = config.LoadConfig(*configPath); err == nil {
+			proc, err = processor.NewProcessorFromConfig(ctx, cfg)
+		}
+	} else {
+		proc, err = processor.NewProcessorWithAI(ctx)
+	}
 	if err != nil {
 		fmt.Printf("Error creating processor: %v\n", err)
 		os.Exit(1)
@@ -84,7 +101,9 @@ func main() {
 	}
 
 	// Stop the processor
-	if err := proc.Stop(); err != nil {
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+	if err := proc.Stop(shutdownCtx); err != nil {
 		fmt.Printf("Error stopping processor: %v\n", err)
 	}
-} 
\ No newline at end of file
+}